@@ -0,0 +1,92 @@
+// Package discordsig verifies the Ed25519 signatures Discord attaches to
+// interaction webhook requests, against a rotating set of application
+// public keys.
+package discordsig
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KeyProvider supplies the current set of valid Discord application public
+// keys to verify a signature against. keystore.Store satisfies this.
+type KeyProvider interface {
+	Keys() []ed25519.PublicKey
+}
+
+// staticKeys is a KeyProvider over a fixed key set, for callers (and tests)
+// that don't need rotation.
+type staticKeys []ed25519.PublicKey
+
+func (k staticKeys) Keys() []ed25519.PublicKey { return k }
+
+// StaticKeys wraps a fixed set of public keys as a KeyProvider.
+func StaticKeys(keys ...ed25519.PublicKey) KeyProvider {
+	return staticKeys(keys)
+}
+
+// Verifier checks the X-Signature-Ed25519 / X-Signature-Timestamp headers
+// Discord sends against Keys, rejecting requests whose timestamp has
+// drifted more than Skew from Clock().
+type Verifier struct {
+	Keys KeyProvider
+
+	// Skew is the maximum allowed difference between the request's
+	// timestamp and Clock(). Defaults to 5 seconds, matching Discord's
+	// documented replay window.
+	Skew time.Duration
+
+	// Clock returns the current time. Defaults to time.Now; tests override
+	// it to exercise expiry without sleeping.
+	Clock func() time.Time
+}
+
+// New creates a Verifier with the default 5-second skew and time.Now clock.
+func New(keys KeyProvider) *Verifier {
+	return &Verifier{Keys: keys, Skew: 5 * time.Second, Clock: time.Now}
+}
+
+// Verify reports whether r carries a valid, fresh Discord signature over
+// body under any key Keys currently returns.
+func (v *Verifier) Verify(r *http.Request, body []byte) bool {
+	signature := strings.TrimSpace(r.Header.Get("X-Signature-Ed25519"))
+	timestamp := strings.TrimSpace(r.Header.Get("X-Signature-Timestamp"))
+
+	if signature == "" || timestamp == "" {
+		return false
+	}
+
+	sigBytes, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	clock := v.Clock
+	if clock == nil {
+		clock = time.Now
+	}
+	skew := v.Skew
+	if skew == 0 {
+		skew = 5 * time.Second
+	}
+	if age := clock().Unix() - ts; age > int64(skew.Seconds()) || age < -int64(skew.Seconds()) {
+		return false
+	}
+
+	message := append([]byte(timestamp), body...)
+	for _, key := range v.Keys.Keys() {
+		if ed25519.Verify(key, message, sigBytes) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package discordsig
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sign(priv ed25519.PrivateKey, timestamp string, body []byte) string {
+	message := append([]byte(timestamp), body...)
+	return hex.EncodeToString(ed25519.Sign(priv, message))
+}
+
+func request(signature, timestamp string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("X-Signature-Ed25519", signature)
+	r.Header.Set("X-Signature-Timestamp", timestamp)
+	return r
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	_, otherPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	body := []byte(`{"type":1}`)
+	now := time.Unix(1700000000, 0)
+	nowTimestamp := strconv.FormatInt(now.Unix(), 10)
+	clock := func() time.Time { return now }
+
+	tests := []struct {
+		name      string
+		signature string
+		timestamp string
+		want      bool
+	}{
+		{
+			name:      "valid signature",
+			signature: sign(priv, nowTimestamp, body),
+			timestamp: nowTimestamp,
+			want:      true,
+		},
+		{
+			name:      "wrong key",
+			signature: sign(otherPriv, nowTimestamp, body),
+			timestamp: nowTimestamp,
+			want:      false,
+		},
+		{
+			name:      "expired timestamp",
+			signature: sign(priv, strconv.FormatInt(now.Add(-time.Minute).Unix(), 10), body),
+			timestamp: strconv.FormatInt(now.Add(-time.Minute).Unix(), 10),
+			want:      false,
+		},
+		{
+			name:      "future timestamp beyond skew",
+			signature: sign(priv, strconv.FormatInt(now.Add(time.Minute).Unix(), 10), body),
+			timestamp: strconv.FormatInt(now.Add(time.Minute).Unix(), 10),
+			want:      false,
+		},
+		{
+			name:      "malformed hex",
+			signature: "not-valid-hex!",
+			timestamp: nowTimestamp,
+			want:      false,
+		},
+		{
+			name:      "extra whitespace is trimmed",
+			signature: "  " + sign(priv, nowTimestamp, body) + "  ",
+			timestamp: "  " + nowTimestamp + "  ",
+			want:      true,
+		},
+		{
+			name:      "missing signature",
+			signature: "",
+			timestamp: nowTimestamp,
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Verifier{Keys: StaticKeys(pub), Skew: 5 * time.Second, Clock: clock}
+			got := v.Verify(request(tt.signature, tt.timestamp), body)
+			if got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifier_VerifyTrimsTimestampBeforeSigning(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	body := []byte(`{"type":1}`)
+	now := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	v := &Verifier{Keys: StaticKeys(pub), Clock: func() time.Time { return now }}
+
+	signature := sign(priv, timestamp, body)
+	if !v.Verify(request(signature, "  "+timestamp+"  "), body) {
+		t.Error("expected a signature over the trimmed timestamp to verify")
+	}
+}
+
+func TestVerifier_DefaultsApplyOnZeroValue(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	body := []byte(`{"type":1}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := sign(priv, timestamp, body)
+
+	v := &Verifier{Keys: StaticKeys(pub)}
+	if !v.Verify(request(signature, timestamp), body) {
+		t.Error("expected zero-value Skew/Clock to fall back to 5s/time.Now")
+	}
+}
+
+func TestStaticKeys(t *testing.T) {
+	pub1, _, _ := ed25519.GenerateKey(nil)
+	pub2, _, _ := ed25519.GenerateKey(nil)
+
+	provider := StaticKeys(pub1, pub2)
+	keys := provider.Keys()
+	if len(keys) != 2 {
+		t.Fatalf("expected 2 keys, got %d", len(keys))
+	}
+}
+
+func TestNew(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(nil)
+	v := New(StaticKeys(pub))
+
+	if v.Skew != 5*time.Second {
+		t.Errorf("expected default Skew of 5s, got %v", v.Skew)
+	}
+	if v.Clock == nil {
+		t.Error("expected a non-nil default Clock")
+	}
+}
+
+func TestVerifier_VerifyRejectsTruncatedSignature(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	body := []byte(`{"type":1}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := sign(priv, timestamp, body)
+
+	v := &Verifier{Keys: StaticKeys(pub)}
+	truncated := signature[:len(signature)/2]
+	if v.Verify(request(truncated, timestamp), body) {
+		t.Error("expected a truncated signature to fail verification")
+	}
+	if strings.TrimSpace(truncated) == signature {
+		t.Fatal("test setup invariant broken: truncated signature equals original")
+	}
+}
@@ -0,0 +1,125 @@
+// Package discordwebhook implements the HTTP handler for Discord
+// interactions webhooks: signature verification, Ping/Pong, and handing
+// slash commands off to an interactionbus.Publisher.
+package discordwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/pkg/discordsig"
+	"github.com/pmgledhill102/discord-bot-test-suite/pkg/interactionbus"
+)
+
+// Interaction types, per the Discord API.
+const (
+	InteractionTypePing               = 1
+	InteractionTypeApplicationCommand = 2
+)
+
+// Response types, per the Discord API.
+const (
+	ResponseTypePong                   = 1
+	ResponseTypeDeferredChannelMessage = 5
+)
+
+// Interaction represents a Discord interaction request.
+type Interaction struct {
+	Type          int                    `json:"type"`
+	ID            string                 `json:"id,omitempty"`
+	ApplicationID string                 `json:"application_id,omitempty"`
+	Token         string                 `json:"token,omitempty"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+	GuildID       string                 `json:"guild_id,omitempty"`
+	ChannelID     string                 `json:"channel_id,omitempty"`
+	Member        map[string]interface{} `json:"member,omitempty"`
+	User          map[string]interface{} `json:"user,omitempty"`
+	Locale        string                 `json:"locale,omitempty"`
+	GuildLocale   string                 `json:"guild_locale,omitempty"`
+}
+
+// InteractionResponse represents a Discord interaction response.
+type InteractionResponse struct {
+	Type int                    `json:"type"`
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// Handler verifies and dispatches Discord interaction webhook requests.
+type Handler struct {
+	Verifier  *discordsig.Verifier
+	Publisher interactionbus.Publisher
+}
+
+// NewHandler creates a Handler. A nil publisher is treated as
+// interactionbus.NoopPublisher{}.
+func NewHandler(verifier *discordsig.Verifier, publisher interactionbus.Publisher) *Handler {
+	if publisher == nil {
+		publisher = interactionbus.NoopPublisher{}
+	}
+	return &Handler{Verifier: verifier, Publisher: publisher}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "failed to read body"})
+		return
+	}
+
+	if !h.Verifier.Verify(r, body) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid signature"})
+		return
+	}
+
+	var interaction Interaction
+	if err := json.Unmarshal(body, &interaction); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON"})
+		return
+	}
+
+	switch interaction.Type {
+	case InteractionTypePing:
+		writeJSON(w, http.StatusOK, InteractionResponse{Type: ResponseTypePong})
+	case InteractionTypeApplicationCommand:
+		go h.publish(&interaction)
+		writeJSON(w, http.StatusOK, InteractionResponse{Type: ResponseTypeDeferredChannelMessage})
+	default:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported interaction type"})
+	}
+}
+
+// publish sanitizes interaction (dropping its Token) and hands it to the
+// configured Publisher, logging rather than failing the request on error
+// since the Discord response has already been sent.
+func (h *Handler) publish(interaction *Interaction) {
+	sanitized := &interactionbus.SanitizedInteraction{
+		Type:          interaction.Type,
+		ID:            interaction.ID,
+		ApplicationID: interaction.ApplicationID,
+		Data:          interaction.Data,
+		GuildID:       interaction.GuildID,
+		ChannelID:     interaction.ChannelID,
+		Member:        interaction.Member,
+		User:          interaction.User,
+		Locale:        interaction.Locale,
+		GuildLocale:   interaction.GuildLocale,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := h.Publisher.Publish(ctx, sanitized); err != nil {
+		log.Printf("Failed to publish interaction: %v", err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
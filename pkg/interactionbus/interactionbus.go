@@ -0,0 +1,175 @@
+// Package interactionbus publishes sanitized Discord interactions to
+// whatever downstream system processes slash commands, behind a Publisher
+// interface so the transport (Pub/Sub, an HTTP forwarder, or nothing at all
+// in tests) is a wiring decision, not a compile-time one.
+package interactionbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2"
+	pubsubpb "cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+)
+
+// SanitizedInteraction is a Discord interaction with sensitive fields (the
+// interaction Token) stripped, safe to hand to a downstream consumer.
+type SanitizedInteraction struct {
+	Type          int                    `json:"type"`
+	ID            string                 `json:"id,omitempty"`
+	ApplicationID string                 `json:"application_id,omitempty"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+	GuildID       string                 `json:"guild_id,omitempty"`
+	ChannelID     string                 `json:"channel_id,omitempty"`
+	Member        map[string]interface{} `json:"member,omitempty"`
+	User          map[string]interface{} `json:"user,omitempty"`
+	Locale        string                 `json:"locale,omitempty"`
+	GuildLocale   string                 `json:"guild_locale,omitempty"`
+}
+
+// CommandName returns Data["name"] if present, for attaching a
+// "command_name" attribute without every Publisher reimplementing the
+// lookup.
+func (s *SanitizedInteraction) CommandName() (string, bool) {
+	if s.Data == nil {
+		return "", false
+	}
+	name, ok := s.Data["name"].(string)
+	return name, ok
+}
+
+// Publisher delivers a sanitized interaction to a downstream consumer.
+type Publisher interface {
+	Publish(ctx context.Context, interaction *SanitizedInteraction) error
+}
+
+// NoopPublisher discards every interaction, for local development and unit
+// tests of the HTTP handler that don't care about the downstream system.
+type NoopPublisher struct{}
+
+// Publish implements Publisher.
+func (NoopPublisher) Publish(context.Context, *SanitizedInteraction) error { return nil }
+
+// MultiPublisher fans an interaction out to every Publisher it wraps,
+// publishing concurrently and returning the first error encountered (after
+// waiting for every publish to finish, so one slow downstream doesn't mask
+// another's failure).
+type MultiPublisher []Publisher
+
+// Publish implements Publisher.
+func (m MultiPublisher) Publish(ctx context.Context, interaction *SanitizedInteraction) error {
+	errs := make([]error, len(m))
+	done := make(chan int, len(m))
+
+	for i, p := range m {
+		go func(i int, p Publisher) {
+			errs[i] = p.Publish(ctx, interaction)
+			done <- i
+		}(i, p)
+	}
+
+	for range m {
+		<-done
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PubSubPublisher publishes sanitized interactions to a Google Cloud
+// Pub/Sub topic, attaching the same attributes the webhook service has
+// always set (interaction/application/guild/channel IDs, command name).
+type PubSubPublisher struct {
+	publisher *pubsub.Publisher
+}
+
+// NewPubSubPublisher creates a PubSubPublisher, creating topicName in
+// projectID if it doesn't already exist (so the Pub/Sub emulator works
+// without a separate provisioning step).
+func NewPubSubPublisher(ctx context.Context, projectID, topicName string) (*PubSubPublisher, error) {
+	client, err := pubsub.NewClient(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("creating Pub/Sub client: %w", err)
+	}
+
+	topicPath := fmt.Sprintf("projects/%s/topics/%s", projectID, topicName)
+	if _, err := client.TopicAdminClient.GetTopic(ctx, &pubsubpb.GetTopicRequest{Topic: topicPath}); err != nil {
+		if _, err := client.TopicAdminClient.CreateTopic(ctx, &pubsubpb.Topic{Name: topicPath}); err != nil {
+			return nil, fmt.Errorf("creating topic %s: %w", topicPath, err)
+		}
+	}
+
+	return &PubSubPublisher{publisher: client.Publisher(topicPath)}, nil
+}
+
+// Publish implements Publisher.
+func (p *PubSubPublisher) Publish(ctx context.Context, interaction *SanitizedInteraction) error {
+	data, err := json.Marshal(interaction)
+	if err != nil {
+		return fmt.Errorf("marshaling interaction: %w", err)
+	}
+
+	attributes := map[string]string{
+		"interaction_id":   interaction.ID,
+		"interaction_type": strconv.Itoa(interaction.Type),
+		"application_id":   interaction.ApplicationID,
+		"guild_id":         interaction.GuildID,
+		"channel_id":       interaction.ChannelID,
+		"timestamp":        time.Now().UTC().Format(time.RFC3339),
+	}
+	if name, ok := interaction.CommandName(); ok {
+		attributes["command_name"] = name
+	}
+
+	result := p.publisher.Publish(ctx, &pubsub.Message{Data: data, Attributes: attributes})
+	if _, err := result.Get(ctx); err != nil {
+		return fmt.Errorf("publishing to Pub/Sub: %w", err)
+	}
+	return nil
+}
+
+// HTTPPublisher POSTs a sanitized interaction as JSON to a fixed URL, for
+// forwarding to a plain HTTP consumer instead of Pub/Sub.
+type HTTPPublisher struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPPublisher creates an HTTPPublisher with a sane request timeout.
+func NewHTTPPublisher(url string) *HTTPPublisher {
+	return &HTTPPublisher{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Publish implements Publisher.
+func (p *HTTPPublisher) Publish(ctx context.Context, interaction *SanitizedInteraction) error {
+	data, err := json.Marshal(interaction)
+	if err != nil {
+		return fmt.Errorf("marshaling interaction: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting interaction: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("publisher endpoint returned %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package interactionbus
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+type countingPublisher struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (p *countingPublisher) Publish(context.Context, *SanitizedInteraction) error {
+	p.calls.Add(1)
+	return p.err
+}
+
+func TestSanitizedInteraction_CommandName(t *testing.T) {
+	tests := []struct {
+		name        string
+		interaction *SanitizedInteraction
+		wantName    string
+		wantOK      bool
+	}{
+		{
+			name:        "no data",
+			interaction: &SanitizedInteraction{},
+			wantOK:      false,
+		},
+		{
+			name:        "name present",
+			interaction: &SanitizedInteraction{Data: map[string]interface{}{"name": "roll"}},
+			wantName:    "roll",
+			wantOK:      true,
+		},
+		{
+			name:        "name wrong type",
+			interaction: &SanitizedInteraction{Data: map[string]interface{}{"name": 5}},
+			wantOK:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := tt.interaction.CommandName()
+			if ok != tt.wantOK || name != tt.wantName {
+				t.Errorf("CommandName() = (%q, %v), want (%q, %v)", name, ok, tt.wantName, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNoopPublisher(t *testing.T) {
+	if err := (NoopPublisher{}).Publish(context.Background(), &SanitizedInteraction{}); err != nil {
+		t.Errorf("expected NoopPublisher to never error, got %v", err)
+	}
+}
+
+func TestMultiPublisher_FanOut(t *testing.T) {
+	a := &countingPublisher{}
+	b := &countingPublisher{}
+	c := &countingPublisher{}
+
+	multi := MultiPublisher{a, b, c}
+	if err := multi.Publish(context.Background(), &SanitizedInteraction{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for name, p := range map[string]*countingPublisher{"a": a, "b": b, "c": c} {
+		if p.calls.Load() != 1 {
+			t.Errorf("publisher %s: expected 1 call, got %d", name, p.calls.Load())
+		}
+	}
+}
+
+func TestMultiPublisher_ReturnsFirstErrorAfterAllComplete(t *testing.T) {
+	wantErr := errors.New("downstream unavailable")
+	a := &countingPublisher{}
+	b := &countingPublisher{err: wantErr}
+	c := &countingPublisher{}
+
+	multi := MultiPublisher{a, b, c}
+	err := multi.Publish(context.Background(), &SanitizedInteraction{})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("expected error %v, got %v", wantErr, err)
+	}
+
+	// Every publisher should still have been called once, even though b
+	// errored - a failing downstream must not stop the others publishing.
+	for name, p := range map[string]*countingPublisher{"a": a, "b": b, "c": c} {
+		if p.calls.Load() != 1 {
+			t.Errorf("publisher %s: expected 1 call, got %d", name, p.calls.Load())
+		}
+	}
+}
+
+func TestMultiPublisher_Empty(t *testing.T) {
+	var multi MultiPublisher
+	if err := multi.Publish(context.Background(), &SanitizedInteraction{}); err != nil {
+		t.Errorf("expected an empty MultiPublisher to succeed trivially, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+// Package keystore holds the set of Ed25519 public keys a webhook service
+// currently accepts Discord signatures against, and optionally keeps that
+// set fresh by polling an external source (Secret Manager, GCS, or an
+// HTTPS endpoint) in the background.
+//
+// Discord occasionally rotates an application's public key and gives
+// operators a window where both the old and new key are valid, so the
+// store always verifies against a *set* rather than a single key, and
+// swaps that set atomically so handlers never observe a half-updated view.
+package keystore
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// KeySet is the payload a Source returns: the currently valid keys (hex
+// encoded) and a monotonically increasing generation number, mirroring the
+// repo's config-client convention of pairing pushed data with a version so
+// a consumer can tell a refresh apart from a no-op poll.
+type KeySet struct {
+	Keys       []string `json:"keys"`
+	Generation int64    `json:"generation"`
+}
+
+// Source fetches the latest KeySet from wherever it's staged.
+type Source interface {
+	Fetch() (KeySet, error)
+}
+
+// snapshot is what the Store's atomic.Pointer actually holds, so
+// Generation and the decoded keys swap together.
+type snapshot struct {
+	keys       []ed25519.PublicKey
+	generation int64
+}
+
+// Store holds the active verification set and, once Watch is called, keeps
+// it refreshed from a Source. The zero value is not usable; create one with
+// New.
+type Store struct {
+	current atomic.Pointer[snapshot]
+
+	lastRefresh atomic.Pointer[time.Time]
+}
+
+// New creates a Store seeded with a single hex-encoded public key, typically
+// from the DISCORD_PUBLIC_KEY environment variable.
+func New(initialKeyHex string) (*Store, error) {
+	key, err := decodeKey(initialKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("decoding initial public key: %w", err)
+	}
+
+	s := &Store{}
+	s.current.Store(&snapshot{keys: []ed25519.PublicKey{key}})
+	now := time.Now()
+	s.lastRefresh.Store(&now)
+
+	return s, nil
+}
+
+// Keys returns the currently active set of public keys, implementing
+// discordsig.KeyProvider.
+func (s *Store) Keys() []ed25519.PublicKey {
+	return s.current.Load().keys
+}
+
+// Watch starts a background goroutine that polls src every interval,
+// atomically swapping the active key set whenever src reports a new
+// generation. It returns immediately; the goroutine runs until the process
+// exits (there is currently no way to stop it, matching main()'s other
+// long-lived background clients).
+func (s *Store) Watch(src Source, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := s.refresh(src); err != nil {
+				log.Printf("keystore: refresh failed: %v", err)
+			}
+		}
+	}()
+}
+
+// refresh fetches once from src and swaps the active set if the generation
+// advanced. It is unexported; Watch's ticker loop is its only caller.
+func (s *Store) refresh(src Source) error {
+	fetched, err := src.Fetch()
+	if err != nil {
+		return err
+	}
+
+	if fetched.Generation <= s.current.Load().generation {
+		return nil
+	}
+
+	keys := make([]ed25519.PublicKey, 0, len(fetched.Keys))
+	for _, hexKey := range fetched.Keys {
+		key, err := decodeKey(hexKey)
+		if err != nil {
+			return fmt.Errorf("decoding rotated public key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("fetched key set (generation %d) is empty, keeping current set", fetched.Generation)
+	}
+
+	s.current.Store(&snapshot{keys: keys, generation: fetched.Generation})
+	now := time.Now()
+	s.lastRefresh.Store(&now)
+
+	log.Printf("keystore: rotated to generation %d (%d key(s))", fetched.Generation, len(keys))
+	return nil
+}
+
+// Health is the /health sub-check payload a handler can embed in its
+// response, so an operator can confirm a rotation actually landed.
+type Health struct {
+	Generation  int64     `json:"generation"`
+	KeyCount    int       `json:"key_count"`
+	LastRefresh time.Time `json:"last_refresh"`
+}
+
+// Health reports the active generation, key count, and last successful
+// refresh time (the store's creation time if Watch has never refreshed).
+func (s *Store) Health() Health {
+	snap := s.current.Load()
+	return Health{
+		Generation:  snap.generation,
+		KeyCount:    len(snap.keys),
+		LastRefresh: *s.lastRefresh.Load(),
+	}
+}
+
+func decodeKey(hexKey string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key is %d bytes, want %d", len(key), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(key), nil
+}
@@ -0,0 +1,132 @@
+package keystore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"cloud.google.com/go/storage"
+)
+
+// HTTPSource fetches a KeySet from an HTTPS endpoint returning
+// {"keys": ["<hex>", ...], "generation": N}, e.g. an operator-run config
+// service.
+type HTTPSource struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPSource creates an HTTPSource with a sane request timeout.
+func NewHTTPSource(url string) *HTTPSource {
+	return &HTTPSource{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Fetch implements Source.
+func (s *HTTPSource) Fetch() (KeySet, error) {
+	resp, err := s.Client.Get(s.URL)
+	if err != nil {
+		return KeySet{}, fmt.Errorf("requesting key set: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return KeySet{}, fmt.Errorf("key set endpoint returned %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return KeySet{}, fmt.Errorf("reading key set response: %w", err)
+	}
+
+	var set KeySet
+	if err := json.Unmarshal(body, &set); err != nil {
+		return KeySet{}, fmt.Errorf("parsing key set response: %w", err)
+	}
+	return set, nil
+}
+
+// GCSSource fetches a KeySet from a JSON object in Google Cloud Storage, for
+// operators who pre-stage a rotated key alongside a deployment rather than
+// running a config endpoint.
+type GCSSource struct {
+	Bucket string
+	Object string
+	client *storage.Client
+}
+
+// NewGCSSource creates a GCSSource backed by Application Default
+// Credentials.
+func NewGCSSource(ctx context.Context, bucket, object string) (*GCSSource, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating storage client: %w", err)
+	}
+	return &GCSSource{Bucket: bucket, Object: object, client: client}, nil
+}
+
+// Fetch implements Source.
+func (s *GCSSource) Fetch() (KeySet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	r, err := s.client.Bucket(s.Bucket).Object(s.Object).NewReader(ctx)
+	if err != nil {
+		return KeySet{}, fmt.Errorf("opening gs://%s/%s: %w", s.Bucket, s.Object, err)
+	}
+	defer r.Close()
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return KeySet{}, fmt.Errorf("reading gs://%s/%s: %w", s.Bucket, s.Object, err)
+	}
+
+	var set KeySet
+	if err := json.Unmarshal(body, &set); err != nil {
+		return KeySet{}, fmt.Errorf("parsing key set object: %w", err)
+	}
+	return set, nil
+}
+
+// SecretManagerSource fetches a KeySet from the latest version of a Secret
+// Manager secret, for operators who already manage other application
+// secrets that way.
+type SecretManagerSource struct {
+	// ResourceName is the secret's version resource name, e.g.
+	// "projects/my-project/secrets/discord-public-keys/versions/latest".
+	ResourceName string
+	client       *secretmanager.Client
+}
+
+// NewSecretManagerSource creates a SecretManagerSource backed by
+// Application Default Credentials.
+func NewSecretManagerSource(ctx context.Context, resourceName string) (*SecretManagerSource, error) {
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating Secret Manager client: %w", err)
+	}
+	return &SecretManagerSource{ResourceName: resourceName, client: client}, nil
+}
+
+// Fetch implements Source.
+func (s *SecretManagerSource) Fetch() (KeySet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := s.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: s.ResourceName,
+	})
+	if err != nil {
+		return KeySet{}, fmt.Errorf("accessing secret version %s: %w", s.ResourceName, err)
+	}
+
+	var set KeySet
+	if err := json.Unmarshal(resp.Payload.Data, &set); err != nil {
+		return KeySet{}, fmt.Errorf("parsing secret version payload: %w", err)
+	}
+	return set, nil
+}
@@ -5,68 +5,31 @@
 // - Responds to Ping (type=1) with Pong (type=1)
 // - Responds to Slash commands (type=2) with Deferred (type=5)
 // - Publishes sanitized slash command payloads to Pub/Sub
+//
+// The handler, signature verification, and publisher logic live in
+// pkg/discordwebhook, pkg/discordsig, and pkg/interactionbus respectively;
+// this file is just wiring.
 package main
 
 import (
 	"context"
-	"crypto/ed25519"
-	"encoding/hex"
-	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"os"
-	"strconv"
+	"strings"
 	"time"
 
-	"cloud.google.com/go/pubsub/v2"
-	pubsubpb "cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"cloud.google.com/go/profiler"
 	"github.com/gin-gonic/gin"
-)
-
-// Interaction types
-const (
-	InteractionTypePing               = 1
-	InteractionTypeApplicationCommand = 2
-)
 
-// Response types
-const (
-	ResponseTypePong                   = 1
-	ResponseTypeDeferredChannelMessage = 5
-)
-
-// Interaction represents a Discord interaction request
-type Interaction struct {
-	Type          int                    `json:"type"`
-	ID            string                 `json:"id,omitempty"`
-	ApplicationID string                 `json:"application_id,omitempty"`
-	Token         string                 `json:"token,omitempty"`
-	Data          map[string]interface{} `json:"data,omitempty"`
-	GuildID       string                 `json:"guild_id,omitempty"`
-	ChannelID     string                 `json:"channel_id,omitempty"`
-	Member        map[string]interface{} `json:"member,omitempty"`
-	User          map[string]interface{} `json:"user,omitempty"`
-	Locale        string                 `json:"locale,omitempty"`
-	GuildLocale   string                 `json:"guild_locale,omitempty"`
-}
-
-// InteractionResponse represents a Discord interaction response
-type InteractionResponse struct {
-	Type int                    `json:"type"`
-	Data map[string]interface{} `json:"data,omitempty"`
-}
-
-var (
-	publicKey       ed25519.PublicKey
-	pubsubClient    *pubsub.Client
-	pubsubPublisher *pubsub.Publisher
-	projectID       string
+	"github.com/pmgledhill102/discord-bot-test-suite/pkg/discordsig"
+	"github.com/pmgledhill102/discord-bot-test-suite/pkg/discordwebhook"
+	"github.com/pmgledhill102/discord-bot-test-suite/pkg/interactionbus"
+	"github.com/pmgledhill102/discord-bot-test-suite/services/go-gin/internal/keystore"
 )
 
 func main() {
-	// Load configuration from environment
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
@@ -76,190 +39,122 @@ func main() {
 	if publicKeyHex == "" {
 		log.Fatal("DISCORD_PUBLIC_KEY environment variable is required")
 	}
-
-	var err error
-	publicKey, err = hex.DecodeString(publicKeyHex)
+	keys, err := keystore.New(publicKeyHex)
 	if err != nil {
 		log.Fatalf("Invalid DISCORD_PUBLIC_KEY: %v", err)
 	}
+	if err := startKeyWatcher(keys); err != nil {
+		log.Printf("Warning: key rotation watcher disabled: %v", err)
+	}
 
-	// Initialize Pub/Sub client
-	projectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
-	topicName := os.Getenv("PUBSUB_TOPIC")
-
-	if projectID != "" && topicName != "" {
-		ctx := context.Background()
-		pubsubClient, err = pubsub.NewClient(ctx, projectID)
-		if err != nil {
-			log.Printf("Warning: Failed to create Pub/Sub client: %v", err)
-		} else {
-			topicPath := fmt.Sprintf("projects/%s/topics/%s", projectID, topicName)
-
-			// Ensure topic exists (for emulator, create if not exists)
-			_, err := pubsubClient.TopicAdminClient.GetTopic(ctx, &pubsubpb.GetTopicRequest{
-				Topic: topicPath,
-			})
-			if err != nil {
-				// Topic doesn't exist, create it
-				_, err = pubsubClient.TopicAdminClient.CreateTopic(ctx, &pubsubpb.Topic{
-					Name: topicPath,
-				})
-				if err != nil {
-					log.Printf("Warning: Failed to create topic: %v", err)
-				}
-			}
+	startProfilerIfEnabled()
 
-			// Create publisher for the topic
-			pubsubPublisher = pubsubClient.Publisher(topicPath)
-		}
+	publisher, err := newPublisher(context.Background())
+	if err != nil {
+		log.Printf("Warning: %v, falling back to a no-op publisher", err)
+		publisher = interactionbus.NoopPublisher{}
 	}
 
-	// Set up Gin router
+	handler := discordwebhook.NewHandler(discordsig.New(keys), publisher)
+
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
 	r.Use(gin.Recovery())
-
-	// Health check endpoint
 	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "keys": keys.Health()})
 	})
+	r.POST("/", gin.WrapH(handler))
+	r.POST("/interactions", gin.WrapH(handler))
 
-	// Discord interactions endpoint
-	r.POST("/", handleInteraction)
-	r.POST("/interactions", handleInteraction)
-
-	// Start server
 	log.Printf("Starting server on port %s", port)
 	if err := r.Run(":" + port); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
 
-func handleInteraction(c *gin.Context) {
-	// Read body
-	body, err := io.ReadAll(c.Request.Body)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read body"})
+// startProfilerIfEnabled starts the Cloud Profiler agent when
+// PROFILER_ENABLED=true, the variable the benchmark runner sets on deploy.
+// Benchmarking relies on this to attribute cold-start latency to specific
+// init code.
+func startProfilerIfEnabled() {
+	if os.Getenv("PROFILER_ENABLED") != "true" {
 		return
 	}
-
-	// Validate signature
-	if !validateSignature(c.Request, body) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid signature"})
-		return
-	}
-
-	// Parse interaction
-	var interaction Interaction
-	if err := json.Unmarshal(body, &interaction); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
-		return
-	}
-
-	// Handle by type
-	switch interaction.Type {
-	case InteractionTypePing:
-		handlePing(c)
-	case InteractionTypeApplicationCommand:
-		handleApplicationCommand(c, &interaction)
-	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported interaction type"})
+	if err := profiler.Start(profiler.Config{
+		Service:        os.Getenv("K_SERVICE"),
+		ServiceVersion: os.Getenv("K_REVISION"),
+		ProjectID:      os.Getenv("GOOGLE_CLOUD_PROJECT"),
+	}); err != nil {
+		log.Printf("Warning: Failed to start Cloud Profiler agent: %v", err)
 	}
 }
 
-func validateSignature(r *http.Request, body []byte) bool {
-	signature := r.Header.Get("X-Signature-Ed25519")
-	timestamp := r.Header.Get("X-Signature-Timestamp")
-
-	if signature == "" || timestamp == "" {
-		return false
-	}
-
-	// Decode signature
-	sigBytes, err := hex.DecodeString(signature)
-	if err != nil {
-		return false
+// newPublisher builds the interactionbus.Publisher for PUBSUB_TOPIC /
+// GOOGLE_CLOUD_PROJECT, or returns an error (handled as a fallback to
+// NoopPublisher by the caller) if either is unset.
+func newPublisher(ctx context.Context) (interactionbus.Publisher, error) {
+	projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
+	topicName := os.Getenv("PUBSUB_TOPIC")
+	if projectID == "" || topicName == "" {
+		return nil, fmt.Errorf("GOOGLE_CLOUD_PROJECT/PUBSUB_TOPIC not set")
 	}
 
-	// Check timestamp (must be within 5 seconds)
-	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	publisher, err := interactionbus.NewPubSubPublisher(ctx, projectID, topicName)
 	if err != nil {
-		return false
-	}
-	if time.Now().Unix()-ts > 5 {
-		return false
+		return nil, fmt.Errorf("creating Pub/Sub publisher: %w", err)
 	}
-
-	// Verify signature: sign(timestamp + body)
-	message := append([]byte(timestamp), body...)
-	return ed25519.Verify(publicKey, message, sigBytes)
+	return publisher, nil
 }
 
-func handlePing(c *gin.Context) {
-	// Respond with Pong - do NOT publish to Pub/Sub
-	c.JSON(http.StatusOK, InteractionResponse{Type: ResponseTypePong})
-}
-
-func handleApplicationCommand(c *gin.Context, interaction *Interaction) {
-	// Publish to Pub/Sub (if configured)
-	if pubsubPublisher != nil {
-		go publishToPubSub(interaction)
-	}
-
-	// Respond with deferred response (non-ephemeral)
-	c.JSON(http.StatusOK, InteractionResponse{Type: ResponseTypeDeferredChannelMessage})
-}
-
-func publishToPubSub(interaction *Interaction) {
-	// Create sanitized copy (remove sensitive fields)
-	sanitized := &Interaction{
-		Type:          interaction.Type,
-		ID:            interaction.ID,
-		ApplicationID: interaction.ApplicationID,
-		// Token is intentionally NOT copied - sensitive data
-		Data:        interaction.Data,
-		GuildID:     interaction.GuildID,
-		ChannelID:   interaction.ChannelID,
-		Member:      interaction.Member,
-		User:        interaction.User,
-		Locale:      interaction.Locale,
-		GuildLocale: interaction.GuildLocale,
-	}
-
-	data, err := json.Marshal(sanitized)
-	if err != nil {
-		log.Printf("Failed to marshal interaction for Pub/Sub: %v", err)
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Build attributes
-	attributes := map[string]string{
-		"interaction_id":   interaction.ID,
-		"interaction_type": strconv.Itoa(interaction.Type),
-		"application_id":   interaction.ApplicationID,
-		"guild_id":         interaction.GuildID,
-		"channel_id":       interaction.ChannelID,
-		"timestamp":        time.Now().UTC().Format(time.RFC3339),
-	}
-
-	// Add command name if available
-	if interaction.Data != nil {
-		if name, ok := interaction.Data["name"].(string); ok {
-			attributes["command_name"] = name
+// startKeyWatcher starts the background key-rotation poller configured via
+// KEY_SOURCE ("secretmanager:<resource>", "gcs:<bucket>/<object>", or an
+// "https://..." URL) and KEY_POLL_INTERVAL (Go duration, default 5m).
+// Returns nil (no watcher) if KEY_SOURCE is unset. Any error it returns is
+// logged by main as a warning rather than failing startup, as is
+// newPublisher's.
+func startKeyWatcher(store *keystore.Store) error {
+	keySource := os.Getenv("KEY_SOURCE")
+	if keySource == "" {
+		return nil
+	}
+
+	interval := 5 * time.Minute
+	if raw := os.Getenv("KEY_POLL_INTERVAL"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid KEY_POLL_INTERVAL: %w", err)
 		}
+		interval = parsed
 	}
 
-	// Build message
-	msg := &pubsub.Message{
-		Data:       data,
-		Attributes: attributes,
+	ctx := context.Background()
+	var src keystore.Source
+	switch {
+	case strings.HasPrefix(keySource, "secretmanager:"):
+		resourceName := strings.TrimPrefix(keySource, "secretmanager:")
+		sm, err := keystore.NewSecretManagerSource(ctx, resourceName)
+		if err != nil {
+			return fmt.Errorf("creating Secret Manager key source: %w", err)
+		}
+		src = sm
+	case strings.HasPrefix(keySource, "gcs:"):
+		bucketObject := strings.TrimPrefix(keySource, "gcs:")
+		bucket, object, ok := strings.Cut(bucketObject, "/")
+		if !ok {
+			return fmt.Errorf("KEY_SOURCE %q must be gcs:<bucket>/<object>", keySource)
+		}
+		gcsSrc, err := keystore.NewGCSSource(ctx, bucket, object)
+		if err != nil {
+			return fmt.Errorf("creating GCS key source: %w", err)
+		}
+		src = gcsSrc
+	case strings.HasPrefix(keySource, "https://"):
+		src = keystore.NewHTTPSource(keySource)
+	default:
+		return fmt.Errorf("KEY_SOURCE %q must start with secretmanager:, gcs:, or https://", keySource)
 	}
 
-	result := pubsubPublisher.Publish(ctx, msg)
-	if _, err := result.Get(ctx); err != nil {
-		log.Printf("Failed to publish to Pub/Sub: %v", err)
-	}
+	store.Watch(src, interval)
+	log.Printf("Watching %s for public key rotation every %s", keySource, interval)
+	return nil
 }
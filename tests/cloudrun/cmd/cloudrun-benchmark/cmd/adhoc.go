@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/report"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/signing"
+)
+
+var (
+	adhocNoJitter           bool
+	adhocMeasureConcurrency int
+)
+
+// adhocCmd runs a complete single-iteration benchmark. This is the DEFAULT
+// command when clicking "Execute" in Cloud Console.
+var adhocCmd = &cobra.Command{
+	Use:   "adhoc",
+	Short: "Run a complete single-iteration benchmark (DEFAULT)",
+	Long:  "Waits for scale-to-zero, measures cold starts, runs warm tests.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdhoc(cmd)
+	},
+}
+
+func init() {
+	f := adhocCmd.Flags()
+	f.BoolVar(&adhocNoJitter, "no-jitter", false, "Skip startup jitter (for testing)")
+	f.IntVar(&adhocMeasureConcurrency, "measure-concurrency", 0, "Number of services to cold-start measure in parallel (overrides config, default 1)")
+}
+
+func runAdhoc(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	applyJitter(adhocNoJitter)
+
+	bucket := getGCSBucket()
+	if bucket == "" {
+		return fmt.Errorf("GCS bucket required: set --gcs-bucket or GCS_RESULTS_BUCKET")
+	}
+
+	fmt.Println("=== Ad-hoc Benchmark Run ===")
+	fmt.Printf("Config: %s\n", configPath)
+	fmt.Printf("Services: %v\n", cfg.Services.Enabled)
+	fmt.Printf("GCS Bucket: %s\n", bucket)
+
+	// Create Cloud Run client
+	cloudrun, err := gcp.NewCloudRunClient(ctx, cfg.GCP.ProjectID, cfg.GCP.Region, cfg.GCP.KeyFilePath, gcp.WithProgressReporter(newCloudOpsReporter()))
+	if err != nil {
+		return fmt.Errorf("creating Cloud Run client: %w", err)
+	}
+
+	// Get service URLs (services are already deployed via CI)
+	fmt.Println("\nLooking up service URLs...")
+	serviceInfos, err := cloudrun.GetAllServicesInfo(ctx, cfg.Services.Enabled)
+	if err != nil {
+		return fmt.Errorf("getting service URLs: %w", err)
+	}
+
+	if len(serviceInfos) == 0 {
+		return fmt.Errorf("no services found - ensure services are deployed")
+	}
+
+	fmt.Printf("Found %d services:\n", len(serviceInfos))
+	for _, svc := range serviceInfos {
+		fmt.Printf("  %s -> %s\n", svc.ServiceKey, svc.URL)
+	}
+
+	// Pre-fetch ID tokens (exclude from cold start measurement)
+	fmt.Println("\nPre-fetching ID tokens...")
+	tokens := make(map[string]string)
+	for _, svc := range serviceInfos {
+		token, err := gcp.GetIDToken(ctx, svc.URL, cfg.GCP.KeyFilePath)
+		if err != nil {
+			return fmt.Errorf("getting ID token for %s: %w", svc.ServiceKey, err)
+		}
+		tokens[svc.ServiceKey] = token
+	}
+	fmt.Printf("Pre-fetched tokens for %d services\n", len(tokens))
+
+	// Wait for scale-to-zero
+	fmt.Println("\nWaiting for all services to scale to zero...")
+	if err := waitForAllScaleToZero(ctx, cfg, serviceInfos); err != nil {
+		return fmt.Errorf("waiting for scale-to-zero: %w", err)
+	}
+	fmt.Println("All services scaled to zero")
+
+	// Take cold start measurements
+	fmt.Println("\nTaking cold start measurements...")
+	signer, err := signing.NewSignerFromEnv(ctx)
+	if err != nil {
+		return fmt.Errorf("creating signer: %w", err)
+	}
+	loggingClient, _ := gcp.NewLoggingClient(ctx, cfg.GCP.ProjectID, cfg.GCP.KeyFilePath)
+	if loggingClient != nil {
+		defer loggingClient.Close()
+	}
+
+	coldResults := takeColdStartMeasurements(ctx, cfg, serviceInfos, signer, loggingClient, tokens, adhocMeasureConcurrency)
+
+	// Run warm request tests (services are now warm)
+	fmt.Println("\nRunning warm request tests...")
+	warmResults := runWarmTests(ctx, cfg, serviceInfos, signer, tokens)
+
+	// Build full benchmark result
+	result := buildBenchmarkResult(cfg, serviceInfos, coldResults, warmResults)
+
+	// Create output directory
+	timestamp := time.Now().UTC()
+	tsStr := timestamp.Format("2006-01-02T15-04-05Z")
+	runDir := filepath.Join(outputDir, "adhoc-"+tsStr)
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	// Write reports
+	jsonPath := filepath.Join(runDir, "results.json")
+	if err := report.WriteJSON(result, jsonPath); err != nil {
+		return fmt.Errorf("writing JSON report: %w", err)
+	}
+	fmt.Printf("JSON report written to: %s\n", jsonPath)
+
+	mdPath := filepath.Join(runDir, "results.md")
+	if err := report.WriteMarkdown(result, mdPath); err != nil {
+		return fmt.Errorf("writing Markdown report: %w", err)
+	}
+	fmt.Printf("Markdown report written to: %s\n", mdPath)
+
+	// Upload to GCS adhoc directory
+	fmt.Printf("\nUploading results to GCS: gs://%s/adhoc/%s/\n", bucket, tsStr)
+	uploader, err := report.NewGCSUploader(ctx, bucket, cfg.GCP.KeyFilePath, gcp.WithProgressReporter(newCloudOpsReporter()))
+	if err != nil {
+		return fmt.Errorf("creating GCS uploader: %w", err)
+	}
+	defer uploader.Close()
+
+	paths, err := uploader.UploadAdhocResults(ctx, timestamp, runDir)
+	if err != nil {
+		return fmt.Errorf("uploading to GCS: %w", err)
+	}
+	for _, p := range paths {
+		fmt.Printf("Uploaded: %s\n", p)
+	}
+
+	fmt.Println("\n=== Ad-hoc Benchmark Complete ===")
+	return nil
+}
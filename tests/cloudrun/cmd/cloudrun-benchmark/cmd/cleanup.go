@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+)
+
+// cleanupCmd removes all Cloud Run services and Pub/Sub resources for the
+// configured run.
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Clean up resources for a specific run",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		runner, err := benchmark.NewRunner(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("creating runner: %w", err)
+		}
+		defer runner.Close()
+
+		return runner.Cleanup(ctx)
+	},
+}
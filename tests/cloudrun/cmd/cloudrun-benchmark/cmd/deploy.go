@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+)
+
+// deployCmd deploys all configured services without running benchmarks.
+var deployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Deploy services without running benchmarks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		runner, err := benchmark.NewRunner(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("creating runner: %w", err)
+		}
+		defer runner.Close()
+
+		return runner.DeployOnly(ctx)
+	},
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/report"
+)
+
+var (
+	diffBaseline      string
+	diffFailOnRegress bool
+	diffRegressCold   string
+	diffRegressWarm   string
+	diffRegressRPS    string
+)
+
+// diffCmd compares a current results JSON against --baseline and writes a
+// diff report. With --fail-on-regress it exits non-zero when any service
+// regresses beyond the configured thresholds, turning the suite into a CI
+// performance gate.
+var diffCmd = &cobra.Command{
+	Use:   "diff <current.json>",
+	Short: "Compare a results JSON against a --baseline and report regressions",
+	Args:  cobra.ExactArgs(1),
+	// diff works entirely from result files on disk, not live GCP state, so
+	// this overrides rootCmd's config-loading PersistentPreRunE with one
+	// that only checks --baseline is set.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if diffBaseline == "" {
+			return fmt.Errorf("--baseline is required")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDiff(args[0])
+	},
+}
+
+func init() {
+	f := diffCmd.Flags()
+	f.StringVar(&diffBaseline, "baseline", "", "Path to a baseline JSON report for the diff command")
+	f.BoolVar(&diffFailOnRegress, "fail-on-regress", false, "Exit non-zero if any service regresses beyond the configured thresholds")
+	f.StringVar(&diffRegressCold, "regress-ttfb-p95", "+15%", "Cold start P95 regression threshold (e.g. +15%)")
+	f.StringVar(&diffRegressWarm, "regress-warm-p95", "+15%", "Warm request P95 regression threshold (e.g. +15%)")
+	f.StringVar(&diffRegressRPS, "regress-rps", "+10%", "Throughput regression threshold (e.g. +10%)")
+}
+
+func runDiff(currentPath string) error {
+	baselineReport, err := report.LoadJSONReport(diffBaseline)
+	if err != nil {
+		return fmt.Errorf("loading baseline: %w", err)
+	}
+
+	currentReport, err := report.LoadJSONReport(currentPath)
+	if err != nil {
+		return fmt.Errorf("loading current results: %w", err)
+	}
+
+	diff := report.CompareReports(baselineReport, currentReport)
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	jsonPath := filepath.Join(outputDir, "diff.json")
+	if err := report.WriteDiffJSON(diff, jsonPath); err != nil {
+		return fmt.Errorf("writing diff.json: %w", err)
+	}
+
+	mdPath := filepath.Join(outputDir, "diff.md")
+	if err := report.WriteDiffMarkdown(diff, mdPath); err != nil {
+		return fmt.Errorf("writing diff.md: %w", err)
+	}
+
+	fmt.Printf("Diff written to %s and %s\n", jsonPath, mdPath)
+
+	if !diffFailOnRegress {
+		return nil
+	}
+
+	thresholds, err := parseRegressionThresholds()
+	if err != nil {
+		return fmt.Errorf("parsing regression thresholds: %w", err)
+	}
+
+	regressions := diff.CheckRegressions(thresholds)
+	if len(regressions) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Performance regressions detected:\n")
+	for _, r := range regressions {
+		fmt.Fprintf(os.Stderr, "  - %s\n", r)
+	}
+
+	os.Exit(1)
+	return nil
+}
+
+// parseRegressionThresholds converts the --regress-* flag strings into a
+// report.RegressionThresholds value.
+func parseRegressionThresholds() (report.RegressionThresholds, error) {
+	var thresholds report.RegressionThresholds
+
+	coldStart, err := report.ParseThreshold(diffRegressCold)
+	if err != nil {
+		return thresholds, fmt.Errorf("--regress-ttfb-p95: %w", err)
+	}
+	thresholds.ColdStartP95Pct = coldStart
+
+	warm, err := report.ParseThreshold(diffRegressWarm)
+	if err != nil {
+		return thresholds, fmt.Errorf("--regress-warm-p95: %w", err)
+	}
+	thresholds.WarmP95Pct = warm
+
+	rps, err := report.ParseThreshold(diffRegressRPS)
+	if err != nil {
+		return thresholds, fmt.Errorf("--regress-rps: %w", err)
+	}
+	thresholds.RPSPct = rps
+
+	return thresholds, nil
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/report"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/signing"
+)
+
+var finalizeNoJitter bool
+
+// finalizeCmd consolidates readings and generates final reports. Used by
+// the scheduled finalize job.
+var finalizeCmd = &cobra.Command{
+	Use:   "finalize",
+	Short: "Consolidate readings and generate reports (for scheduled jobs)",
+	Long:  "Loads readings, runs warm tests, uploads final reports.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bucket := getGCSBucket()
+		if bucket == "" {
+			return fmt.Errorf("GCS bucket required: set --gcs-bucket or GCS_RESULTS_BUCKET")
+		}
+		return runFinalize(cmd, bucket)
+	},
+}
+
+func init() {
+	finalizeCmd.Flags().BoolVar(&finalizeNoJitter, "no-jitter", false, "Skip startup jitter (for testing)")
+}
+
+func runFinalize(cmd *cobra.Command, bucket string) error {
+	ctx := cmd.Context()
+	applyJitter(finalizeNoJitter)
+
+	runDate := getRunDate()
+
+	fmt.Println("=== Finalize Command ===")
+	fmt.Printf("Run date: %s\n", runDate)
+	fmt.Printf("Config: %s\n", configPath)
+	fmt.Printf("GCS Bucket: %s\n", bucket)
+
+	// Create GCS uploader
+	uploader, err := report.NewGCSUploader(ctx, bucket, cfg.GCP.KeyFilePath, gcp.WithProgressReporter(newCloudOpsReporter()))
+	if err != nil {
+		return fmt.Errorf("creating GCS uploader: %w", err)
+	}
+	defer uploader.Close()
+
+	// Load all readings from GCS
+	fmt.Println("\nLoading readings from GCS...")
+	readings, err := uploader.LoadAllReadings(ctx, runDate)
+	if err != nil {
+		return fmt.Errorf("loading readings: %w", err)
+	}
+
+	if len(readings) == 0 {
+		return fmt.Errorf("no readings found for %s", runDate)
+	}
+
+	fmt.Printf("Loaded %d readings\n", len(readings))
+	for _, r := range readings {
+		fmt.Printf("  Iteration %d: %d services measured\n", r.Iteration, len(r.Services))
+	}
+
+	// Create Cloud Run client for warm tests
+	cloudrun, err := gcp.NewCloudRunClient(ctx, cfg.GCP.ProjectID, cfg.GCP.Region, cfg.GCP.KeyFilePath, gcp.WithProgressReporter(newCloudOpsReporter()))
+	if err != nil {
+		return fmt.Errorf("creating Cloud Run client: %w", err)
+	}
+
+	// Get service URLs for warm tests
+	serviceInfos, err := cloudrun.GetAllServicesInfo(ctx, cfg.Services.Enabled)
+	if err != nil {
+		return fmt.Errorf("getting service URLs: %w", err)
+	}
+
+	// Pre-fetch ID tokens for warm tests
+	fmt.Println("\nPre-fetching ID tokens...")
+	tokens := make(map[string]string)
+	for _, svc := range serviceInfos {
+		token, err := gcp.GetIDToken(ctx, svc.URL, cfg.GCP.KeyFilePath)
+		if err != nil {
+			return fmt.Errorf("getting ID token for %s: %w", svc.ServiceKey, err)
+		}
+		tokens[svc.ServiceKey] = token
+	}
+	fmt.Printf("Pre-fetched tokens for %d services\n", len(tokens))
+
+	// Run warm request tests (services are warm from recent cold start tests)
+	fmt.Println("\nRunning warm request tests...")
+	signer, err := signing.NewSignerFromEnv(ctx)
+	if err != nil {
+		return fmt.Errorf("creating signer: %w", err)
+	}
+	warmResults := runWarmTests(ctx, cfg, serviceInfos, signer, tokens)
+
+	// Consolidate into benchmark result
+	result := consolidateReadings(cfg, readings, serviceInfos, warmResults)
+
+	// Create output directory
+	runDir := filepath.Join(outputDir, runDate)
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	// Write reports
+	jsonPath := filepath.Join(runDir, "results.json")
+	if err := report.WriteJSON(result, jsonPath); err != nil {
+		return fmt.Errorf("writing JSON report: %w", err)
+	}
+	fmt.Printf("JSON report written to: %s\n", jsonPath)
+
+	mdPath := filepath.Join(runDir, "results.md")
+	if err := report.WriteMarkdown(result, mdPath); err != nil {
+		return fmt.Errorf("writing Markdown report: %w", err)
+	}
+	fmt.Printf("Markdown report written to: %s\n", mdPath)
+
+	// Upload final reports to GCS: YYYY/MM/DD/<run-id>/
+	datePath := result.StartTime.UTC().Format("2006/01/02")
+	gcsPrefix := path.Join(datePath, result.RunID)
+
+	fmt.Printf("\nUploading final reports to GCS: gs://%s/%s/\n", bucket, gcsPrefix)
+	paths, err := uploader.UploadResults(ctx, result.RunID, result.StartTime, runDir)
+	if err != nil {
+		return fmt.Errorf("uploading final reports: %w", err)
+	}
+	for _, p := range paths {
+		fmt.Printf("Uploaded: %s\n", p)
+	}
+
+	// Clean up intermediate files
+	fmt.Printf("\nCleaning up runs/%s/...\n", runDate)
+	if err := uploader.CleanupRun(ctx, runDate); err != nil {
+		fmt.Printf("Warning: cleanup failed: %v\n", err)
+	}
+
+	fmt.Println("\n=== Finalize Complete ===")
+	return nil
+}
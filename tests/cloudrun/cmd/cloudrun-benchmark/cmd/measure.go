@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/config"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/profiling"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/progress"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/report"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/signing"
+)
+
+var (
+	measureNoJitter    bool
+	measureIteration   int
+	measureConcurrency int
+)
+
+// measureCmd takes a single cold start reading and saves it to GCS. Used by
+// scheduled measure-N jobs.
+var measureCmd = &cobra.Command{
+	Use:   "measure",
+	Short: "Take a cold start reading (for scheduled jobs)",
+	Long:  "Saves reading to GCS: runs/<date>/reading-N.json",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if measureIteration < 1 {
+			return fmt.Errorf("--iteration must be >= 1")
+		}
+		bucket := getGCSBucket()
+		if bucket == "" {
+			return fmt.Errorf("GCS bucket required: set --gcs-bucket or GCS_RESULTS_BUCKET")
+		}
+		return runMeasure(cmd, bucket)
+	},
+}
+
+func init() {
+	f := measureCmd.Flags()
+	f.BoolVar(&measureNoJitter, "no-jitter", false, "Skip startup jitter (for testing)")
+	f.IntVar(&measureIteration, "iteration", 1, "Iteration number for measure command")
+	f.IntVar(&measureConcurrency, "measure-concurrency", 0, "Number of services to cold-start measure in parallel (overrides config, default 1)")
+}
+
+func runMeasure(cmd *cobra.Command, bucket string) error {
+	ctx := cmd.Context()
+	applyJitter(measureNoJitter)
+
+	runDate := getRunDate()
+	iterNum := measureIteration
+
+	fmt.Println("=== Measure Command ===")
+	fmt.Printf("Run date: %s\n", runDate)
+	fmt.Printf("Iteration: %d\n", iterNum)
+	fmt.Printf("Config: %s\n", configPath)
+	fmt.Printf("Services: %v\n", cfg.Services.Enabled)
+
+	// Create Cloud Run client
+	cloudrun, err := gcp.NewCloudRunClient(ctx, cfg.GCP.ProjectID, cfg.GCP.Region, cfg.GCP.KeyFilePath, gcp.WithProgressReporter(newCloudOpsReporter()))
+	if err != nil {
+		return fmt.Errorf("creating Cloud Run client: %w", err)
+	}
+
+	// Get service URLs
+	fmt.Println("\nLooking up service URLs...")
+	serviceInfos, err := cloudrun.GetAllServicesInfo(ctx, cfg.Services.Enabled)
+	if err != nil {
+		return fmt.Errorf("getting service URLs: %w", err)
+	}
+
+	if len(serviceInfos) == 0 {
+		return fmt.Errorf("no services found - ensure services are deployed")
+	}
+
+	fmt.Printf("Found %d services\n", len(serviceInfos))
+
+	// Pre-fetch ID tokens (exclude from cold start measurement)
+	fmt.Println("\nPre-fetching ID tokens...")
+	tokens := make(map[string]string)
+	for _, svc := range serviceInfos {
+		token, err := gcp.GetIDToken(ctx, svc.URL, cfg.GCP.KeyFilePath)
+		if err != nil {
+			return fmt.Errorf("getting ID token for %s: %w", svc.ServiceKey, err)
+		}
+		tokens[svc.ServiceKey] = token
+	}
+	fmt.Printf("Pre-fetched tokens for %d services\n", len(tokens))
+
+	// Verify services are scaled to zero
+	fmt.Println("\nVerifying services are scaled to zero...")
+	if err := verifyScaledToZero(ctx, cfg, serviceInfos); err != nil {
+		fmt.Printf("Warning: some services may not be at zero instances: %v\n", err)
+		// Continue anyway - we still want to take measurements
+	}
+
+	// Take cold start measurements
+	fmt.Println("\nTaking cold start measurements...")
+	loggingClient, _ := gcp.NewLoggingClient(ctx, cfg.GCP.ProjectID, cfg.GCP.KeyFilePath)
+	if loggingClient != nil {
+		defer loggingClient.Close()
+	}
+
+	var profilingClient *profiling.Client
+	if cfg.Profiling.Enabled {
+		profilingClient, err = profiling.NewClient(ctx, cfg.GCP.ProjectID)
+		if err != nil {
+			fmt.Printf("Warning: could not create profiling client: %v\n", err)
+		}
+	}
+
+	uploader, err := report.NewGCSUploader(ctx, bucket, cfg.GCP.KeyFilePath, gcp.WithProgressReporter(newCloudOpsReporter()))
+	if err != nil {
+		return fmt.Errorf("creating GCS uploader: %w", err)
+	}
+	defer uploader.Close()
+
+	measurements := make(map[string]*report.ColdStartMeasurement, len(serviceInfos))
+	var measurementsMu sync.Mutex
+
+	bar := progress.New("Cold start", len(serviceInfos), progressSilent())
+
+	concurrency := getMeasureConcurrency(cfg, measureConcurrency)
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for _, svc := range orderedServices(cfg, serviceInfos) {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(svc *gcp.GetServiceInfo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			// Each worker owns its own signer so concurrent measurements
+			// don't contend over shared signing state.
+			workerSigner, signerErr := signing.NewSignerFromEnv(ctx)
+			if signerErr != nil {
+				fmt.Printf("    Error creating signer: %v\n", signerErr)
+				return
+			}
+
+			fmt.Printf("  Measuring %s...\n", svc.ServiceKey)
+
+			requestStartTime := time.Now()
+			result, err := benchmark.MeasureColdStart(ctx, svc.URL, workerSigner, tokens[svc.ServiceKey])
+
+			measurement := &report.ColdStartMeasurement{
+				ServiceName: svc.ServiceName,
+				ServiceURL:  svc.URL,
+				StatusCode:  result.StatusCode,
+			}
+
+			if err != nil {
+				measurement.Error = err.Error()
+				fmt.Printf("    Error: %v\n", err)
+			} else {
+				measurement.TTFB = result.TTFB
+				fmt.Printf("    TTFB: %v\n", result.TTFB)
+			}
+
+			// Try to get container startup time
+			if loggingClient != nil && result.Error == nil {
+				metrics, err := loggingClient.WaitForStartupLog(ctx, svc.ServiceName, cfg.GCP.Region, requestStartTime, 30*time.Second)
+				if err == nil && metrics.Found {
+					measurement.ContainerStartup = metrics.ContainerStartupLatency
+					fmt.Printf("    Container startup: %v\n", measurement.ContainerStartup)
+				}
+			}
+
+			if profilingClient != nil && result.Error == nil {
+				if err := saveAndUploadProfiles(ctx, profilingClient, uploader, cfg, svc.ServiceName, iterNum); err != nil {
+					fmt.Printf("    Warning: profiling failed: %v\n", err)
+				}
+			}
+
+			measurementsMu.Lock()
+			measurements[svc.ServiceKey] = measurement
+			measurementsMu.Unlock()
+
+			bar.Increment()
+		}(svc)
+	}
+
+	wg.Wait()
+	bar.Finish()
+
+	// Save reading to GCS
+	readingResult := &report.ReadingResult{
+		RunID:     runDate,
+		Iteration: iterNum,
+		Timestamp: time.Now(),
+		Config:    cfg,
+		Services:  measurements,
+	}
+
+	if ctx.Err() != nil {
+		fmt.Printf("\nInterrupted: saving partial reading (%d/%d services measured)...\n", len(measurements), len(serviceInfos))
+		return savePartialReading(context.Background(), uploader, runDate, iterNum, readingResult)
+	}
+
+	fmt.Printf("\nSaving reading to GCS...\n")
+	gcsPath, err := uploader.SaveReadingResult(ctx, runDate, iterNum, readingResult)
+	if err != nil {
+		return fmt.Errorf("saving reading: %w", err)
+	}
+	fmt.Printf("Saved: %s\n", gcsPath)
+
+	fmt.Println("\n=== Measure Complete ===")
+	return nil
+}
+
+// saveAndUploadProfiles fetches the profiles captured during a service's
+// cold start, writes them next to the local results directory, and uploads
+// them through uploader under profiles/<service>/reading-N-<type>.pb.gz.
+func saveAndUploadProfiles(ctx context.Context, client *profiling.Client, uploader *report.GCSUploader, cfg *config.Config, serviceName string, iterNum int) error {
+	profiles, err := client.FetchProfiles(ctx, serviceName, cfg.Profiling)
+	if err != nil {
+		return fmt.Errorf("fetching profiles: %w", err)
+	}
+
+	profileDir := filepath.Join(outputDir, "profiles", serviceName)
+	if err := os.MkdirAll(profileDir, 0755); err != nil {
+		return fmt.Errorf("creating profile directory: %w", err)
+	}
+
+	for _, p := range profiles {
+		localPath := filepath.Join(profileDir, fmt.Sprintf("reading-%d-%s.pb.gz", iterNum, p.ProfileType))
+		if err := profiling.Save(p, localPath); err != nil {
+			return fmt.Errorf("saving profile: %w", err)
+		}
+
+		data, err := os.ReadFile(localPath)
+		if err != nil {
+			return fmt.Errorf("reading saved profile: %w", err)
+		}
+
+		gcsPath := path.Join("profiles", serviceName, fmt.Sprintf("reading-%d-%s.pb.gz", iterNum, p.ProfileType))
+		if _, err := uploader.UploadBytes(ctx, gcsPath, data, "application/octet-stream"); err != nil {
+			return fmt.Errorf("uploading profile: %w", err)
+		}
+		fmt.Printf("    Uploaded profile: %s\n", gcsPath)
+	}
+
+	return nil
+}
+
+// savePartialReading is used when a SIGINT/SIGTERM arrives mid-measurement.
+// It still uploads whatever ReadingResult was captured so finalizeCmd has
+// something to consolidate, but marks it as partial both locally and in
+// GCS so a future finalize pass can tell it apart from a complete reading.
+// It takes a fresh context since ctx has already been cancelled.
+func savePartialReading(ctx context.Context, uploader *report.GCSUploader, runDate string, iterNum int, reading *report.ReadingResult) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	markerPath := filepath.Join(outputDir, "results.partial.json")
+	data, err := json.MarshalIndent(reading, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling partial reading: %w", err)
+	}
+	if err := os.WriteFile(markerPath, data, 0644); err != nil {
+		return fmt.Errorf("writing partial marker: %w", err)
+	}
+	fmt.Printf("Wrote partial marker: %s\n", markerPath)
+
+	gcsPath, err := uploader.SaveReadingResult(ctx, runDate, iterNum, reading)
+	if err != nil {
+		return fmt.Errorf("uploading partial reading: %w", err)
+	}
+	fmt.Printf("Uploaded partial reading: %s\n", gcsPath)
+
+	markerGCSPath := path.Join("runs", runDate, fmt.Sprintf("reading-%d.partial", iterNum))
+	if _, err := uploader.UploadBytes(ctx, markerGCSPath, []byte(gcsPath), "text/plain"); err != nil {
+		return fmt.Errorf("uploading partial marker: %w", err)
+	}
+
+	return fmt.Errorf("measurement interrupted: partial reading saved")
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/report"
+)
+
+var (
+	compareAlpha     float64
+	compareBenchstat bool
+)
+
+// reportCmd regenerates reports from existing results files. It has no
+// RunE of its own; use the "render" or "compare" subcommands.
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate or compare reports from existing results",
+	// Reports are generated from existing results files, not live GCP
+	// state, so this overrides rootCmd's config-loading PersistentPreRunE
+	// with a no-op.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+}
+
+// reportRenderCmd regenerates the Markdown report from a results.json file
+// that was written by a prior "run" or "measure"/"finalize" pair.
+var reportRenderCmd = &cobra.Command{
+	Use:   "render <results.json>",
+	Short: "Regenerate the Markdown report from a results JSON file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReportRender(args[0])
+	},
+}
+
+// reportCompareCmd compares two results.json files using a Mann-Whitney U
+// test per service/metric, the same statistical test `go test -bench`
+// users will recognize from benchstat. With --benchstat, it instead treats
+// both arguments as testing.B-format text files (see report.WriteBenchstat)
+// and shells out to the real benchstat CLI for an exact comparison.
+var reportCompareCmd = &cobra.Command{
+	Use:   "compare <old> <new>",
+	Short: "Compare two runs with benchstat-style significance testing",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if compareBenchstat {
+			return runReportCompareBenchstat(args[0], args[1])
+		}
+		return runReportCompare(args[0], args[1])
+	},
+}
+
+func init() {
+	reportCompareCmd.Flags().Float64Var(&compareAlpha, "alpha", 0.05, "Significance level for the Mann-Whitney U test")
+	reportCompareCmd.Flags().BoolVar(&compareBenchstat, "benchstat", false,
+		"Treat <old>/<new> as testing.B-format text files and compare them with the external benchstat CLI, instead of comparing two results.json files with the built-in approximation")
+
+	reportCmd.AddCommand(reportRenderCmd, reportCompareCmd)
+}
+
+func runReportRender(resultsPath string) error {
+	jsonReport, err := report.LoadJSONReport(resultsPath)
+	if err != nil {
+		return fmt.Errorf("loading results: %w", err)
+	}
+
+	mdPath := filepath.Join(filepath.Dir(resultsPath), "results.md")
+	if err := report.WriteMarkdownFromJSON(jsonReport, mdPath); err != nil {
+		return fmt.Errorf("writing Markdown report: %w", err)
+	}
+
+	fmt.Printf("Markdown report written to: %s\n", mdPath)
+	return nil
+}
+
+func runReportCompare(oldPath, newPath string) error {
+	oldReport, err := report.LoadJSONReport(oldPath)
+	if err != nil {
+		return fmt.Errorf("loading old results: %w", err)
+	}
+
+	newReport, err := report.LoadJSONReport(newPath)
+	if err != nil {
+		return fmt.Errorf("loading new results: %w", err)
+	}
+
+	cmp := report.CompareRuns(oldReport, newReport, compareAlpha)
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	mdPath := filepath.Join(outputDir, "benchstat.md")
+	if err := report.WriteBenchstatMarkdown(cmp, mdPath); err != nil {
+		return fmt.Errorf("writing comparison report: %w", err)
+	}
+
+	fmt.Printf("Comparison written to %s\n", mdPath)
+	for _, m := range cmp.Metrics {
+		fmt.Printf("  %s/%s: %+.1f%% (p=%.4f) %s\n", m.ServiceName, m.Metric, m.DeltaPct, m.PValue, m.Marker)
+	}
+
+	return nil
+}
+
+// runReportCompareBenchstat invokes the external benchstat CLI (expected on
+// PATH) against two testing.B-format text files written by
+// report.WriteBenchstat, then inlines its output as a Regression section of
+// the Markdown report.
+func runReportCompareBenchstat(oldPath, newPath string) error {
+	out, err := exec.Command("benchstat", oldPath, newPath).Output()
+	if err != nil {
+		return fmt.Errorf("running benchstat (is golang.org/x/perf/cmd/benchstat installed?): %w", err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	mdPath := filepath.Join(outputDir, "benchstat.md")
+	if err := report.WriteBenchstatRegressionMarkdown(string(out), mdPath); err != nil {
+		return fmt.Errorf("writing regression report: %w", err)
+	}
+
+	fmt.Printf("Regression report written to %s\n", mdPath)
+	fmt.Print(string(out))
+
+	return nil
+}
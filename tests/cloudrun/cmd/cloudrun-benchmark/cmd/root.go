@@ -0,0 +1,77 @@
+// Package cmd implements the cloudrun-benchmark CLI as a tree of cobra
+// subcommands, one per file, each declaring only the flags it consumes.
+// Shared helpers (config loading, progress bars, worker pools) live in
+// shared.go.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	configPath = "/configs/scheduled-full.yaml"
+	outputDir  = "results"
+	services   string
+	gcsBucket  string
+	keyFile    string
+	silent     bool
+	noProgress bool
+)
+
+// rootCmd is the cloudrun-benchmark entrypoint. With no subcommand given it
+// runs adhocCmd, preserving the historical default used when clicking
+// "Execute" in Cloud Console.
+var rootCmd = &cobra.Command{
+	Use:           "cloudrun-benchmark",
+	Short:         "Benchmark Cloud Run cold start and warm request performance",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	// PersistentPreRunE loads the config once per invocation and stores it
+	// in cfg for every subcommand to read. report and diff override this
+	// with hooks that load no config, since they work from existing results
+	// files and don't need a config or GCP clients.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		loaded, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		cfg = loaded
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		adhocCmd.SetContext(cmd.Context())
+		return adhocCmd.RunE(adhocCmd, args)
+	},
+}
+
+func init() {
+	pf := rootCmd.PersistentFlags()
+	pf.StringVar(&configPath, "config", configPath, "Path to configuration file")
+	pf.StringVar(&outputDir, "output", outputDir, "Output directory for results")
+	pf.StringVar(&services, "services", "", "Comma-separated list of services to benchmark (overrides config)")
+	pf.StringVar(&gcsBucket, "gcs-bucket", "", "GCS bucket for uploading results (env: GCS_RESULTS_BUCKET)")
+	pf.StringVar(&keyFile, "key-file", "", "Path to a service account key file for GCP auth (env: GCP_KEY_FILE, default: Application Default Credentials)")
+	pf.BoolVar(&silent, "silent", false, "Suppress progress bars and status output")
+	pf.BoolVar(&noProgress, "no-progress", false, "Suppress progress bars only (status lines still print)")
+
+	rootCmd.AddCommand(adhocCmd, measureCmd, finalizeCmd, deployCmd, runCmd, cleanupCmd, reportCmd, diffCmd, sweepCmd)
+}
+
+// Execute runs the root command. SIGINT/SIGTERM cancels the context passed
+// to every subcommand, so Cloud Run and GCS calls in flight unwind instead
+// of leaving a multi-minute scale-to-zero wait stuck.
+func Execute() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
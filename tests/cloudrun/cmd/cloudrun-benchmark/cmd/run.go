@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/report"
+)
+
+var (
+	runBatchMode         bool
+	runLocalResults      string
+	runEventsOut         string
+	runOTelEndpoint      string
+	runNoVerify          bool
+	runNotifyEmail       string
+	runNotifyWebhook     string
+	runAlertOnRegression float64
+	runMetricsAddr       string
+	runResultsOut        string
+	runResumeFrom        string
+)
+
+// runCmd runs the full legacy benchmark suite (deploy, cold start, warm
+// request, cleanup for every configured service in one process).
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run the full benchmark suite (legacy)",
+	Long:  "Use --batch for efficient multi-service testing.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLegacy(cmd)
+	},
+}
+
+func init() {
+	f := runCmd.Flags()
+	f.BoolVar(&runBatchMode, "batch", false, "Run in batch mode (deploy all → wait → test all, more efficient)")
+	f.StringVar(&runLocalResults, "local-results", "", "Path to local benchmark results for comparison")
+	f.StringVar(&runEventsOut, "events-out", "", "Stream NDJSON progress events to this path, or '-' for stdout")
+	f.StringVar(&runOTelEndpoint, "otel-endpoint", "", "OTLP (gRPC) collector endpoint to export benchmark spans/metrics to (also OTEL_EXPORTER_OTLP_ENDPOINT)")
+	f.BoolVar(&runNoVerify, "no-verify", false, "Skip re-checking uploaded GCS artifacts against the local results after upload")
+	f.StringVar(&runNotifyEmail, "notify-email", "", "Comma-separated email addresses to notify on completion (env: NOTIFY_EMAIL)")
+	f.StringVar(&runNotifyWebhook, "notify-webhook", "", "HTTP webhook URL to POST the result to on completion (env: NOTIFY_WEBHOOK)")
+	f.Float64Var(&runAlertOnRegression, "alert-on-regression", 0, "Percent cold-start/warm-latency regression (vs --local-results) that triggers a regression notification instead of an ok one")
+	f.StringVar(&runMetricsAddr, "metrics-addr", "", "If set, serve live Prometheus metrics on this address (e.g. :9090) for the duration of the run")
+	f.StringVar(&runResultsOut, "results-out", "", "Stream full-fidelity NDJSON results to this path (--batch only), or '-' for stdout; enables --resume-from later")
+	f.StringVar(&runResumeFrom, "resume-from", "", "Resume a batch run from a prior --results-out NDJSON file instead of deploying and testing from scratch (--batch only)")
+}
+
+func runLegacy(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+
+	if runOTelEndpoint != "" {
+		cfg.OTelEndpoint = runOTelEndpoint
+	}
+	cfg.OutputDir = outputDir
+
+	runner, err := benchmark.NewRunner(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("creating runner: %w", err)
+	}
+	defer runner.Close()
+
+	runner.SetProgressReporter(newProgressReporter())
+
+	if runMetricsAddr != "" {
+		registry := report.NewPrometheusRegistry()
+		runner.SetMetricsSink(registry)
+		go func() {
+			if err := registry.Serve(runMetricsAddr); err != nil {
+				fmt.Printf("Warning: live metrics server stopped: %v\n", err)
+			}
+		}()
+		fmt.Printf("Serving live metrics on http://%s/metrics\n", runMetricsAddr)
+	}
+
+	if runEventsOut != "" {
+		sink, err := report.NewNDJSONEventSink(runEventsOut)
+		if err != nil {
+			return fmt.Errorf("opening events output: %w", err)
+		}
+		defer sink.Close()
+		runner.SetEventSink(sink)
+	}
+
+	if runResultsOut != "" {
+		sink, err := report.NewNDJSONResultSink(runResultsOut)
+		if err != nil {
+			return fmt.Errorf("opening results output: %w", err)
+		}
+		defer sink.Close()
+		runner.SetResultSink(sink)
+	}
+
+	// Run benchmarks (batch mode or sequential)
+	var result *benchmark.BenchmarkResult
+	if runResumeFrom != "" {
+		result, err = runner.RunBatchResume(ctx, runResumeFrom)
+	} else if runBatchMode {
+		result, err = runner.RunBatch(ctx)
+	} else {
+		result, err = runner.Run(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("running benchmark: %w", err)
+	}
+
+	// Create output directory
+	runDir := filepath.Join(outputDir, result.RunID)
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	// Write reports
+	jsonPath := filepath.Join(runDir, "results.json")
+	if err := report.WriteJSON(result, jsonPath); err != nil {
+		return fmt.Errorf("writing JSON report: %w", err)
+	}
+	fmt.Printf("JSON report written to: %s\n", jsonPath)
+
+	mdPath := filepath.Join(runDir, "results.md")
+	if err := report.WriteMarkdown(result, mdPath); err != nil {
+		return fmt.Errorf("writing Markdown report: %w", err)
+	}
+	fmt.Printf("Markdown report written to: %s\n", mdPath)
+
+	benchstatPath := filepath.Join(runDir, "benchstat.txt")
+	if err := report.WriteBenchstat(result, benchstatPath); err != nil {
+		return fmt.Errorf("writing benchstat report: %w", err)
+	}
+	fmt.Printf("Benchstat report written to: %s\n", benchstatPath)
+
+	openMetricsPath := filepath.Join(runDir, "openmetrics.txt")
+	if err := report.WriteOpenMetrics(result, openMetricsPath); err != nil {
+		return fmt.Errorf("writing OpenMetrics report: %w", err)
+	}
+	fmt.Printf("OpenMetrics report written to: %s\n", openMetricsPath)
+
+	// Write comparison report if local results provided
+	var comparison *report.ComparisonReport
+	compPath := filepath.Join(runDir, "comparison.md")
+	if runLocalResults != "" {
+		localData, err := report.LoadLocalResults(runLocalResults)
+		if err != nil {
+			fmt.Printf("Warning: could not load local results: %v\n", err)
+		} else {
+			comparison = report.Compare(localData, result)
+			if err := report.WriteComparisonMarkdown(comparison, compPath); err != nil {
+				return fmt.Errorf("writing comparison report: %w", err)
+			}
+			fmt.Printf("Comparison report written to: %s\n", compPath)
+		}
+	}
+
+	// Upload to GCS if bucket specified (flag or env var)
+	var artifactURLs []string
+	bucket := getGCSBucket()
+	if bucket != "" {
+		fmt.Printf("\nUploading results to GCS bucket: %s\n", bucket)
+		uploader, err := report.NewGCSUploader(ctx, bucket, cfg.GCP.KeyFilePath, gcp.WithProgressReporter(newCloudOpsReporter()))
+		if err != nil {
+			fmt.Printf("Warning: could not create GCS uploader: %v\n", err)
+		} else {
+			defer uploader.Close()
+			paths, err := uploader.UploadResults(ctx, result.RunID, result.StartTime, runDir)
+			if err != nil {
+				fmt.Printf("Warning: GCS upload failed: %v\n", err)
+			} else {
+				for _, p := range paths {
+					fmt.Printf("Uploaded: %s\n", p)
+				}
+
+				if !runNoVerify {
+					if err := verifyUpload(ctx, uploader, result, runDir); err != nil {
+						return err
+					}
+				}
+
+				artifactURLs = signedArtifactURLs(uploader, result, paths)
+			}
+		}
+	}
+
+	// Notify (fires after GCS upload so signed links can be embedded)
+	if err := sendNotifications(ctx, result, comparison, runDir, artifactURLs); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	// Cleanup
+	fmt.Println("\nCleaning up resources...")
+	if err := runner.Cleanup(ctx); err != nil {
+		fmt.Printf("Warning: cleanup failed: %v\n", err)
+	}
+
+	return nil
+}
+
+// verifyUpload re-lists the objects UploadResults just wrote, compares them
+// against the local files in runDir, and writes a verification.md report.
+// It returns an error (failing the run) if any discrepancy is found.
+func verifyUpload(ctx context.Context, uploader *report.GCSUploader, result *benchmark.BenchmarkResult, runDir string) error {
+	prefix := path.Join(result.StartTime.UTC().Format("2006/01/02"), result.RunID)
+
+	verification, err := uploader.VerifyUpload(ctx, prefix, runDir, 2)
+	if err != nil {
+		return fmt.Errorf("verifying GCS upload: %w", err)
+	}
+
+	verifyPath := filepath.Join(runDir, "verification.md")
+	if err := report.WriteVerificationMarkdown(verification, verifyPath); err != nil {
+		return fmt.Errorf("writing verification report: %w", err)
+	}
+
+	if !verification.OK() {
+		return fmt.Errorf("GCS upload verification failed, see %s (pass --no-verify to ignore)", verifyPath)
+	}
+
+	fmt.Printf("Verified %d uploaded file(s) match local originals\n", verification.SampledCount)
+	return nil
+}
+
+// signedArtifactURLs turns the gs:// paths UploadResults returned into
+// short-lived signed URLs, so a notification's recipient can open an
+// artifact without needing bucket access. Paths that fail to sign are
+// skipped rather than failing the whole run.
+func signedArtifactURLs(uploader *report.GCSUploader, result *benchmark.BenchmarkResult, gcsPaths []string) []string {
+	bucketPrefix := fmt.Sprintf("gs://%s/", getGCSBucket())
+
+	var urls []string
+	for _, p := range gcsPaths {
+		objectPath := strings.TrimPrefix(p, bucketPrefix)
+		url, err := uploader.SignedURL(objectPath, 7*24*time.Hour)
+		if err != nil {
+			fmt.Printf("Warning: could not sign URL for %s: %v\n", objectPath, err)
+			continue
+		}
+		urls = append(urls, url)
+	}
+	return urls
+}
+
+// sendNotifications fires any configured Notifier (email and/or webhook)
+// with the run's outcome. It's called after GCS upload so artifactURLs can
+// be embedded, and a notification failure never fails the run itself.
+func sendNotifications(ctx context.Context, result *benchmark.BenchmarkResult, comparison *report.ComparisonReport, runDir string, artifactURLs []string) error {
+	emailTo := runNotifyEmail
+	if emailTo == "" {
+		emailTo = os.Getenv("NOTIFY_EMAIL")
+	}
+	webhookURL := runNotifyWebhook
+	if webhookURL == "" {
+		webhookURL = os.Getenv("NOTIFY_WEBHOOK")
+	}
+
+	if emailTo == "" && webhookURL == "" {
+		return nil
+	}
+
+	summary, err := os.ReadFile(filepath.Join(runDir, "results.md"))
+	if err != nil {
+		return fmt.Errorf("reading results.md for notification: %w", err)
+	}
+
+	notification := report.Notification{
+		RunID:        result.RunID,
+		Regression:   report.RegressionDetected(comparison, runAlertOnRegression),
+		Summary:      string(summary),
+		Result:       result,
+		Attachments:  []string{filepath.Join(runDir, "results.md"), filepath.Join(runDir, "comparison.md")},
+		ArtifactURLs: artifactURLs,
+	}
+
+	var notifiers []report.Notifier
+	if emailTo != "" {
+		notifiers = append(notifiers, report.NewEmailNotifier(
+			os.Getenv("SMTP_ADDR"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"),
+			os.Getenv("SMTP_FROM"), strings.Split(emailTo, ","),
+		))
+	}
+	if webhookURL != "" {
+		notifiers = append(notifiers, report.NewWebhookNotifier(webhookURL))
+	}
+
+	var errs []string
+	for _, n := range notifiers {
+		if err := n.Notify(ctx, notification); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("sending notifications: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,413 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/config"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/progress"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/report"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/signing"
+)
+
+// startupJitter is the delay before executing scheduled jobs to avoid exact-minute contention.
+const startupJitter = 37*time.Second + 300*time.Millisecond
+
+// cfg is the config loaded once by rootCmd's PersistentPreRunE and read by
+// every subcommand that needs it.
+var cfg *config.Config
+
+// loadConfig reads the YAML config and applies the --services/--key-file
+// overrides shared by every data-bearing command.
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	if services != "" {
+		cfg.Services.Enabled = splitServices(services)
+	}
+
+	// --key-file takes precedence over GCP_KEY_FILE, which config.Load
+	// already applied.
+	if keyFile != "" {
+		cfg.GCP.KeyFilePath = keyFile
+	}
+
+	return cfg, nil
+}
+
+func splitServices(s string) []string {
+	var result []string
+	for _, svc := range filepath.SplitList(s) {
+		if svc != "" {
+			result = append(result, svc)
+		}
+	}
+	// Also handle comma-separated
+	if len(result) == 1 {
+		result = nil
+		for i := 0; i < len(s); i++ {
+			j := i
+			for j < len(s) && s[j] != ',' {
+				j++
+			}
+			if j > i {
+				result = append(result, s[i:j])
+			}
+			i = j
+		}
+	}
+	return result
+}
+
+// applyJitter sleeps for the startup jitter period unless noJitter is set.
+func applyJitter(noJitter bool) {
+	if !noJitter {
+		fmt.Printf("Applying startup jitter: %v\n", startupJitter)
+		time.Sleep(startupJitter)
+	}
+}
+
+// getRunDate returns today's date in UTC as the run ID.
+func getRunDate() string {
+	return time.Now().UTC().Format("2006-01-02")
+}
+
+// progressSilent reports whether progress bars should be suppressed,
+// either because --silent also silences status output or --no-progress
+// targets just the bars.
+func progressSilent() bool {
+	return silent || noProgress
+}
+
+// newProgressReporter returns a live TTY progress reporter for
+// benchmark.Runner to drive, or nil when --silent/--no-progress is set, in
+// which case the runner falls back to its plain log lines.
+func newProgressReporter() benchmark.ProgressReporter {
+	if progressSilent() {
+		return nil
+	}
+	return progress.NewTTYReporter()
+}
+
+// newCloudOpsReporter returns a live TTY progress reporter for
+// CloudRunClient.Deploy and GCSUploader.UploadDir to drive, or nil when
+// --silent/--no-progress is set, in which case they report nothing.
+func newCloudOpsReporter() gcp.ProgressReporter {
+	if progressSilent() {
+		return nil
+	}
+	return progress.NewCloudOpsReporter()
+}
+
+// getGCSBucket returns the GCS bucket from flag or environment.
+func getGCSBucket() string {
+	bucket := gcsBucket
+	if bucket == "" {
+		bucket = os.Getenv("GCS_RESULTS_BUCKET")
+	}
+	return bucket
+}
+
+// getMeasureConcurrency returns the cold-start measurement concurrency from
+// flag or config, in that order of precedence.
+func getMeasureConcurrency(cfg *config.Config, measureConcurrency int) int {
+	if measureConcurrency > 0 {
+		return measureConcurrency
+	}
+	return cfg.Benchmark.MeasureConcurrency
+}
+
+// orderedServices returns services in measurement order, shuffled in place
+// on a copy when cfg.Benchmark.ShuffleMeasureOrder is set, so systematic
+// ordering bias doesn't leak into the aggregated percentiles computed by
+// ColdStartStats.CalculateStats.
+func orderedServices(cfg *config.Config, services []*gcp.GetServiceInfo) []*gcp.GetServiceInfo {
+	if !cfg.Benchmark.ShuffleMeasureOrder {
+		return services
+	}
+	shuffled := make([]*gcp.GetServiceInfo, len(services))
+	copy(shuffled, services)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+// waitForAllScaleToZero waits until all services have zero instances.
+func waitForAllScaleToZero(ctx context.Context, cfg *config.Config, services []*gcp.GetServiceInfo) error {
+	bar := progress.New("Scale-to-zero", len(services), progressSilent())
+	defer bar.Finish()
+
+	for _, svc := range services {
+		scaleConfig := benchmark.ScaleToZeroConfig{
+			ProjectID:    cfg.GCP.ProjectID,
+			Region:       cfg.GCP.Region,
+			ServiceName:  svc.ServiceName,
+			KeyFilePath:  cfg.GCP.KeyFilePath,
+			Timeout:      cfg.Benchmark.ScaleToZeroTimeout,
+			PollInterval: 30 * time.Second,
+		}
+
+		if _, err := benchmark.WaitForScaleToZero(ctx, scaleConfig); err != nil {
+			return fmt.Errorf("%s: %w", svc.ServiceKey, err)
+		}
+		bar.Increment()
+	}
+	return nil
+}
+
+// verifyScaledToZero checks that all services are at zero instances (non-blocking).
+func verifyScaledToZero(ctx context.Context, cfg *config.Config, services []*gcp.GetServiceInfo) error {
+	for _, svc := range services {
+		scaleConfig := benchmark.ScaleToZeroConfig{
+			ProjectID:   cfg.GCP.ProjectID,
+			Region:      cfg.GCP.Region,
+			ServiceName: svc.ServiceName,
+		}
+
+		isZero, err := benchmark.IsScaledToZero(ctx, scaleConfig)
+		if err != nil {
+			return fmt.Errorf("%s: %w", svc.ServiceKey, err)
+		}
+		if !isZero {
+			return fmt.Errorf("%s is not at zero instances", svc.ServiceKey)
+		}
+	}
+	return nil
+}
+
+// takeColdStartMeasurements takes cold start measurements for all services.
+func takeColdStartMeasurements(ctx context.Context, cfg *config.Config, services []*gcp.GetServiceInfo, signer signing.Signer, loggingClient *gcp.LoggingClient, tokens map[string]string, measureConcurrency int) map[string]*benchmark.ColdStartResult {
+	bar := progress.New("Cold start", len(services), progressSilent())
+	defer bar.Finish()
+
+	results := measureColdStartsConcurrent(ctx, cfg, services, tokens, bar, measureConcurrency, func(svc *gcp.GetServiceInfo, token string) *benchmark.ColdStartResult {
+		fmt.Printf("  Measuring %s...\n", svc.ServiceKey)
+
+		requestStartTime := time.Now()
+		result, err := benchmark.MeasureColdStart(ctx, svc.URL, signer, token)
+		if err != nil {
+			fmt.Printf("    Error: %v\n", err)
+		} else {
+			fmt.Printf("    TTFB: %v\n", result.TTFB)
+		}
+
+		// Try to get container startup time
+		if loggingClient != nil && result.Error == nil {
+			metrics, err := loggingClient.WaitForStartupLog(ctx, svc.ServiceName, cfg.GCP.Region, requestStartTime, 30*time.Second)
+			if err == nil && metrics.Found {
+				result.ContainerStartup = metrics.ContainerStartupLatency
+				fmt.Printf("    Container startup: %v\n", result.ContainerStartup)
+			}
+		}
+
+		return result
+	})
+
+	return results
+}
+
+// measureColdStartsConcurrent fires one cold start measurement per service
+// through a bounded worker pool sized by getMeasureConcurrency, so a
+// reading's services are all measured within roughly the same wall-clock
+// window instead of one WaitForStartupLog timeout stacked after another.
+// Each worker owns its own invocation of measure, and results are collected
+// under a mutex. Iteration order is optionally shuffled per call via
+// orderedServices to avoid systematic bias in aggregated percentiles.
+func measureColdStartsConcurrent(ctx context.Context, cfg *config.Config, services []*gcp.GetServiceInfo, tokens map[string]string, bar *progress.Bar, measureConcurrency int, measure func(svc *gcp.GetServiceInfo, token string) *benchmark.ColdStartResult) map[string]*benchmark.ColdStartResult {
+	results := make(map[string]*benchmark.ColdStartResult, len(services))
+	var mu sync.Mutex
+
+	concurrency := getMeasureConcurrency(cfg, measureConcurrency)
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for _, svc := range orderedServices(cfg, services) {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(svc *gcp.GetServiceInfo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := measure(svc, tokens[svc.ServiceKey])
+
+			mu.Lock()
+			results[svc.ServiceKey] = result
+			mu.Unlock()
+
+			if bar != nil {
+				bar.Increment()
+			}
+		}(svc)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// runWarmTests runs warm request tests on all services.
+func runWarmTests(ctx context.Context, cfg *config.Config, services []*gcp.GetServiceInfo, signer signing.Signer, tokens map[string]string) map[string]*benchmark.WarmRequestStats {
+	results := make(map[string]*benchmark.WarmRequestStats)
+
+	bar := progress.New("Warm requests", len(services)*cfg.Benchmark.WarmRequests, progressSilent())
+	defer bar.Finish()
+
+	for _, svc := range services {
+		if ctx.Err() != nil {
+			break
+		}
+
+		fmt.Printf("  Testing %s (%d requests, %d concurrency)...\n",
+			svc.ServiceKey, cfg.Benchmark.WarmRequests, cfg.Benchmark.WarmConcurrency)
+
+		warmCfg := benchmark.WarmRequestConfig{
+			ServiceURL:   svc.URL,
+			RequestCount: cfg.Benchmark.WarmRequests,
+			Concurrency:  cfg.Benchmark.WarmConcurrency,
+			Signer:       signer,
+			RequestType:  benchmark.RequestTypePing,
+			IDToken:      tokens[svc.ServiceKey],
+		}
+
+		stats, err := benchmark.RunWarmRequestBenchmark(ctx, warmCfg)
+		if err != nil {
+			fmt.Printf("    Error: %v\n", err)
+		} else {
+			fmt.Printf("    P50: %v, P95: %v (%.1f req/s)\n", stats.P50, stats.P95, stats.RequestsPerSecond)
+		}
+
+		results[svc.ServiceKey] = stats
+		for i := 0; i < cfg.Benchmark.WarmRequests; i++ {
+			bar.Increment()
+		}
+	}
+
+	return results
+}
+
+// buildBenchmarkResult builds a BenchmarkResult from adhoc measurements.
+func buildBenchmarkResult(cfg *config.Config, services []*gcp.GetServiceInfo, coldResults map[string]*benchmark.ColdStartResult, warmResults map[string]*benchmark.WarmRequestStats) *benchmark.BenchmarkResult {
+	result := &benchmark.BenchmarkResult{
+		RunID:     "adhoc-" + time.Now().UTC().Format("20060102-150405"),
+		StartTime: time.Now(),
+		Config:    cfg,
+		Services:  make(map[string]*benchmark.ServiceResult),
+	}
+
+	for _, svc := range services {
+		svcResult := &benchmark.ServiceResult{
+			ServiceName: svc.ServiceKey,
+			ServiceURL:  svc.URL,
+			Profile:     "default",
+			Image:       cfg.ImageURI(svc.ServiceKey, "latest"),
+		}
+
+		// Add cold start result
+		if cold, ok := coldResults[svc.ServiceKey]; ok {
+			svcResult.ColdStart = &benchmark.ColdStartStats{
+				Results: []benchmark.ColdStartResult{*cold},
+			}
+			if cold.Error == nil {
+				svcResult.ColdStart.SuccessCount = 1
+				svcResult.ColdStart.TTFBMin = cold.TTFB
+				svcResult.ColdStart.TTFBMax = cold.TTFB
+				svcResult.ColdStart.TTFBAvg = cold.TTFB
+				svcResult.ColdStart.TTFBP50 = cold.TTFB
+				svcResult.ColdStart.TTFBP95 = cold.TTFB
+				svcResult.ColdStart.TTFBP99 = cold.TTFB
+			} else {
+				svcResult.ColdStart.FailureCount = 1
+				svcResult.BenchmarkError = cold.Error
+			}
+		}
+
+		// Add warm results
+		if warm, ok := warmResults[svc.ServiceKey]; ok {
+			svcResult.WarmRequest = warm
+		}
+
+		result.Services[svc.ServiceKey] = svcResult
+	}
+
+	result.EndTime = time.Now()
+	return result
+}
+
+// consolidateReadings consolidates multiple readings into a single BenchmarkResult.
+func consolidateReadings(cfg *config.Config, readings []*report.ReadingResult, services []*gcp.GetServiceInfo, warmResults map[string]*benchmark.WarmRequestStats) *benchmark.BenchmarkResult {
+	result := &benchmark.BenchmarkResult{
+		RunID:     readings[0].RunID,
+		StartTime: readings[0].Timestamp,
+		Config:    cfg,
+		Services:  make(map[string]*benchmark.ServiceResult),
+	}
+
+	// Build service URL map
+	serviceURLs := make(map[string]string)
+	for _, svc := range services {
+		serviceURLs[svc.ServiceKey] = svc.URL
+	}
+
+	// Aggregate cold start results from all readings
+	for _, svc := range services {
+		svcResult := &benchmark.ServiceResult{
+			ServiceName: svc.ServiceKey,
+			ServiceURL:  svc.URL,
+			Profile:     "default",
+			Image:       cfg.ImageURI(svc.ServiceKey, "latest"),
+			ColdStart: &benchmark.ColdStartStats{
+				Results: make([]benchmark.ColdStartResult, 0, len(readings)),
+			},
+		}
+
+		// Collect cold start measurements from all readings
+		for _, reading := range readings {
+			if measurement, ok := reading.Services[svc.ServiceKey]; ok {
+				coldResult := benchmark.ColdStartResult{
+					TTFB:             measurement.TTFB,
+					ContainerStartup: measurement.ContainerStartup,
+					StatusCode:       measurement.StatusCode,
+					Timestamp:        reading.Timestamp,
+				}
+				if measurement.Error != "" {
+					coldResult.Error = fmt.Errorf("%s", measurement.Error)
+					svcResult.ColdStart.FailureCount++
+				} else {
+					svcResult.ColdStart.SuccessCount++
+				}
+				svcResult.ColdStart.Results = append(svcResult.ColdStart.Results, coldResult)
+			}
+		}
+
+		// Calculate stats
+		svcResult.ColdStart.CalculateStats()
+
+		// Add warm results
+		if warm, ok := warmResults[svc.ServiceKey]; ok {
+			svcResult.WarmRequest = warm
+		}
+
+		result.Services[svc.ServiceKey] = svcResult
+	}
+
+	result.EndTime = time.Now()
+	return result
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/report"
+)
+
+var (
+	sweepRevisions string
+	sweepGitRepo   string
+	sweepGitRange  string
+	sweepBisect    bool
+	sweepMetric    string
+	sweepThreshold float64
+)
+
+// sweepCmd benchmarks a sequence of revisions of the same services,
+// re-deploying each revision's image tag in turn, to find where a
+// regression was introduced across a range of commits.
+var sweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "Benchmark a range of revisions (image tags) to find a regression",
+	Long: "Revisions are image tags — each one is expected to already have a " +
+		"matching image pushed (see Config.ImageURI). Provide them directly " +
+		"with --revisions, or have sweep resolve a git commit range to short " +
+		"SHAs with --git-repo and --git-range.\n\n" +
+		"With --bisect, sweep binary-searches the range instead of " +
+		"benchmarking every revision, assuming (like git bisect) that once " +
+		"the regression appears it persists in every later revision.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSweep(cmd)
+	},
+}
+
+func init() {
+	f := sweepCmd.Flags()
+	f.StringVar(&sweepRevisions, "revisions", "", "Comma-separated image tags to benchmark, oldest first")
+	f.StringVar(&sweepGitRepo, "git-repo", "", "Path to a git repo to resolve --git-range against, instead of --revisions")
+	f.StringVar(&sweepGitRange, "git-range", "", "git rev-list range (e.g. v1.0..v1.1) to resolve to revisions, oldest first")
+	f.BoolVar(&sweepBisect, "bisect", false, "Binary-search the revisions for the first regression instead of benchmarking all of them")
+	f.StringVar(&sweepMetric, "metric", "cold_start_p50", "Metric to compare in --bisect mode: cold_start_p50 or warm_p50")
+	f.Float64Var(&sweepThreshold, "threshold", 10, "Percent regression (vs the oldest revision) that --bisect searches for")
+}
+
+func runSweep(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+
+	revisions, err := resolveSweepRevisions()
+	if err != nil {
+		return err
+	}
+
+	runner, err := benchmark.NewRunner(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("creating runner: %w", err)
+	}
+	defer runner.Close()
+
+	sweeper := benchmark.NewSweeper(runner, benchmark.SweepConfig{Revisions: revisions})
+
+	if sweepBisect {
+		culprit, err := sweeper.Bisect(ctx, benchmark.SweepMetric(sweepMetric), sweepThreshold)
+		if err != nil {
+			return fmt.Errorf("bisecting: %w", err)
+		}
+		fmt.Printf("First revision to regress by more than %.1f%%: %s\n", sweepThreshold, culprit)
+		return nil
+	}
+
+	results, err := sweeper.Run(ctx)
+	if err != nil {
+		return fmt.Errorf("running sweep: %w", err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	for _, r := range results {
+		if r.Result == nil {
+			continue
+		}
+		revDir := filepath.Join(outputDir, "sweep", r.Revision)
+		if err := os.MkdirAll(revDir, 0755); err != nil {
+			return fmt.Errorf("creating revision directory for %s: %w", r.Revision, err)
+		}
+		if err := report.WriteJSON(r.Result, filepath.Join(revDir, "results.json")); err != nil {
+			return fmt.Errorf("writing results for %s: %w", r.Revision, err)
+		}
+		if err := report.WriteMarkdown(r.Result, filepath.Join(revDir, "results.md")); err != nil {
+			return fmt.Errorf("writing report for %s: %w", r.Revision, err)
+		}
+	}
+
+	sweepPath := filepath.Join(outputDir, "sweep.md")
+	if err := report.WriteSweepMarkdown(results, sweepPath); err != nil {
+		return fmt.Errorf("writing sweep report: %w", err)
+	}
+	fmt.Printf("Sweep report written to: %s\n", sweepPath)
+
+	return nil
+}
+
+// resolveSweepRevisions returns the configured --revisions, or resolves
+// --git-range to a list of short SHAs via `git rev-list` when --git-repo is
+// set instead.
+func resolveSweepRevisions() ([]string, error) {
+	if sweepGitRepo != "" {
+		if sweepGitRange == "" {
+			return nil, fmt.Errorf("--git-range is required with --git-repo")
+		}
+
+		out, err := exec.Command("git", "-C", sweepGitRepo, "rev-list", "--reverse", "--abbrev-commit", sweepGitRange).Output()
+		if err != nil {
+			return nil, fmt.Errorf("resolving git range %s: %w", sweepGitRange, err)
+		}
+
+		var revisions []string
+		for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+			if line != "" {
+				revisions = append(revisions, line)
+			}
+		}
+		if len(revisions) == 0 {
+			return nil, fmt.Errorf("git range %s contained no commits", sweepGitRange)
+		}
+		return revisions, nil
+	}
+
+	if sweepRevisions == "" {
+		return nil, fmt.Errorf("--revisions or --git-repo/--git-range is required")
+	}
+	return strings.Split(sweepRevisions, ","), nil
+}
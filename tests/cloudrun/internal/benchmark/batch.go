@@ -2,20 +2,31 @@ package benchmark
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/config"
 	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
 )
 
 // DeployedService holds information about a deployed service for batch testing.
 type DeployedService struct {
-	Name        string
-	FullName    string
-	URL         string
-	DeployTime  time.Duration
-	DeployError error
+	Name          string
+	Region        string
+	FullName      string
+	URL           string
+	DeployTime    time.Duration
+	DeployRetries gcp.RetryMetrics
+	DeployError   error
 }
 
 // BatchResult contains results from a batch benchmark run.
@@ -23,57 +34,203 @@ type BatchResult struct {
 	DeployedServices map[string]*DeployedService
 	ColdStartResults map[string][]*ColdStartResult // service -> iterations
 	WarmResults      map[string]*WarmRequestStats
+
+	// Profiles holds whatever the configured ProfileCollectors captured
+	// for each service, keyed by service name. Empty unless
+	// config.BenchmarkConfig.Profiling was enabled.
+	Profiles map[string][]ProfileArtifact
 }
 
-// deployAll deploys all enabled services and returns their URLs.
-func (r *Runner) deployAll(ctx context.Context) map[string]*DeployedService {
-	results := make(map[string]*DeployedService)
-	profile := r.config.GetProfile("default")
+// profileCollectors builds the ProfileCollectors RunBatch should attach to
+// this run, or nil if benchmark.profiling is disabled in config.
+func (r *Runner) profileCollectors() []ProfileCollector {
+	if !r.config.Benchmark.Profiling.Enabled {
+		return nil
+	}
 
-	for _, service := range r.config.Services.Enabled {
-		fmt.Printf("Deploying %s...\n", service)
-		deployStart := time.Now()
-
-		deployConfig := gcp.DeployConfig{
-			ServiceName:     service,
-			RunID:           r.config.RunID,
-			Image:           r.config.ImageURI(service, "latest"),
-			CPU:             profile.CPU,
-			Memory:          profile.Memory,
-			MaxInstances:    profile.MaxInstances,
-			Concurrency:     profile.Concurrency,
-			ExecutionEnv:    profile.ExecutionEnv,
-			StartupCPUBoost: profile.StartupCPUBoost,
-			EnvVars: map[string]string{
-				"DISCORD_PUBLIC_KEY":   r.signer.PublicKeyHex(),
-				"PUBSUB_TOPIC":         r.pubsub.GetTopicName(gcp.PubSubConfig{RunID: r.config.RunID}),
-				"GOOGLE_CLOUD_PROJECT": r.config.GCP.ProjectID,
-			},
-		}
+	collectors := []ProfileCollector{
+		&CloudProfilerCollector{
+			RunID:     r.config.RunID,
+			ProjectID: r.config.GCP.ProjectID,
+		},
+	}
 
-		serviceURL, err := r.cloudrun.Deploy(ctx, deployConfig)
-		deployTime := time.Since(deployStart)
+	if r.config.OutputDir != "" {
+		collectors = append(collectors, &PprofScraper{
+			OutputDir: filepath.Join(r.config.OutputDir, r.config.RunID, "profiles"),
+			RunID:     r.config.RunID,
+			Types:     r.config.Benchmark.Profiling.Types,
+			Duration:  r.config.Benchmark.Profiling.Duration,
+		})
+	}
 
-		result := &DeployedService{
-			Name:        service,
-			FullName:    deployConfig.FullServiceName(),
-			DeployTime:  deployTime,
-			DeployError: err,
-		}
+	return collectors
+}
+
+// batchRegions returns the regions RunBatch should deploy into, falling
+// back to r.config.GCP.Region alone if Regions is empty, and whether more
+// than one region is active (which switches deployAll and its callers over
+// to "{service}@{region}" composite keys instead of plain service names).
+func (r *Runner) batchRegions() (regions []string, multiRegion bool) {
+	regions = r.config.GCP.Regions
+	if len(regions) == 0 {
+		regions = []string{r.config.GCP.Region}
+	}
+	return regions, len(regions) > 1
+}
 
+// batchKey returns the map key deployAll and its callers use for service
+// deployed into region: the bare service name for a single-region run, or
+// "{service}@{region}" once Regions names more than one region.
+func batchKey(service, region string, multiRegion bool) string {
+	if !multiRegion {
+		return service
+	}
+	return fmt.Sprintf("%s@%s", service, region)
+}
+
+// deployAll deploys the cartesian product of services and the active
+// regions (see batchRegions) concurrently, bounded by
+// config.Benchmark.Regions.MaxParallel (0 = unbounded), and returns the
+// results keyed by batchKey. services is passed explicitly, rather than
+// read from r.config.Services.Enabled directly, so RunBatchResume can
+// redeploy only the services a prior run left undeployed.
+func (r *Runner) deployAll(ctx context.Context, services []string, collectors []ProfileCollector) map[string]*DeployedService {
+	profile := r.config.GetProfile("default")
+	regions, multiRegion := r.batchRegions()
+
+	clients := make(map[string]*gcp.CloudRunClient, len(regions))
+	clients[r.config.GCP.Region] = r.cloudrun
+	for _, region := range regions {
+		if _, ok := clients[region]; ok {
+			continue
+		}
+		client, err := gcp.NewCloudRunClient(ctx, r.config.GCP.ProjectID, region, r.config.GCP.KeyFilePath)
 		if err != nil {
-			fmt.Printf("  Failed to deploy %s: %v\n", service, err)
-		} else {
-			result.URL = serviceURL
-			fmt.Printf("  Deployed %s -> %s (took %v)\n", service, serviceURL, deployTime)
+			fmt.Printf("  Failed to create Cloud Run client for %s: %v\n", region, err)
+			continue
 		}
+		clients[region] = client
+	}
+
+	var sem chan struct{}
+	if maxParallel := r.config.Benchmark.Regions.MaxParallel; maxParallel > 0 {
+		sem = make(chan struct{}, maxParallel)
+	}
+
+	results := make(map[string]*DeployedService)
+	var resultsMu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, service := range services {
+		for _, region := range regions {
+			client, ok := clients[region]
+			if !ok {
+				continue
+			}
+
+			key := batchKey(service, region, multiRegion)
 
-		results[service] = result
+			if sem != nil {
+				sem <- struct{}{}
+			}
+			wg.Add(1)
+			go func(service, region, key string, client *gcp.CloudRunClient) {
+				defer wg.Done()
+				if sem != nil {
+					defer func() { <-sem }()
+				}
+
+				deployed := r.deployOne(ctx, client, service, region, key, profile, collectors)
+
+				resultsMu.Lock()
+				results[key] = deployed
+				resultsMu.Unlock()
+			}(service, region, key, client)
+		}
 	}
 
+	wg.Wait()
 	return results
 }
 
+// deployOne deploys service into region via client, reporting progress and
+// telemetry the same way as the single-region path it replaces. key
+// identifies this (service, region) pair to the progress reporter and
+// event sink, so two regions deploying the same service concurrently
+// don't collide on a shared progress bar.
+func (r *Runner) deployOne(ctx context.Context, client *gcp.CloudRunClient, service, region, key string, profile config.ProfileConfig, collectors []ProfileCollector) *DeployedService {
+	fmt.Printf("Deploying %s in %s...\n", service, region)
+	emit(r.events, Event{Type: EventDeployStart, Service: key})
+	if r.progress != nil {
+		r.progress.StartPhase(key, PhaseDeploy, 1)
+	}
+	deployStart := time.Now()
+	deployCtx, deploySpan := r.telemetry.Tracer().Start(ctx, "benchmark.deploy", trace.WithAttributes(
+		attribute.String("cloudrun.service_name", service),
+		attribute.String("cloudrun.region", region),
+	))
+
+	deployConfig := gcp.DeployConfig{
+		ServiceName:     service,
+		RunID:           r.config.RunID,
+		Image:           r.config.ImageURI(service, "latest"),
+		CPU:             profile.CPU,
+		Memory:          profile.Memory,
+		MaxInstances:    profile.MaxInstances,
+		Concurrency:     profile.Concurrency,
+		ExecutionEnv:    profile.ExecutionEnv,
+		StartupCPUBoost: profile.StartupCPUBoost,
+		EnvVars: map[string]string{
+			"DISCORD_PUBLIC_KEY":   r.signer.PublicKeyHex(),
+			"PUBSUB_TOPIC":         r.pubsub.GetTopicName(gcp.PubSubConfig{RunID: r.config.RunID}),
+			"GOOGLE_CLOUD_PROJECT": r.config.GCP.ProjectID,
+		},
+	}
+
+	for _, collector := range collectors {
+		collector.ConfigureDeploy(&deployConfig)
+	}
+
+	deployResult, err := client.Deploy(deployCtx, deployConfig)
+	deployTime := time.Since(deployStart)
+
+	deployDurationHistogram, _ := r.telemetry.Meter().Float64Histogram("cloudrun_benchmark.deploy.duration_seconds",
+		metric.WithDescription("Time to deploy a service revision."), metric.WithUnit("s"))
+	deployDurationHistogram.Record(ctx, deployTime.Seconds(), metric.WithAttributes(attribute.String("cloudrun.service_name", service)))
+	observeDeployDuration(r.metrics, service, deployTime)
+
+	result := &DeployedService{
+		Name:          service,
+		Region:        region,
+		FullName:      deployConfig.FullServiceName(),
+		DeployTime:    deployTime,
+		DeployRetries: deployResult.Retries,
+		DeployError:   err,
+	}
+
+	if err != nil {
+		fmt.Printf("  Failed to deploy %s in %s: %v\n", service, region, err)
+		emit(r.events, Event{Type: EventDeployEnd, Service: key, Error: err.Error()})
+		deploySpan.RecordError(err)
+	} else {
+		result.URL = deployResult.ServiceURL
+		fmt.Printf("  Deployed %s in %s -> %s (took %v)\n", service, region, deployResult.ServiceURL, deployTime)
+		emit(r.events, Event{Type: EventDeployEnd, Service: key})
+		if r.progress != nil {
+			r.progress.AdvanceRequests(key, PhaseDeploy, 1)
+		}
+	}
+	deploySpan.End()
+	if r.progress != nil {
+		r.progress.EndPhase(key, PhaseDeploy)
+	}
+
+	emitDeployResult(r.resultSink, key, result)
+
+	return result
+}
+
 // waitAllScaleToZero waits until all deployed services have scaled to zero instances.
 func (r *Runner) waitAllScaleToZero(ctx context.Context, services map[string]*DeployedService) error {
 	fmt.Println("Waiting for all services to scale to zero...")
@@ -93,12 +250,13 @@ func (r *Runner) waitAllScaleToZero(ctx context.Context, services map[string]*De
 
 			scaleConfig := ScaleToZeroConfig{
 				ProjectID:   r.config.GCP.ProjectID,
-				Region:      r.config.GCP.Region,
+				Region:      service.Region,
 				ServiceName: service.FullName,
+				KeyFilePath: r.config.GCP.KeyFilePath,
 				Timeout:     r.config.Benchmark.ScaleToZeroTimeout,
 			}
 
-			if err := WaitForScaleToZero(ctx, scaleConfig); err != nil {
+			if _, err := WaitForScaleToZero(ctx, scaleConfig); err != nil {
 				errors <- fmt.Errorf("%s: %w", service.Name, err)
 			}
 		}(svc)
@@ -127,21 +285,49 @@ func (r *Runner) waitAllScaleToZero(ctx context.Context, services map[string]*De
 func (r *Runner) testAllColdStart(ctx context.Context, services map[string]*DeployedService, iteration int) map[string]*ColdStartResult {
 	results := make(map[string]*ColdStartResult)
 
-	for _, svc := range services {
+	for key, svc := range services {
 		if svc.DeployError != nil || svc.URL == "" {
 			continue
 		}
 
-		fmt.Printf("  Cold start test: %s (iteration %d)...\n", svc.Name, iteration+1)
+		fmt.Printf("  Cold start test: %s in %s (iteration %d)...\n", svc.Name, svc.Region, iteration+1)
 
 		// Record the time before making the request (for log queries)
 		requestStartTime := time.Now()
 
-		result, err := MeasureColdStart(ctx, svc.URL, r.signer)
+		spanCtx, span := r.telemetry.Tracer().Start(ctx, "benchmark.cold_start", trace.WithAttributes(
+			attribute.String("cloudrun.service_name", svc.FullName),
+			attribute.String("cloudrun.region", svc.Region),
+			attribute.Bool("cloudrun.cold_start", true),
+			attribute.Int("cloudrun.iteration", iteration+1),
+		))
+		result, err := MeasureColdStart(spanCtx, svc.URL, r.signer, "")
 		if err != nil {
 			fmt.Printf("    Warning: cold start measurement failed for %s: %v\n", svc.Name, err)
+			span.RecordError(err)
 		} else {
 			fmt.Printf("    %s TTFB: %v\n", svc.Name, result.TTFB)
+			ttfbHistogram, _ := r.telemetry.Meter().Float64Histogram("cloudrun_benchmark.cold_start.ttfb_seconds",
+				metric.WithDescription("Cold-start time-to-first-byte."), metric.WithUnit("s"))
+			ttfbHistogram.Record(ctx, result.TTFB.Seconds(), metric.WithAttributes(attribute.String("cloudrun.service_name", svc.Name)))
+		}
+		span.End()
+		observeColdStart(r.metrics, svc.Name, result.TTFB, result.Error == nil)
+		emitColdStartResult(r.resultSink, iteration, key, result)
+
+		emit(r.events, Event{
+			Type:      EventColdStartIteration,
+			Service:   key,
+			Iteration: iteration + 1,
+			TTFB:      result.TTFB.String(),
+			Success:   result.Error == nil,
+		})
+
+		if r.progress != nil {
+			r.progress.AdvanceRequests(key, PhaseColdStart, 1)
+			if result.Error == nil {
+				r.progress.RecordLatency(key, PhaseColdStart, result.TTFB)
+			}
 		}
 
 		// Try to get container startup time from Cloud Logging
@@ -149,7 +335,7 @@ func (r *Runner) testAllColdStart(ctx context.Context, services map[string]*Depl
 			metrics, err := r.logging.WaitForStartupLog(
 				ctx,
 				svc.FullName,
-				r.config.GCP.Region,
+				svc.Region,
 				requestStartTime,
 				30*time.Second,
 			)
@@ -159,30 +345,38 @@ func (r *Runner) testAllColdStart(ctx context.Context, services map[string]*Depl
 			}
 		}
 
-		results[svc.Name] = result
+		results[key] = result
 	}
 
 	return results
 }
 
-// testAllWarm runs warm request tests on all services.
-func (r *Runner) testAllWarm(ctx context.Context, services map[string]*DeployedService) map[string]*WarmRequestStats {
+// testAllWarm runs warm request tests on all services, then gives each
+// collector a chance to capture profiles from the now-warm service.
+func (r *Runner) testAllWarm(ctx context.Context, services map[string]*DeployedService, collectors []ProfileCollector) (map[string]*WarmRequestStats, map[string][]ProfileArtifact) {
 	results := make(map[string]*WarmRequestStats)
+	profiles := make(map[string][]ProfileArtifact)
 
-	for _, svc := range services {
+	for key, svc := range services {
 		if svc.DeployError != nil || svc.URL == "" {
 			continue
 		}
 
-		fmt.Printf("  Warm request test: %s (%d requests, %d concurrency)...\n",
-			svc.Name, r.config.Benchmark.WarmRequests, r.config.Benchmark.WarmConcurrency)
+		fmt.Printf("  Warm request test: %s in %s (%d requests, %d concurrency)...\n",
+			svc.Name, svc.Region, r.config.Benchmark.WarmRequests, r.config.Benchmark.WarmConcurrency)
 
 		warmCfg := WarmRequestConfig{
 			ServiceURL:   svc.URL,
+			ServiceName:  key,
+			Region:       svc.Region,
 			RequestCount: r.config.Benchmark.WarmRequests,
 			Concurrency:  r.config.Benchmark.WarmConcurrency,
 			Signer:       r.signer,
 			RequestType:  RequestTypePing,
+			Events:       r.events,
+			Progress:     r.progress,
+			Telemetry:    r.telemetry,
+			Metrics:      r.metrics,
 		}
 
 		stats, err := RunWarmRequestBenchmark(ctx, warmCfg)
@@ -191,61 +385,76 @@ func (r *Runner) testAllWarm(ctx context.Context, services map[string]*DeployedS
 		} else {
 			fmt.Printf("    %s P50: %v, P95: %v, P99: %v (%.1f req/s)\n",
 				svc.Name, stats.P50, stats.P95, stats.P99, stats.RequestsPerSecond)
+			emitWarmResult(r.resultSink, key, stats)
 		}
 
-		results[svc.Name] = stats
-	}
-
-	return results
-}
-
-// RunBatch executes benchmark in batch mode: deploy all → (wait → test all) × iterations.
-// This is more efficient than the sequential approach when testing multiple services.
-func (r *Runner) RunBatch(ctx context.Context) (*BenchmarkResult, error) {
-	result := &BenchmarkResult{
-		RunID:     r.config.RunID,
-		StartTime: time.Now(),
-		Config:    r.config,
-		Services:  make(map[string]*ServiceResult),
-	}
+		for _, collector := range collectors {
+			artifacts, err := collector.Collect(ctx, key, svc.URL)
+			if err != nil {
+				fmt.Printf("    Warning: profile collection failed for %s: %v\n", svc.Name, err)
+			}
+			profiles[key] = append(profiles[key], artifacts...)
+		}
 
-	fmt.Printf("Starting BATCH benchmark run: %s\n", r.config.RunID)
-	fmt.Printf("Services: %v\n", r.config.Services.Enabled)
-	fmt.Printf("Cold start iterations: %d\n", r.config.Benchmark.ColdStartIterations)
+		emit(r.events, Event{Type: EventServiceComplete, Service: key})
 
-	// Setup Pub/Sub resources
-	fmt.Println("\nSetting up Pub/Sub resources...")
-	pubsubCfg := gcp.PubSubConfig{RunID: r.config.RunID}
-	if err := r.pubsub.Setup(ctx, pubsubCfg); err != nil {
-		return nil, fmt.Errorf("setting up Pub/Sub: %w", err)
+		results[key] = stats
 	}
 
-	// Phase 1: Deploy all services
-	fmt.Println("\n=== Phase 1: Deploy All Services ===")
-	deployedServices := r.deployAll(ctx)
+	return results, profiles
+}
 
-	// Initialize service results
+// initServiceResults seeds result.Services from deployedServices, one
+// ServiceResult per deployed (service, region) pair, ready for
+// runColdStartAndWarm to fill in. Shared by RunBatch and RunBatchResume.
+func (r *Runner) initServiceResults(result *BenchmarkResult, deployedServices map[string]*DeployedService) {
 	for name, deployed := range deployedServices {
 		result.Services[name] = &ServiceResult{
-			ServiceName:        name,
+			ServiceName:        deployed.Name,
+			Region:             deployed.Region,
 			ServiceURL:         deployed.URL,
 			Profile:            "default",
 			DeploymentDuration: deployed.DeployTime,
-			Image:              r.config.ImageURI(name, "latest"),
+			Image:              r.config.ImageURI(deployed.Name, "latest"),
 			DeployError:        deployed.DeployError,
 			ColdStart: &ColdStartStats{
 				Results: make([]ColdStartResult, 0, r.config.Benchmark.ColdStartIterations),
 			},
 		}
 	}
+}
 
-	// Phase 2 & 3: For each iteration, wait for scale-to-zero then test all
+// runColdStartAndWarm runs Phase 2 & 3 (cold start iterations, starting at
+// startIteration) and Phase 4 (warm request testing) against
+// deployedServices, filling in result.Services as it goes. It's shared by
+// RunBatch (startIteration 0) and RunBatchResume (startIteration resumed
+// from a prior run's NDJSON stream), so the two phases only need writing
+// once. The scale-to-zero wait that normally precedes every iteration
+// after the first is also run before startIteration itself whenever
+// startIteration > 0, since a resumed run's services aren't guaranteed to
+// still be cold the way a freshly deployed service is.
+func (r *Runner) runColdStartAndWarm(ctx context.Context, result *BenchmarkResult, deployedServices map[string]*DeployedService, startIteration int, collectors []ProfileCollector) {
 	fmt.Printf("\n=== Phase 2 & 3: Cold Start Testing (%d iterations) ===\n", r.config.Benchmark.ColdStartIterations)
-	for iter := 0; iter < r.config.Benchmark.ColdStartIterations; iter++ {
+
+	if r.progress != nil {
+		for name, deployed := range deployedServices {
+			if deployed.DeployError == nil && deployed.URL != "" {
+				r.progress.StartPhase(name, PhaseColdStart, r.config.Benchmark.ColdStartIterations)
+			}
+		}
+	}
+
+	for iter := startIteration; iter < r.config.Benchmark.ColdStartIterations; iter++ {
+		if ctx.Err() != nil {
+			fmt.Printf("\nStopping: %v (keeping results gathered so far)\n", ctx.Err())
+			break
+		}
+
 		fmt.Printf("\n--- Iteration %d/%d ---\n", iter+1, r.config.Benchmark.ColdStartIterations)
 
-		// Skip scale-to-zero wait on first iteration (services start cold)
-		if iter > 0 {
+		// Skip scale-to-zero wait on the very first iteration of a fresh
+		// run (services start cold), but not when resuming partway through.
+		if iter > startIteration || startIteration > 0 {
 			if err := r.waitAllScaleToZero(ctx, deployedServices); err != nil {
 				fmt.Printf("Warning: scale-to-zero wait failed: %v\n", err)
 				// Continue anyway - we might still get useful measurements
@@ -268,6 +477,14 @@ func (r *Runner) RunBatch(ctx context.Context) (*BenchmarkResult, error) {
 		}
 	}
 
+	if r.progress != nil {
+		for name, deployed := range deployedServices {
+			if deployed.DeployError == nil && deployed.URL != "" {
+				r.progress.EndPhase(name, PhaseColdStart)
+			}
+		}
+	}
+
 	// Calculate cold start statistics
 	for _, svcResult := range result.Services {
 		if svcResult.ColdStart != nil {
@@ -277,7 +494,7 @@ func (r *Runner) RunBatch(ctx context.Context) (*BenchmarkResult, error) {
 
 	// Phase 4: Warm request tests (services should be warm after cold start tests)
 	fmt.Println("\n=== Phase 4: Warm Request Testing ===")
-	warmResults := r.testAllWarm(ctx, deployedServices)
+	warmResults, profiles := r.testAllWarm(ctx, deployedServices, collectors)
 
 	for name, warmStats := range warmResults {
 		if svcResult, ok := result.Services[name]; ok {
@@ -285,7 +502,45 @@ func (r *Runner) RunBatch(ctx context.Context) (*BenchmarkResult, error) {
 		}
 	}
 
+	for name, artifacts := range profiles {
+		if svcResult, ok := result.Services[name]; ok {
+			svcResult.Profiles = artifacts
+		}
+	}
+}
+
+// RunBatch executes benchmark in batch mode: deploy all → (wait → test all) × iterations.
+// This is more efficient than the sequential approach when testing multiple services.
+func (r *Runner) RunBatch(ctx context.Context) (*BenchmarkResult, error) {
+	result := &BenchmarkResult{
+		RunID:     r.config.RunID,
+		StartTime: time.Now(),
+		Config:    r.config,
+		Services:  make(map[string]*ServiceResult),
+	}
+
+	fmt.Printf("Starting BATCH benchmark run: %s\n", r.config.RunID)
+	fmt.Printf("Services: %v\n", r.config.Services.Enabled)
+	fmt.Printf("Cold start iterations: %d\n", r.config.Benchmark.ColdStartIterations)
+
+	// Setup Pub/Sub resources
+	fmt.Println("\nSetting up Pub/Sub resources...")
+	pubsubCfg := gcp.PubSubConfig{RunID: r.config.RunID}
+	if err := r.pubsub.Setup(ctx, pubsubCfg); err != nil {
+		return nil, fmt.Errorf("setting up Pub/Sub: %w", err)
+	}
+
+	collectors := r.profileCollectors()
+
+	// Phase 1: Deploy all services
+	fmt.Println("\n=== Phase 1: Deploy All Services ===")
+	deployedServices := r.deployAll(ctx, r.config.Services.Enabled, collectors)
+
+	r.initServiceResults(result, deployedServices)
+	r.runColdStartAndWarm(ctx, result, deployedServices, 0, collectors)
+
 	result.EndTime = time.Now()
+	emitSummaryResult(r.resultSink, result)
 
 	// Print summary
 	fmt.Printf("\n=== Benchmark Complete ===\n")
@@ -294,3 +549,110 @@ func (r *Runner) RunBatch(ctx context.Context) (*BenchmarkResult, error) {
 
 	return result, nil
 }
+
+// RunBatchResume continues an interrupted batch run from ndjsonPath, the
+// NDJSON output of a prior run's ResultSink (see report.NDJSONResultSink).
+// Services with a successful "deploy" record are reused as-is rather than
+// redeployed; any other configured service is deployed fresh via deployAll.
+// The cold-start iteration loop resumes from the lowest iteration count
+// completed across the resumed services, so re-running a benchmark that
+// died partway through doesn't throw away the work it already did.
+func (r *Runner) RunBatchResume(ctx context.Context, ndjsonPath string) (*BenchmarkResult, error) {
+	deployedServices, startIteration, err := r.readResumeState(ndjsonPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Resuming BATCH benchmark run: %s\n", r.config.RunID)
+	fmt.Printf("Resumed %d deployed service(s) from %s, starting at cold-start iteration %d\n",
+		len(deployedServices), ndjsonPath, startIteration+1)
+
+	regions, multiRegion := r.batchRegions()
+	var missing []string
+	for _, service := range r.config.Services.Enabled {
+		for _, region := range regions {
+			if _, ok := deployedServices[batchKey(service, region, multiRegion)]; !ok {
+				missing = append(missing, service)
+				break
+			}
+		}
+	}
+
+	collectors := r.profileCollectors()
+
+	if len(missing) > 0 {
+		fmt.Printf("\n=== Phase 1: Deploy %d Missing Service(s) ===\n", len(missing))
+		for key, deployed := range r.deployAll(ctx, missing, collectors) {
+			deployedServices[key] = deployed
+		}
+	}
+
+	result := &BenchmarkResult{
+		RunID:     r.config.RunID,
+		StartTime: time.Now(),
+		Config:    r.config,
+		Services:  make(map[string]*ServiceResult),
+	}
+
+	r.initServiceResults(result, deployedServices)
+	r.runColdStartAndWarm(ctx, result, deployedServices, startIteration, collectors)
+
+	result.EndTime = time.Now()
+	emitSummaryResult(r.resultSink, result)
+
+	fmt.Printf("\n=== Benchmark Complete ===\n")
+	fmt.Printf("Total time: %v\n", result.EndTime.Sub(result.StartTime))
+	fmt.Printf("Services tested: %d\n", len(result.Services))
+
+	return result, nil
+}
+
+// readResumeState replays a prior run's NDJSON ResultSink output, returning
+// the services that deployed successfully (keyed the same way deployAll
+// keys its own results) and the cold-start iteration to resume from: one
+// past the fewest iterations any resumed service completed.
+func (r *Runner) readResumeState(ndjsonPath string) (map[string]*DeployedService, int, error) {
+	f, err := os.Open(ndjsonPath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("opening resume file: %w", err)
+	}
+	defer f.Close()
+
+	deployedServices := make(map[string]*DeployedService)
+	iterationsSeen := make(map[string]int)
+
+	dec := json.NewDecoder(f)
+	for {
+		var rec resumeRecord
+		if err := dec.Decode(&rec); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, 0, fmt.Errorf("parsing resume file: %w", err)
+		}
+
+		switch rec.Type {
+		case "deploy":
+			if rec.Deploy != nil && rec.Deploy.DeployError == nil && rec.Deploy.URL != "" {
+				deployedServices[rec.Key] = rec.Deploy
+			}
+		case "cold_start":
+			if rec.Iteration+1 > iterationsSeen[rec.Key] {
+				iterationsSeen[rec.Key] = rec.Iteration + 1
+			}
+		}
+	}
+
+	if len(deployedServices) == 0 {
+		return nil, 0, fmt.Errorf("resume file %s has no successful deployments to resume from", ndjsonPath)
+	}
+
+	startIteration := -1
+	for key := range deployedServices {
+		if completed := iterationsSeen[key]; startIteration == -1 || completed < startIteration {
+			startIteration = completed
+		}
+	}
+
+	return deployedServices, startIteration, nil
+}
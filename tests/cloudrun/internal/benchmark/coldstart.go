@@ -3,14 +3,25 @@ package benchmark
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"net/http/httptrace"
 	"sort"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/config"
 	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/latency"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/profiling"
 	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/signing"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/telemetry"
 )
 
 // ColdStartResult contains the results of a single cold start measurement.
@@ -21,6 +32,14 @@ type ColdStartResult struct {
 	TotalLatency time.Duration
 	// ContainerStartup is the container startup time from Cloud Logging (if available)
 	ContainerStartup time.Duration
+	// Phases breaks TTFB down into its httptrace-observed components.
+	Phases ColdStartPhases
+	// AppInitLatency is TTFB with the known network phases and (if
+	// available) Cloud Logging's ContainerStartup subtracted out, leaving
+	// roughly the Go handler's own init cost (ed25519 verification, JSON
+	// unmarshal, Pub/Sub client setup). Zero if ContainerStartup wasn't
+	// available for this iteration.
+	AppInitLatency time.Duration
 	// StatusCode is the HTTP response status code
 	StatusCode int
 	// Timestamp is when the measurement was taken
@@ -29,6 +48,27 @@ type ColdStartResult struct {
 	Error error
 }
 
+// ColdStartPhases breaks a single cold-start request down via
+// httptrace.ClientTrace. DNSLookup, TCPConnect, TLSHandshake, and
+// WroteRequest are discrete (non-overlapping) phase costs and can be summed
+// into a "network" total; GotFirstResponseByte and ReadBody are cumulative
+// from request start, mirroring TTFB and TotalLatency respectively.
+type ColdStartPhases struct {
+	DNSLookup            time.Duration
+	TCPConnect           time.Duration
+	TLSHandshake         time.Duration
+	WroteRequest         time.Duration
+	GotFirstResponseByte time.Duration
+	ReadBody             time.Duration
+}
+
+// NetworkDuration is the sum of the discrete connection-establishment and
+// request-write phases, i.e. client-side overhead before the server starts
+// processing the request.
+func (p ColdStartPhases) NetworkDuration() time.Duration {
+	return p.DNSLookup + p.TCPConnect + p.TLSHandshake + p.WroteRequest
+}
+
 // ColdStartStats contains aggregated statistics from multiple cold start measurements.
 type ColdStartStats struct {
 	// Individual results
@@ -42,6 +82,11 @@ type ColdStartStats struct {
 	TTFBP95 time.Duration
 	TTFBP99 time.Duration
 
+	// TTFBHistogram is the full HDR-histogram-backed distribution of TTFB
+	// samples, letting consumers recompute arbitrary quantiles or merge
+	// results across runs without access to the raw samples.
+	TTFBHistogram *latency.Histogram
+
 	// Container startup statistics (from Cloud Logging)
 	ContainerStartupMin time.Duration
 	ContainerStartupMax time.Duration
@@ -50,6 +95,42 @@ type ColdStartStats struct {
 	// Success/failure counts
 	SuccessCount int
 	FailureCount int
+
+	// Profiles captured during the first iteration's startup window, if
+	// profiling was enabled. Empty unless ColdStartConfig.Profiling was set.
+	Profiles []*profiling.Profile
+
+	// Phases aggregates each httptrace-observed phase across successful
+	// iterations.
+	Phases ColdStartPhaseStats
+
+	// AppInitLatency aggregates ColdStartResult.AppInitLatency across
+	// iterations where a ContainerStartup reading was available.
+	AppInitLatency PhaseStat
+
+	// Regions holds one ColdStartStats per region, keyed by region name,
+	// for a Runner.RunRegional benchmark. Nil for a single-region run.
+	Regions map[string]*ColdStartStats
+}
+
+// PhaseStat is min/avg/P50/P95 for one ColdStartPhases field (or
+// AppInitLatency) across a batch of iterations.
+type PhaseStat struct {
+	Min time.Duration
+	Avg time.Duration
+	P50 time.Duration
+	P95 time.Duration
+}
+
+// ColdStartPhaseStats aggregates ColdStartPhases across a batch of
+// iterations, one PhaseStat per field.
+type ColdStartPhaseStats struct {
+	DNSLookup            PhaseStat
+	TCPConnect           PhaseStat
+	TLSHandshake         PhaseStat
+	WroteRequest         PhaseStat
+	GotFirstResponseByte PhaseStat
+	ReadBody             PhaseStat
 }
 
 // ColdStartConfig contains configuration for cold start benchmarking.
@@ -60,13 +141,98 @@ type ColdStartConfig struct {
 	Region             string
 	Iterations         int
 	ScaleToZeroTimeout time.Duration
-	Signer             *signing.Signer
+	Signer             signing.Signer
 	LoggingClient      *gcp.LoggingClient
+
+	// IDToken, if set, is sent as an "Authorization: Bearer" header on
+	// each measurement request, for a service deployed with a
+	// non-public gcp.InvokerPolicy.
+	IDToken string
+
+	// Events, if set, receives EventColdStartIteration events as each
+	// iteration completes.
+	Events EventSink
+
+	// Progress, if set, receives live per-iteration progress updates for a
+	// CLI to render as a bar.
+	Progress ProgressReporter
+
+	// Telemetry, if set, traces each iteration as a span and records TTFB
+	// and error counts through its meter. A nil Telemetry behaves like a
+	// Provider configured with no endpoint: spans and metrics are no-ops.
+	Telemetry *telemetry.Provider
+
+	// Metrics, if set, receives each iteration's TTFB as it completes, for
+	// an external exporter like report.PrometheusRegistry to update live.
+	Metrics MetricsSink
+
+	// Profiling, if enabled, triggers a profile fetch spanning the first
+	// iteration's startup window via ProfilingClient.
+	Profiling       config.ProfilingConfig
+	ProfilingClient *profiling.Client
+
+	// Convergence, if enabled, stops sampling once the running P95
+	// estimate's bootstrap confidence interval narrows below its
+	// Threshold, so a run doesn't burn iterations (and scale-to-zero
+	// waits) past the point where more samples would change the reported
+	// ranking.
+	Convergence ConvergenceConfig
+}
+
+// ConvergenceConfig configures RunColdStartBenchmark's early-stopping
+// behavior.
+type ConvergenceConfig struct {
+	Enabled bool
+
+	// MinIterations is the fewest successful samples gathered before
+	// convergence is even checked, so a handful of suspiciously tight
+	// early samples can't stop the benchmark prematurely. Defaults to 10.
+	MinIterations int
+
+	// Threshold is the maximum acceptable width of the P95 estimate's 95%
+	// bootstrap confidence interval before sampling stops. Defaults to
+	// 50ms.
+	Threshold time.Duration
+
+	// BootstrapSamples is how many resamples ConvergenceCheck draws to
+	// estimate the confidence interval. Defaults to 1000.
+	BootstrapSamples int
+}
+
+// ConvergenceCheck estimates the 95% bootstrap confidence interval around
+// the P95 of samples by resampling (with replacement) bootstrapSamples
+// times and recomputing P95 for each resample. It returns the width of
+// that interval and whether it has narrowed to or below threshold.
+func ConvergenceCheck(samples []time.Duration, bootstrapSamples int, threshold time.Duration) (width time.Duration, converged bool) {
+	if len(samples) < 2 || bootstrapSamples <= 0 {
+		return 0, false
+	}
+
+	estimates := make([]time.Duration, bootstrapSamples)
+	resample := make([]time.Duration, len(samples))
+	for i := 0; i < bootstrapSamples; i++ {
+		for j := range resample {
+			resample[j] = samples[rand.Intn(len(samples))]
+		}
+		sort.Slice(resample, func(a, b int) bool { return resample[a] < resample[b] })
+		estimates[i] = percentile(resample, 95)
+	}
+	sort.Slice(estimates, func(a, b int) bool { return estimates[a] < estimates[b] })
+
+	lo := estimates[int(0.025*float64(len(estimates)))]
+	hiIdx := int(0.975 * float64(len(estimates)))
+	if hiIdx >= len(estimates) {
+		hiIdx = len(estimates) - 1
+	}
+	hi := estimates[hiIdx]
+
+	width = hi - lo
+	return width, width <= threshold
 }
 
 // MeasureColdStart performs a single cold start measurement.
 // It sends a signed Discord ping request and measures the response time.
-func MeasureColdStart(ctx context.Context, serviceURL string, signer *signing.Signer) (*ColdStartResult, error) {
+func MeasureColdStart(ctx context.Context, serviceURL string, signer signing.Signer, idToken string) (*ColdStartResult, error) {
 	result := &ColdStartResult{
 		Timestamp: time.Now(),
 	}
@@ -85,6 +251,9 @@ func MeasureColdStart(ctx context.Context, serviceURL string, signer *signing.Si
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Signature-Ed25519", signature)
 	req.Header.Set("X-Signature-Timestamp", timestamp)
+	if idToken != "" {
+		req.Header.Set("Authorization", "Bearer "+idToken)
+	}
 
 	// Measure the request
 	client := &http.Client{
@@ -92,6 +261,39 @@ func MeasureColdStart(ctx context.Context, serviceURL string, signer *signing.Si
 	}
 
 	start := time.Now()
+
+	var dnsStart, connectStart, tlsStart, connReady time.Time
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			if !dnsStart.IsZero() {
+				result.Phases.DNSLookup = time.Since(dnsStart)
+			}
+		},
+		ConnectStart: func(string, string) { connectStart = time.Now() },
+		ConnectDone: func(network, addr string, err error) {
+			if !connectStart.IsZero() {
+				result.Phases.TCPConnect = time.Since(connectStart)
+			}
+		},
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			if !tlsStart.IsZero() {
+				result.Phases.TLSHandshake = time.Since(tlsStart)
+			}
+		},
+		GotConn: func(httptrace.GotConnInfo) { connReady = time.Now() },
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			if !connReady.IsZero() {
+				result.Phases.WroteRequest = time.Since(connReady)
+			}
+		},
+		GotFirstResponseByte: func() {
+			result.Phases.GotFirstResponseByte = time.Since(start)
+		},
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+
 	resp, err := client.Do(req)
 	if err != nil {
 		result.Error = fmt.Errorf("making request: %w", err)
@@ -104,7 +306,8 @@ func MeasureColdStart(ctx context.Context, serviceURL string, signer *signing.Si
 	_, _ = io.ReadAll(resp.Body)
 
 	result.TotalLatency = time.Since(start)
-	result.TTFB = result.TotalLatency // For now, TTFB approximates total latency
+	result.Phases.ReadBody = result.TotalLatency - result.Phases.GotFirstResponseByte
+	result.TTFB = result.Phases.GotFirstResponseByte
 	result.StatusCode = resp.StatusCode
 
 	if resp.StatusCode != http.StatusOK {
@@ -122,12 +325,39 @@ func RunColdStartBenchmark(ctx context.Context, cfg ColdStartConfig) (*ColdStart
 	if cfg.ScaleToZeroTimeout == 0 {
 		cfg.ScaleToZeroTimeout = 15 * time.Minute
 	}
+	if cfg.Convergence.Enabled {
+		if cfg.Convergence.MinIterations == 0 {
+			cfg.Convergence.MinIterations = 10
+		}
+		if cfg.Convergence.Threshold == 0 {
+			cfg.Convergence.Threshold = 50 * time.Millisecond
+		}
+		if cfg.Convergence.BootstrapSamples == 0 {
+			cfg.Convergence.BootstrapSamples = 1000
+		}
+	}
 
 	stats := &ColdStartStats{
 		Results: make([]ColdStartResult, 0, cfg.Iterations),
 	}
 
+	if cfg.Progress != nil {
+		cfg.Progress.StartPhase(cfg.ServiceName, PhaseColdStart, cfg.Iterations)
+		defer cfg.Progress.EndPhase(cfg.ServiceName, PhaseColdStart)
+	}
+
+	meter := cfg.Telemetry.Meter()
+	ttfbHistogram, _ := meter.Float64Histogram("cloudrun_benchmark.cold_start.ttfb_seconds",
+		metric.WithDescription("Cold-start time-to-first-byte."), metric.WithUnit("s"))
+	errorCounter, _ := meter.Int64Counter("cloudrun_benchmark.cold_start.errors",
+		metric.WithDescription("Cold-start iterations that failed."))
+
 	for i := 0; i < cfg.Iterations; i++ {
+		if ctx.Err() != nil {
+			fmt.Printf("Stopping cold start benchmark: %v (keeping %d iterations gathered so far)\n", ctx.Err(), len(stats.Results))
+			break
+		}
+
 		fmt.Printf("Cold start iteration %d/%d\n", i+1, cfg.Iterations)
 
 		// Skip scale-to-zero wait on first iteration (service might already be cold)
@@ -140,7 +370,11 @@ func RunColdStartBenchmark(ctx context.Context, cfg ColdStartConfig) (*ColdStart
 				Timeout:     cfg.ScaleToZeroTimeout,
 			}
 
-			if err := WaitForScaleToZero(ctx, scaleConfig); err != nil {
+			if _, err := WaitForScaleToZero(ctx, scaleConfig); err != nil {
+				if ctx.Err() != nil {
+					fmt.Printf("Stopping cold start benchmark: %v (keeping %d iterations gathered so far)\n", ctx.Err(), len(stats.Results))
+					break
+				}
 				return nil, fmt.Errorf("waiting for scale to zero: %w", err)
 			}
 		}
@@ -150,14 +384,24 @@ func RunColdStartBenchmark(ctx context.Context, cfg ColdStartConfig) (*ColdStart
 
 		// Measure cold start
 		fmt.Println("  Measuring cold start...")
-		result, err := MeasureColdStart(ctx, cfg.ServiceURL, cfg.Signer)
+		spanCtx, span := cfg.Telemetry.Tracer().Start(ctx, "benchmark.cold_start", trace.WithAttributes(
+			attribute.String("cloudrun.service_name", cfg.ServiceName),
+			attribute.String("cloudrun.region", cfg.Region),
+			attribute.Bool("cloudrun.cold_start", true),
+			attribute.Int("cloudrun.iteration", i+1),
+		))
+		result, err := MeasureColdStart(spanCtx, cfg.ServiceURL, cfg.Signer, cfg.IDToken)
 		if err != nil {
 			fmt.Printf("  Warning: cold start measurement failed: %v\n", err)
 			stats.FailureCount++
+			span.RecordError(err)
+			errorCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("cloudrun.service_name", cfg.ServiceName)))
 		} else {
 			stats.SuccessCount++
 			fmt.Printf("  TTFB: %v\n", result.TTFB)
+			ttfbHistogram.Record(ctx, result.TTFB.Seconds(), metric.WithAttributes(attribute.String("cloudrun.service_name", cfg.ServiceName)))
 		}
+		span.End()
 
 		// Try to get container startup time from Cloud Logging
 		if cfg.LoggingClient != nil && result.Error == nil {
@@ -171,10 +415,53 @@ func RunColdStartBenchmark(ctx context.Context, cfg ColdStartConfig) (*ColdStart
 			if err == nil && metrics.Found {
 				result.ContainerStartup = metrics.ContainerStartupLatency
 				fmt.Printf("  Container startup: %v\n", result.ContainerStartup)
+				result.AppInitLatency = result.TTFB - result.Phases.NetworkDuration() - result.ContainerStartup
+			}
+		}
+
+		// Fetch a profile spanning this iteration's startup window. Only the
+		// first iteration is profiled, since it is the one actually cold.
+		if i == 0 && cfg.Profiling.Enabled && cfg.ProfilingClient != nil {
+			fmt.Println("  Fetching startup profile...")
+			profiles, err := cfg.ProfilingClient.FetchProfiles(ctx, cfg.ServiceName, cfg.Profiling)
+			if err != nil {
+				fmt.Printf("  Warning: fetching profile failed: %v\n", err)
+			} else {
+				stats.Profiles = profiles
 			}
 		}
 
 		stats.Results = append(stats.Results, *result)
+		observeColdStart(cfg.Metrics, cfg.ServiceName, result.TTFB, result.Error == nil)
+
+		emit(cfg.Events, Event{
+			Type:      EventColdStartIteration,
+			Service:   cfg.ServiceName,
+			Iteration: i + 1,
+			TTFB:      result.TTFB.String(),
+			Success:   result.Error == nil,
+		})
+
+		if cfg.Progress != nil {
+			cfg.Progress.AdvanceRequests(cfg.ServiceName, PhaseColdStart, 1)
+			if result.Error == nil {
+				cfg.Progress.RecordLatency(cfg.ServiceName, PhaseColdStart, result.TTFB)
+			}
+		}
+
+		if cfg.Convergence.Enabled && stats.SuccessCount >= cfg.Convergence.MinIterations {
+			var ttfbs []time.Duration
+			for _, r := range stats.Results {
+				if r.Error == nil {
+					ttfbs = append(ttfbs, r.TTFB)
+				}
+			}
+			if width, converged := ConvergenceCheck(ttfbs, cfg.Convergence.BootstrapSamples, cfg.Convergence.Threshold); converged {
+				fmt.Printf("  P95 converged after %d iterations (95%% CI width %v <= %v), stopping early\n",
+					i+1, width, cfg.Convergence.Threshold)
+				break
+			}
+		}
 	}
 
 	// Calculate statistics
@@ -195,18 +482,42 @@ func (s *ColdStartStats) CalculateStats() {
 	var ttfbSum time.Duration
 	var startupSum time.Duration
 
+	var dnsLookups, tcpConnects, tlsHandshakes, wroteRequests, gotFirstBytes, readBodies []time.Duration
+	var appInits []time.Duration
+
+	s.TTFBHistogram = latency.NewHistogram()
+
 	for _, r := range s.Results {
 		if r.Error == nil {
 			ttfbs = append(ttfbs, r.TTFB)
 			ttfbSum += r.TTFB
+			s.TTFBHistogram.RecordValue(r.TTFB)
+
+			dnsLookups = append(dnsLookups, r.Phases.DNSLookup)
+			tcpConnects = append(tcpConnects, r.Phases.TCPConnect)
+			tlsHandshakes = append(tlsHandshakes, r.Phases.TLSHandshake)
+			wroteRequests = append(wroteRequests, r.Phases.WroteRequest)
+			gotFirstBytes = append(gotFirstBytes, r.Phases.GotFirstResponseByte)
+			readBodies = append(readBodies, r.Phases.ReadBody)
 
 			if r.ContainerStartup > 0 {
 				startups = append(startups, r.ContainerStartup)
 				startupSum += r.ContainerStartup
+				appInits = append(appInits, r.AppInitLatency)
 			}
 		}
 	}
 
+	s.Phases = ColdStartPhaseStats{
+		DNSLookup:            calcPhaseStat(dnsLookups),
+		TCPConnect:           calcPhaseStat(tcpConnects),
+		TLSHandshake:         calcPhaseStat(tlsHandshakes),
+		WroteRequest:         calcPhaseStat(wroteRequests),
+		GotFirstResponseByte: calcPhaseStat(gotFirstBytes),
+		ReadBody:             calcPhaseStat(readBodies),
+	}
+	s.AppInitLatency = calcPhaseStat(appInits)
+
 	if len(ttfbs) == 0 {
 		return
 	}
@@ -231,6 +542,70 @@ func (s *ColdStartStats) CalculateStats() {
 	}
 }
 
+// MergeColdStartStats combines one ColdStartStats per region (as gathered
+// by Runner.benchmarkServiceRegional) into a single aggregate. Unlike CalculateStats, the
+// aggregate's TTFB percentiles come from exactly merging each region's
+// TTFBHistogram rather than re-deriving them from concatenated raw
+// samples, so the merge stays cheap no matter how many regions or
+// iterations contributed.
+func MergeColdStartStats(perRegion map[string]*ColdStartStats) (*ColdStartStats, error) {
+	agg := &ColdStartStats{
+		Regions:       perRegion,
+		TTFBHistogram: latency.NewHistogram(),
+	}
+
+	for region, regionStats := range perRegion {
+		if regionStats == nil {
+			continue
+		}
+		agg.SuccessCount += regionStats.SuccessCount
+		agg.FailureCount += regionStats.FailureCount
+		if regionStats.TTFBHistogram != nil {
+			if err := agg.TTFBHistogram.Merge(regionStats.TTFBHistogram); err != nil {
+				return nil, fmt.Errorf("merging %s histogram: %w", region, err)
+			}
+		}
+	}
+
+	if agg.TTFBHistogram.TotalCount() == 0 {
+		return agg, nil
+	}
+
+	buckets := agg.TTFBHistogram.Buckets()
+	agg.TTFBMin = buckets[0].Value
+	agg.TTFBMax = buckets[len(buckets)-1].Value
+	agg.TTFBAvg = agg.TTFBHistogram.Mean()
+	agg.TTFBP50 = agg.TTFBHistogram.ValueAtQuantile(50)
+	agg.TTFBP95 = agg.TTFBHistogram.ValueAtQuantile(95)
+	agg.TTFBP99 = agg.TTFBHistogram.ValueAtQuantile(99)
+
+	return agg, nil
+}
+
+// calcPhaseStat computes min/avg/P50/P95 for an unsorted slice of phase
+// durations, returning the zero PhaseStat if durations is empty.
+func calcPhaseStat(durations []time.Duration) PhaseStat {
+	if len(durations) == 0 {
+		return PhaseStat{}
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+	}
+
+	return PhaseStat{
+		Min: sorted[0],
+		Avg: sum / time.Duration(len(sorted)),
+		P50: percentile(sorted, 50),
+		P95: percentile(sorted, 95),
+	}
+}
+
 // percentile calculates the p-th percentile of a sorted slice.
 func percentile(sorted []time.Duration, p int) time.Duration {
 	if len(sorted) == 0 {
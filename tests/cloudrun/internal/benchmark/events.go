@@ -0,0 +1,52 @@
+package benchmark
+
+import "time"
+
+// EventType identifies the kind of benchmark progress event.
+type EventType string
+
+const (
+	EventDeployStart        EventType = "deploy_start"
+	EventDeployEnd          EventType = "deploy_end"
+	EventColdStartIteration EventType = "cold_start_iteration"
+	EventWarmProgress       EventType = "warm_progress"
+	EventServiceComplete    EventType = "service_complete"
+)
+
+// Event is a single benchmark progress event, emitted in real time as the
+// runner executes so operators can follow along without waiting for the
+// final BenchmarkResult.
+type Event struct {
+	Type      EventType `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Service   string    `json:"service,omitempty"`
+
+	// Iteration and TTFB are populated for EventColdStartIteration.
+	Iteration int    `json:"iteration,omitempty"`
+	TTFB      string `json:"ttfb,omitempty"`
+	Success   bool   `json:"success,omitempty"`
+
+	// RequestsDone, RPS and P95 are populated for EventWarmProgress.
+	RequestsDone int     `json:"requests_done,omitempty"`
+	RPS          float64 `json:"requests_per_second,omitempty"`
+	P95          string  `json:"p95,omitempty"`
+
+	// Error is populated for failed deploy_end/service_complete events.
+	Error string `json:"error,omitempty"`
+}
+
+// EventSink receives benchmark progress events. Implementations must be
+// safe for concurrent use, since warm request workers emit from multiple
+// goroutines.
+type EventSink interface {
+	Emit(event Event)
+}
+
+// emit sends an event to sink if one is configured, stamping the timestamp.
+func emit(sink EventSink, event Event) {
+	if sink == nil {
+		return
+	}
+	event.Timestamp = time.Now()
+	sink.Emit(event)
+}
@@ -0,0 +1,100 @@
+package benchmark
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// HarnessProfilePaths holds the local paths a harnessProfiler wrote to,
+// relative to nothing in particular — callers (e.g. report.WriteMarkdown)
+// link to them as-is.
+type HarnessProfilePaths struct {
+	CPU   string
+	Trace string
+	Heap  string
+}
+
+// harnessProfiler captures CPU and execution trace profiles of this process
+// (the benchmark harness itself, not the deployed Cloud Run service) for the
+// duration it's in scope, writing a heap snapshot when stopped.
+type harnessProfiler struct {
+	cpuFile   *os.File
+	traceFile *os.File
+	paths     HarnessProfilePaths
+}
+
+// startHarnessProfile begins capturing a CPU profile and execution trace
+// into dir/service.{cpu,trace}.pprof. Returns nil, nil if dir is empty
+// (harness profiling disabled).
+func startHarnessProfile(dir, service string) (*harnessProfiler, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating harness profile directory: %w", err)
+	}
+
+	p := &harnessProfiler{}
+
+	cpuPath := filepath.Join(dir, service+".cpu.pprof")
+	cpuFile, err := os.Create(cpuPath)
+	if err != nil {
+		return nil, fmt.Errorf("creating CPU profile file: %w", err)
+	}
+	if err := pprof.StartCPUProfile(cpuFile); err != nil {
+		cpuFile.Close()
+		return nil, fmt.Errorf("starting CPU profile: %w", err)
+	}
+	p.cpuFile = cpuFile
+	p.paths.CPU = cpuPath
+
+	tracePath := filepath.Join(dir, service+".trace.pprof")
+	traceFile, err := os.Create(tracePath)
+	if err != nil {
+		pprof.StopCPUProfile()
+		cpuFile.Close()
+		return nil, fmt.Errorf("creating trace file: %w", err)
+	}
+	if err := trace.Start(traceFile); err != nil {
+		traceFile.Close()
+		pprof.StopCPUProfile()
+		cpuFile.Close()
+		return nil, fmt.Errorf("starting execution trace: %w", err)
+	}
+	p.traceFile = traceFile
+	p.paths.Trace = tracePath
+
+	heapPath := filepath.Join(dir, service+".heap.pprof")
+	p.paths.Heap = heapPath
+
+	return p, nil
+}
+
+// stop stops the CPU profile and trace, writes a heap profile, and returns
+// the paths written. Safe to call on a nil *harnessProfiler (no-op).
+func (p *harnessProfiler) stop() (*HarnessProfilePaths, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	pprof.StopCPUProfile()
+	p.cpuFile.Close()
+
+	trace.Stop()
+	p.traceFile.Close()
+
+	heapFile, err := os.Create(p.paths.Heap)
+	if err != nil {
+		return nil, fmt.Errorf("creating heap profile file: %w", err)
+	}
+	defer heapFile.Close()
+	if err := pprof.WriteHeapProfile(heapFile); err != nil {
+		return nil, fmt.Errorf("writing heap profile: %w", err)
+	}
+
+	return &p.paths, nil
+}
@@ -0,0 +1,38 @@
+package benchmark
+
+import "time"
+
+// MetricsSink receives live benchmark observations as the runner executes,
+// so an external exporter (e.g. a Prometheus registry) can update
+// incrementally instead of only seeing the final assembled BenchmarkResult.
+// Implementations must be safe for concurrent use, since warm request
+// workers observe from multiple goroutines.
+type MetricsSink interface {
+	ObserveDeployDuration(service string, d time.Duration)
+	ObserveColdStart(service string, ttfb time.Duration, success bool)
+	ObserveWarmRequest(service string, latency time.Duration, success bool)
+}
+
+// observeDeployDuration reports d to sink if one is configured.
+func observeDeployDuration(sink MetricsSink, service string, d time.Duration) {
+	if sink == nil {
+		return
+	}
+	sink.ObserveDeployDuration(service, d)
+}
+
+// observeColdStart reports ttfb to sink if one is configured.
+func observeColdStart(sink MetricsSink, service string, ttfb time.Duration, success bool) {
+	if sink == nil {
+		return
+	}
+	sink.ObserveColdStart(service, ttfb, success)
+}
+
+// observeWarmRequest reports latency to sink if one is configured.
+func observeWarmRequest(sink MetricsSink, service string, latency time.Duration, success bool) {
+	if sink == nil {
+		return
+	}
+	sink.ObserveWarmRequest(service, latency, success)
+}
@@ -0,0 +1,176 @@
+package benchmark
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
+)
+
+// ProfileArtifact describes one profile captured from a deployed service
+// and written to local disk during a batch benchmark run.
+type ProfileArtifact struct {
+	Service    string
+	Type       string // "cpu", "heap", "goroutine"
+	Path       string
+	CapturedAt time.Time
+}
+
+// ProfileCollector attaches profiling behavior to RunBatch. ConfigureDeploy
+// is called once per service before it is deployed, so implementations can
+// inject environment variables the running container reads at startup.
+// Collect is called once per service during Phase 4 warm testing, after
+// load has already been driven against serviceURL, so implementations can
+// scrape or fetch whatever the service produced while warm.
+type ProfileCollector interface {
+	ConfigureDeploy(deployConfig *gcp.DeployConfig)
+	Collect(ctx context.Context, service, serviceURL string) ([]ProfileArtifact, error)
+}
+
+// CloudProfilerCollector enables the Cloud Profiler agent inside each
+// deployed service by setting the env vars its startup code reads. Unlike
+// PprofScraper it collects nothing itself: Cloud Profiler uploads profiles
+// out-of-band to the project, so there's nothing to fetch locally here.
+type CloudProfilerCollector struct {
+	RunID     string
+	ProjectID string
+}
+
+// ConfigureDeploy sets PROFILER_ENABLED, PROFILER_SERVICE and
+// PROFILER_PROJECT. PROFILER_ENABLED is the same variable
+// Runner.benchmarkService sets for the sequential Run path; the other two
+// are set only here.
+func (c *CloudProfilerCollector) ConfigureDeploy(deployConfig *gcp.DeployConfig) {
+	if deployConfig.EnvVars == nil {
+		deployConfig.EnvVars = make(map[string]string)
+	}
+	deployConfig.EnvVars["PROFILER_ENABLED"] = strconv.FormatBool(true)
+	deployConfig.EnvVars["PROFILER_SERVICE"] = fmt.Sprintf("%s-%s", deployConfig.ServiceName, c.RunID)
+	deployConfig.EnvVars["PROFILER_PROJECT"] = c.ProjectID
+}
+
+// Collect is a no-op: Cloud Profiler profiles are fetched separately via
+// internal/profiling, not written to local disk.
+func (c *CloudProfilerCollector) Collect(ctx context.Context, service, serviceURL string) ([]ProfileArtifact, error) {
+	return nil, nil
+}
+
+// PprofScraper hits net/http/pprof endpoints on a deployed service during
+// Phase 4 warm testing and writes gzip-compressed copies of whatever it
+// gets back under OutputDir. It requires the target to expose
+// net/http/pprof handlers at /debug/pprof, which CloudProfilerCollector
+// does not provide on its own.
+type PprofScraper struct {
+	OutputDir string
+	RunID     string
+	Types     []string // "cpu", "heap", "goroutine"
+	Duration  time.Duration
+	Client    *http.Client
+}
+
+// ConfigureDeploy is a no-op: PprofScraper only reads from the already
+// deployed service, it doesn't need to change how it's deployed.
+func (s *PprofScraper) ConfigureDeploy(deployConfig *gcp.DeployConfig) {}
+
+// Collect scrapes each configured profile type from serviceURL and writes
+// it to OutputDir/RunID/service/<type>-<unix-timestamp>.pprof.gz. It
+// attempts every type even if one fails, returning whatever artifacts it
+// did capture alongside an aggregate error.
+func (s *PprofScraper) Collect(ctx context.Context, service, serviceURL string) ([]ProfileArtifact, error) {
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	dir := filepath.Join(s.OutputDir, s.RunID, service)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating profile directory for %s: %w", service, err)
+	}
+
+	var artifacts []ProfileArtifact
+	var errs []error
+	for _, profileType := range s.Types {
+		capturedAt := time.Now()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, serviceURL+s.endpoint(profileType), nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", profileType, err))
+			continue
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", profileType, err))
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: reading response: %w", profileType, err))
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			errs = append(errs, fmt.Errorf("%s: unexpected status %d", profileType, resp.StatusCode))
+			continue
+		}
+
+		path := filepath.Join(dir, fmt.Sprintf("%s-%d.pprof.gz", profileType, capturedAt.Unix()))
+		if err := writeGzipped(path, body); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", profileType, err))
+			continue
+		}
+
+		artifacts = append(artifacts, ProfileArtifact{
+			Service:    service,
+			Type:       profileType,
+			Path:       path,
+			CapturedAt: capturedAt,
+		})
+	}
+
+	if len(errs) > 0 {
+		return artifacts, fmt.Errorf("scraping profiles for %s: %v", service, errs)
+	}
+	return artifacts, nil
+}
+
+// endpoint maps a profile type to its net/http/pprof path, sizing the CPU
+// profile's collection window to s.Duration.
+func (s *PprofScraper) endpoint(profileType string) string {
+	switch profileType {
+	case "cpu":
+		seconds := int(s.Duration.Seconds())
+		if seconds <= 0 {
+			seconds = 30
+		}
+		return fmt.Sprintf("/debug/pprof/profile?seconds=%d", seconds)
+	case "heap", "goroutine":
+		return "/debug/pprof/" + profileType
+	default:
+		return "/debug/pprof/" + profileType
+	}
+}
+
+// writeGzipped gzip-compresses data and writes it to path.
+func writeGzipped(path string, data []byte) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	if _, err := gz.Write(data); err != nil {
+		gz.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return gz.Close()
+}
@@ -0,0 +1,37 @@
+package benchmark
+
+import "time"
+
+// ProgressReporter receives live progress updates as the runner executes,
+// so a CLI can render per-service bars instead of leaving the terminal
+// silent for the multi-minute duration of a cold start run. Unlike
+// EventSink, which emits discrete events for consumers like the NDJSON
+// sink, a ProgressReporter is driven by the runner directly and is
+// expected to maintain its own per-phase display state between calls.
+//
+// Implementations must be safe for concurrent use, since warm request
+// workers report from multiple goroutines.
+type ProgressReporter interface {
+	// StartPhase begins tracking service/phase, which will advance toward
+	// total steps (e.g. cold start iterations, or warm request count).
+	StartPhase(service, phase string, total int)
+
+	// AdvanceRequests advances service/phase's progress by n steps.
+	AdvanceRequests(service, phase string, n int)
+
+	// RecordLatency folds a single completed request's latency into
+	// service/phase's running statistics (e.g. p50/p95 and RPS).
+	RecordLatency(service, phase string, d time.Duration)
+
+	// EndPhase finishes service/phase, leaving its final state rendered.
+	EndPhase(service, phase string)
+}
+
+// Progress phase names, shared between the runner and ProgressReporter
+// implementations so a reporter can distinguish cold start from warm
+// request bars without parsing free-form strings.
+const (
+	PhaseDeploy      = "deploy"
+	PhaseColdStart   = "cold_start"
+	PhaseWarmRequest = "warm_request"
+)
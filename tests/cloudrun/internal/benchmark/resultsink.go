@@ -0,0 +1,72 @@
+package benchmark
+
+// ResultSink receives full-fidelity batch benchmark results as RunBatch
+// produces them, distinct from EventSink's lightweight progress
+// notifications: a ResultSink's output is durable and replayable, so
+// RunBatchResume can rebuild deployedServices and skip completed cold
+// start iterations from a prior run's stream instead of starting over.
+// Implementations must be safe for concurrent use, since deployAll and
+// testAllColdStart/testAllWarm emit from multiple goroutines.
+type ResultSink interface {
+	// EmitDeploy is called once per deployed (service, region) pair,
+	// after Deploy returns, whether or not it succeeded.
+	EmitDeploy(key string, deployed *DeployedService)
+
+	// EmitColdStart is called immediately after each MeasureColdStart
+	// return, before results are aggregated into ColdStartStats.
+	EmitColdStart(iteration int, key string, result *ColdStartResult)
+
+	// EmitWarm is called once per service after its warm request phase
+	// completes successfully.
+	EmitWarm(key string, stats *WarmRequestStats)
+
+	// EmitSummary is called once, with the final assembled
+	// BenchmarkResult, after all phases complete.
+	EmitSummary(result *BenchmarkResult)
+}
+
+// emitDeployResult reports deployed to sink if one is configured.
+func emitDeployResult(sink ResultSink, key string, deployed *DeployedService) {
+	if sink == nil {
+		return
+	}
+	sink.EmitDeploy(key, deployed)
+}
+
+// emitColdStartResult reports result to sink if one is configured.
+func emitColdStartResult(sink ResultSink, iteration int, key string, result *ColdStartResult) {
+	if sink == nil {
+		return
+	}
+	sink.EmitColdStart(iteration, key, result)
+}
+
+// emitWarmResult reports stats to sink if one is configured.
+func emitWarmResult(sink ResultSink, key string, stats *WarmRequestStats) {
+	if sink == nil {
+		return
+	}
+	sink.EmitWarm(key, stats)
+}
+
+// emitSummaryResult reports result to sink if one is configured.
+func emitSummaryResult(sink ResultSink, result *BenchmarkResult) {
+	if sink == nil {
+		return
+	}
+	sink.EmitSummary(result)
+}
+
+// resumeRecord is the minimal shape RunBatchResume needs in order to read
+// back a prior ResultSink's NDJSON output (see report.NDJSONResultSink,
+// which is what actually writes this format). It's defined here, rather
+// than alongside NDJSONResultSink in internal/report, to avoid a
+// benchmark -> report import: report already imports benchmark for
+// ResultSink itself. It decodes only the fields RunBatchResume cares about
+// and ignores the rest of each line.
+type resumeRecord struct {
+	Type      string           `json:"type"`
+	Key       string           `json:"key"`
+	Iteration int              `json:"iteration"`
+	Deploy    *DeployedService `json:"deploy"`
+}
@@ -3,22 +3,43 @@ package benchmark
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strconv"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/config"
 	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/profiling"
 	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/signing"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/telemetry"
 )
 
+// instrumentationServiceName identifies this tool to telemetry backends,
+// distinct from the Cloud Run services it benchmarks.
+const instrumentationServiceName = "cloudrun-benchmark"
+
 // ServiceResult contains all benchmark results for a single service.
 type ServiceResult struct {
 	ServiceName string
 	ServiceURL  string
 	Profile     string
 
+	// Region is the region this result's service was deployed into.
+	// Populated by Runner.RunBatch when GCPConfig.Regions names more than
+	// one region, so BenchmarkResult.Services (keyed "{service}@{region}"
+	// in that case) can be aggregated per-region or per-service. Empty
+	// for single-region batch runs and for the sequential Run path, which
+	// keys Services by plain service name.
+	Region string
+
 	// Deployment info
 	DeploymentDuration time.Duration
 	Image              string
+	DeployRetries      gcp.RetryMetrics
 
 	// Benchmark results
 	ColdStart   *ColdStartStats
@@ -27,6 +48,28 @@ type ServiceResult struct {
 	// Errors
 	DeployError    error
 	BenchmarkError error
+
+	// HarnessProfiles holds local paths to runtime/pprof CPU, heap, and
+	// execution trace captures of this process for the duration of the
+	// service's cold-start batch, if config.HarnessProfilingConfig was
+	// enabled. Empty otherwise.
+	HarnessProfiles *HarnessProfilePaths
+
+	// ProfileURIs and TopFunctions summarize whatever Cloud Profiler
+	// collected from the deployed service for this RunID, fetched once
+	// after the cold-start and warm-request phases complete. Both are nil
+	// unless config.ProfilingConfig was enabled and a profiling.Client was
+	// available (see Runner.profiling).
+	ProfileURIs  []string
+	TopFunctions []profiling.HotFunction
+
+	// Profiles holds whatever RunBatch's ProfileCollectors captured for
+	// this service (see benchmark/profiler.go), distinct from ProfileURIs
+	// above: those are Cloud Profiler correlations for the sequential Run
+	// path, these are local pprof/Cloud-Profiler-agent artifacts from the
+	// batch path. Empty unless config.BenchmarkConfig.Profiling was
+	// enabled.
+	Profiles []ProfileArtifact
 }
 
 // BenchmarkResult contains results for all services in a benchmark run.
@@ -45,32 +88,93 @@ type Runner struct {
 	cloudrun      *gcp.CloudRunClient
 	pubsub        *gcp.PubSubClient
 	logging       *gcp.LoggingClient
-	signer        *signing.Signer
+	signer        signing.Signer
+	events        EventSink
+	progress      ProgressReporter
+	metrics       MetricsSink
+	resultSink    ResultSink
+	telemetry     *telemetry.Provider
+	profiling     *profiling.Client
+}
+
+// SetEventSink configures the runner to emit progress events as the
+// benchmark executes. Pass nil to disable event emission.
+func (r *Runner) SetEventSink(sink EventSink) {
+	r.events = sink
+}
+
+// SetProgressReporter configures the runner to drive a live progress
+// display as the benchmark executes. Pass nil (the default) to leave the
+// runner printing only the plain log lines it already does.
+func (r *Runner) SetProgressReporter(reporter ProgressReporter) {
+	r.progress = reporter
+}
+
+// SetMetricsSink configures the runner to report live observations (deploy
+// durations, cold start TTFBs, warm request latencies) as they happen,
+// rather than only once the final BenchmarkResult is assembled. Pass nil
+// (the default) to disable.
+func (r *Runner) SetMetricsSink(sink MetricsSink) {
+	r.metrics = sink
+}
+
+// SetResultSink configures RunBatch to stream full-fidelity deploy,
+// cold-start, warm-request and summary results to sink as each phase
+// produces them, rather than only returning the assembled BenchmarkResult
+// at the end. Pass nil (the default) to disable. See ResultSink and
+// RunBatchResume, which replays a prior sink's NDJSON output to continue
+// an interrupted run.
+func (r *Runner) SetResultSink(sink ResultSink) {
+	r.resultSink = sink
 }
 
 // NewRunner creates a new benchmark runner.
 func NewRunner(ctx context.Context, cfg *config.Config) (*Runner, error) {
-	cloudrun, err := gcp.NewCloudRunClient(ctx, cfg.GCP.ProjectID, cfg.GCP.Region)
+	cloudrun, err := gcp.NewCloudRunClient(ctx, cfg.GCP.ProjectID, cfg.GCP.Region, cfg.GCP.KeyFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("creating Cloud Run client: %w", err)
 	}
 
-	pubsub, err := gcp.NewPubSubClient(ctx, cfg.GCP.ProjectID)
+	pubsub, err := gcp.NewPubSubClient(ctx, cfg.GCP.ProjectID, cfg.GCP.KeyFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("creating Pub/Sub client: %w", err)
 	}
 
-	logging, err := gcp.NewLoggingClient(ctx, cfg.GCP.ProjectID)
+	logging, err := gcp.NewLoggingClient(ctx, cfg.GCP.ProjectID, cfg.GCP.KeyFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("creating logging client: %w", err)
 	}
 
+	telemetryProvider, err := telemetry.NewProvider(ctx, instrumentationServiceName, cfg.OTelEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("creating telemetry provider: %w", err)
+	}
+
+	// Cloud Profiler is only reachable from inside GCP, so a client creation
+	// failure (e.g. running against a local emulator, or no ADC available)
+	// is a warning, not a fatal error: the runner just skips profile
+	// correlation for this run.
+	var profilingClient *profiling.Client
+	if cfg.Profiling.Enabled {
+		profilingClient, err = profiling.NewClient(ctx, cfg.GCP.ProjectID)
+		if err != nil {
+			fmt.Printf("Warning: could not create Cloud Profiler client, profile correlation disabled: %v\n", err)
+		}
+	}
+
+	signer, err := signing.NewSignerFromEnv(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating signer: %w", err)
+	}
+
 	return &Runner{
-		config:   cfg,
-		cloudrun: cloudrun,
-		pubsub:   pubsub,
-		logging:  logging,
-		signer:   signing.NewSigner(),
+		config:    cfg,
+		cloudrun:  cloudrun,
+		pubsub:    pubsub,
+		logging:   logging,
+		signer:    signer,
+		telemetry: telemetryProvider,
+		profiling: profilingClient,
 	}, nil
 }
 
@@ -82,11 +186,22 @@ func (r *Runner) Close() error {
 	if r.logging != nil {
 		r.logging.Close()
 	}
+	if err := r.telemetry.Shutdown(context.Background()); err != nil {
+		return fmt.Errorf("shutting down telemetry: %w", err)
+	}
 	return nil
 }
 
-// Run executes the full benchmark suite.
+// Run executes the full benchmark suite, deploying each service's "latest" image.
 func (r *Runner) Run(ctx context.Context) (*BenchmarkResult, error) {
+	return r.RunWithImageTag(ctx, "latest")
+}
+
+// RunWithImageTag executes the full benchmark suite, deploying each
+// service from the image at the given tag instead of "latest". A
+// benchmark.Sweeper uses this to benchmark one revision (image tag) at a
+// time while reusing the same Runner and its GCP clients.
+func (r *Runner) RunWithImageTag(ctx context.Context, tag string) (*BenchmarkResult, error) {
 	result := &BenchmarkResult{
 		RunID:     r.config.RunID,
 		StartTime: time.Now(),
@@ -94,7 +209,7 @@ func (r *Runner) Run(ctx context.Context) (*BenchmarkResult, error) {
 		Services:  make(map[string]*ServiceResult),
 	}
 
-	fmt.Printf("Starting benchmark run: %s\n", r.config.RunID)
+	fmt.Printf("Starting benchmark run: %s (image tag %s)\n", r.config.RunID, tag)
 	fmt.Printf("Services: %v\n", r.config.Services.Enabled)
 
 	// Setup Pub/Sub resources
@@ -106,9 +221,14 @@ func (r *Runner) Run(ctx context.Context) (*BenchmarkResult, error) {
 
 	// Run benchmarks for each service
 	for _, service := range r.config.Services.Enabled {
+		if ctx.Err() != nil {
+			fmt.Printf("\nStopping: %v (keeping results gathered so far)\n", ctx.Err())
+			break
+		}
+
 		fmt.Printf("\n=== Benchmarking %s ===\n", service)
 
-		serviceResult := r.benchmarkService(ctx, service)
+		serviceResult := r.benchmarkService(ctx, service, tag)
 		result.Services[service] = serviceResult
 	}
 
@@ -117,8 +237,9 @@ func (r *Runner) Run(ctx context.Context) (*BenchmarkResult, error) {
 	return result, nil
 }
 
-// benchmarkService runs benchmarks for a single service.
-func (r *Runner) benchmarkService(ctx context.Context, service string) *ServiceResult {
+// benchmarkService runs benchmarks for a single service, deployed from
+// the image at tag.
+func (r *Runner) benchmarkService(ctx context.Context, service, tag string) *ServiceResult {
 	result := &ServiceResult{
 		ServiceName: service,
 		Profile:     "default",
@@ -128,12 +249,20 @@ func (r *Runner) benchmarkService(ctx context.Context, service string) *ServiceR
 
 	// Deploy the service
 	fmt.Printf("Deploying %s...\n", service)
+	emit(r.events, Event{Type: EventDeployStart, Service: service})
+	if r.progress != nil {
+		r.progress.StartPhase(service, PhaseDeploy, 1)
+	}
 	deployStart := time.Now()
+	deployCtx, deploySpan := r.telemetry.Tracer().Start(ctx, "benchmark.deploy", trace.WithAttributes(
+		attribute.String("cloudrun.service_name", service),
+		attribute.String("cloudrun.region", r.config.GCP.Region),
+	))
 
 	deployConfig := gcp.DeployConfig{
 		ServiceName:     service,
 		RunID:           r.config.RunID,
-		Image:           r.config.ImageURI(service, "latest"),
+		Image:           r.config.ImageURI(service, tag),
 		CPU:             profile.CPU,
 		Memory:          profile.Memory,
 		MaxInstances:    profile.MaxInstances,
@@ -141,26 +270,57 @@ func (r *Runner) benchmarkService(ctx context.Context, service string) *ServiceR
 		ExecutionEnv:    profile.ExecutionEnv,
 		StartupCPUBoost: profile.StartupCPUBoost,
 		EnvVars: map[string]string{
-			"DISCORD_PUBLIC_KEY":   r.signer.PublicKeyHex(),
-			"PUBSUB_TOPIC":         r.pubsub.GetTopicPath(gcp.PubSubConfig{RunID: r.config.RunID}),
-			"GOOGLE_CLOUD_PROJECT": r.config.GCP.ProjectID,
+			"DISCORD_PUBLIC_KEY":       r.signer.PublicKeyHex(),
+			"PUBSUB_TOPIC":             r.pubsub.GetTopicPath(gcp.PubSubConfig{RunID: r.config.RunID}),
+			"GOOGLE_CLOUD_PROJECT":     r.config.GCP.ProjectID,
+			"RUN_ID":                   r.config.RunID,
+			"PROFILER_ENABLED":         strconv.FormatBool(r.config.Profiling.Enabled),
+			"PROFILER_SERVICE_VERSION": tag,
 		},
 	}
 
-	serviceURL, err := r.cloudrun.Deploy(ctx, deployConfig)
+	deployResult, err := r.cloudrun.Deploy(deployCtx, deployConfig)
 	result.DeploymentDuration = time.Since(deployStart)
 	result.Image = deployConfig.Image
+	result.DeployRetries = deployResult.Retries
+
+	deployDurationHistogram, _ := r.telemetry.Meter().Float64Histogram("cloudrun_benchmark.deploy.duration_seconds",
+		metric.WithDescription("Time to deploy a service revision."), metric.WithUnit("s"))
+	deployDurationHistogram.Record(ctx, result.DeploymentDuration.Seconds(), metric.WithAttributes(attribute.String("cloudrun.service_name", service)))
+	observeDeployDuration(r.metrics, service, result.DeploymentDuration)
 
 	if err != nil {
 		result.DeployError = err
 		fmt.Printf("Failed to deploy %s: %v\n", service, err)
+		emit(r.events, Event{Type: EventDeployEnd, Service: service, Error: err.Error()})
+		deploySpan.RecordError(err)
+		deploySpan.End()
+		if r.progress != nil {
+			r.progress.EndPhase(service, PhaseDeploy)
+		}
 		return result
 	}
 
+	serviceURL := deployResult.ServiceURL
 	result.ServiceURL = serviceURL
 	fmt.Printf("Deployed to: %s (took %v)\n", serviceURL, result.DeploymentDuration)
+	emit(r.events, Event{Type: EventDeployEnd, Service: service})
+	deploySpan.End()
+	if r.progress != nil {
+		r.progress.AdvanceRequests(service, PhaseDeploy, 1)
+		r.progress.EndPhase(service, PhaseDeploy)
+	}
+
+	// Run cold start benchmark, optionally wrapped in a harness self-profile
+	var profileDir string
+	if r.config.HarnessProfiling.Enabled && r.config.OutputDir != "" {
+		profileDir = filepath.Join(r.config.OutputDir, r.config.RunID, "profiles")
+	}
+	profiler, err := startHarnessProfile(profileDir, service)
+	if err != nil {
+		fmt.Printf("Warning: could not start harness profile for %s: %v\n", service, err)
+	}
 
-	// Run cold start benchmark
 	fmt.Printf("Running cold start benchmark (%d iterations)...\n", r.config.Benchmark.ColdStartIterations)
 	coldStartCfg := ColdStartConfig{
 		ServiceURL:         serviceURL,
@@ -171,9 +331,20 @@ func (r *Runner) benchmarkService(ctx context.Context, service string) *ServiceR
 		ScaleToZeroTimeout: r.config.Benchmark.ScaleToZeroTimeout,
 		Signer:             r.signer,
 		LoggingClient:      r.logging,
+		Events:             r.events,
+		Progress:           r.progress,
+		Telemetry:          r.telemetry,
+		Metrics:            r.metrics,
 	}
 
 	coldStartStats, err := RunColdStartBenchmark(ctx, coldStartCfg)
+
+	if paths, profileErr := profiler.stop(); profileErr != nil {
+		fmt.Printf("Warning: could not finish harness profile for %s: %v\n", service, profileErr)
+	} else {
+		result.HarnessProfiles = paths
+	}
+
 	if err != nil {
 		result.BenchmarkError = err
 		fmt.Printf("Cold start benchmark failed: %v\n", err)
@@ -189,10 +360,16 @@ func (r *Runner) benchmarkService(ctx context.Context, service string) *ServiceR
 
 	warmCfg := WarmRequestConfig{
 		ServiceURL:   serviceURL,
+		ServiceName:  service,
+		Region:       r.config.GCP.Region,
 		RequestCount: r.config.Benchmark.WarmRequests,
 		Concurrency:  r.config.Benchmark.WarmConcurrency,
 		Signer:       r.signer,
 		RequestType:  RequestTypePing,
+		Events:       r.events,
+		Progress:     r.progress,
+		Telemetry:    r.telemetry,
+		Metrics:      r.metrics,
 	}
 
 	warmStats, err := RunWarmRequestBenchmark(ctx, warmCfg)
@@ -207,9 +384,210 @@ func (r *Runner) benchmarkService(ctx context.Context, service string) *ServiceR
 			warmStats.P50, warmStats.P95, warmStats.P99, warmStats.RequestsPerSecond)
 	}
 
+	// Fetch whatever Cloud Profiler collected for this RunID now that both
+	// phases are done, so P95 TTFB can be attributed to e.g. ed25519
+	// verification, JSON unmarshal, or Pub/Sub client init rather than
+	// treated as one opaque number. Skipped outside GCP (r.profiling nil).
+	if r.profiling != nil {
+		profiles, err := r.profiling.FetchProfiles(ctx, deployConfig.FullServiceName(), r.config.Profiling)
+		if err != nil {
+			fmt.Printf("Warning: could not fetch Cloud Profiler profiles for %s: %v\n", service, err)
+		}
+		for _, p := range profiles {
+			result.ProfileURIs = append(result.ProfileURIs, profiling.ConsoleURL(r.config.GCP.ProjectID, deployConfig.FullServiceName(), p.ProfileType))
+			result.TopFunctions = append(result.TopFunctions, profiling.TopFunctions(p, 10)...)
+		}
+	}
+
+	completeEvent := Event{Type: EventServiceComplete, Service: service}
+	if result.BenchmarkError != nil {
+		completeEvent.Error = result.BenchmarkError.Error()
+	}
+	emit(r.events, completeEvent)
+
+	return result
+}
+
+// RunRegional executes the benchmark suite across every region in
+// cfg.GCP.Regions concurrently, deploying each service once per region and
+// sampling cold starts in parallel instead of serially within a single
+// region, so N regions yield roughly N times the cold-start samples per
+// wall-clock hour. Falls back to cfg.GCP.Region alone if Regions is empty.
+func (r *Runner) RunRegional(ctx context.Context, tag string) (*BenchmarkResult, error) {
+	regions := r.config.GCP.Regions
+	if len(regions) == 0 {
+		regions = []string{r.config.GCP.Region}
+	}
+
+	result := &BenchmarkResult{
+		RunID:     r.config.RunID,
+		StartTime: time.Now(),
+		Config:    r.config,
+		Services:  make(map[string]*ServiceResult),
+	}
+
+	fmt.Printf("Starting regional benchmark run: %s across %v (image tag %s)\n", r.config.RunID, regions, tag)
+
+	pubsubCfg := gcp.PubSubConfig{RunID: r.config.RunID}
+	if err := r.pubsub.Setup(ctx, pubsubCfg); err != nil {
+		return nil, fmt.Errorf("setting up Pub/Sub: %w", err)
+	}
+
+	for _, service := range r.config.Services.Enabled {
+		if ctx.Err() != nil {
+			fmt.Printf("\nStopping: %v (keeping results gathered so far)\n", ctx.Err())
+			break
+		}
+
+		fmt.Printf("\n=== Benchmarking %s across %d region(s) ===\n", service, len(regions))
+		result.Services[service] = r.benchmarkServiceRegional(ctx, service, tag, regions)
+	}
+
+	result.EndTime = time.Now()
+	return result, nil
+}
+
+// regionOutcome is one region's deploy+cold-start result, gathered by
+// benchmarkServiceRegional's per-region goroutines.
+type regionOutcome struct {
+	region        string
+	serviceURL    string
+	deployDur     time.Duration
+	deployRetries gcp.RetryMetrics
+	coldStart     *ColdStartStats
+	err           error
+}
+
+// benchmarkServiceRegional deploys service into each region and runs a
+// cold-start benchmark in each concurrently, merging the per-region
+// results into a single ServiceResult via MergeColdStartStats. Warm-request
+// benchmarking is skipped here: it measures steady-state handler
+// performance rather than anything region-dependent, and benchmarkService
+// already covers it for the single-region path.
+func (r *Runner) benchmarkServiceRegional(ctx context.Context, service, tag string, regions []string) *ServiceResult {
+	result := &ServiceResult{ServiceName: service, Profile: "default"}
+	profile := r.config.GetProfile("default")
+
+	outcomes := make(chan regionOutcome, len(regions))
+	for _, region := range regions {
+		go func(region string) {
+			outcomes <- r.deployAndSampleRegion(ctx, service, tag, region, profile)
+		}(region)
+	}
+
+	perRegion := make(map[string]*ColdStartStats, len(regions))
+	var primaryURL string
+	var totalDeployDur time.Duration
+	var deployRetries gcp.RetryMetrics
+	for range regions {
+		outcome := <-outcomes
+		if outcome.err != nil {
+			fmt.Printf("Region %s failed: %v\n", outcome.region, outcome.err)
+			if result.DeployError == nil {
+				result.DeployError = outcome.err
+			}
+			continue
+		}
+
+		perRegion[outcome.region] = outcome.coldStart
+		totalDeployDur += outcome.deployDur
+		deployRetries.Attempts += outcome.deployRetries.Attempts
+		deployRetries.TotalBackoff += outcome.deployRetries.TotalBackoff
+		if primaryURL == "" {
+			primaryURL = outcome.serviceURL
+		}
+	}
+
+	if len(perRegion) == 0 {
+		return result
+	}
+
+	result.DeployError = nil
+	result.ServiceURL = primaryURL
+	result.DeploymentDuration = totalDeployDur / time.Duration(len(regions))
+	result.DeployRetries = deployRetries
+	result.Image = r.config.ImageURI(service, tag)
+
+	merged, err := MergeColdStartStats(perRegion)
+	if err != nil {
+		result.BenchmarkError = fmt.Errorf("merging regional cold start stats: %w", err)
+		return result
+	}
+	result.ColdStart = merged
+
+	fmt.Printf("Aggregate cold start P50: %v, P95: %v, P99: %v (%d samples across %d region(s))\n",
+		merged.TTFBP50, merged.TTFBP95, merged.TTFBP99, merged.TTFBHistogram.TotalCount(), len(perRegion))
+	for region, stats := range perRegion {
+		fmt.Printf("  %s: P50=%v (n=%d)\n", region, stats.TTFBP50, stats.SuccessCount)
+	}
+
 	return result
 }
 
+// deployAndSampleRegion deploys service into region and runs a cold-start
+// benchmark against that deployment, with early stopping enabled so a
+// multi-region run doesn't over-sample a region that's already converged.
+func (r *Runner) deployAndSampleRegion(ctx context.Context, service, tag, region string, profile config.ProfileConfig) regionOutcome {
+	outcome := regionOutcome{region: region}
+
+	cloudrun, err := gcp.NewCloudRunClient(ctx, r.config.GCP.ProjectID, region, r.config.GCP.KeyFilePath)
+	if err != nil {
+		outcome.err = fmt.Errorf("creating Cloud Run client for %s: %w", region, err)
+		return outcome
+	}
+
+	deployConfig := gcp.DeployConfig{
+		ServiceName:     service,
+		RunID:           r.config.RunID,
+		Image:           r.config.ImageURI(service, tag),
+		CPU:             profile.CPU,
+		Memory:          profile.Memory,
+		MaxInstances:    profile.MaxInstances,
+		Concurrency:     profile.Concurrency,
+		ExecutionEnv:    profile.ExecutionEnv,
+		StartupCPUBoost: profile.StartupCPUBoost,
+		EnvVars: map[string]string{
+			"DISCORD_PUBLIC_KEY":   r.signer.PublicKeyHex(),
+			"PUBSUB_TOPIC":         r.pubsub.GetTopicPath(gcp.PubSubConfig{RunID: r.config.RunID}),
+			"GOOGLE_CLOUD_PROJECT": r.config.GCP.ProjectID,
+			"RUN_ID":               r.config.RunID,
+		},
+	}
+
+	deployStart := time.Now()
+	deployResult, err := cloudrun.Deploy(ctx, deployConfig)
+	outcome.deployDur = time.Since(deployStart)
+	if err != nil {
+		outcome.err = fmt.Errorf("deploying to %s: %w", region, err)
+		return outcome
+	}
+	outcome.serviceURL = deployResult.ServiceURL
+	outcome.deployRetries = deployResult.Retries
+	serviceURL := deployResult.ServiceURL
+
+	coldStartCfg := ColdStartConfig{
+		ServiceURL:         serviceURL,
+		ServiceName:        deployConfig.FullServiceName(),
+		ProjectID:          r.config.GCP.ProjectID,
+		Region:             region,
+		Iterations:         r.config.Benchmark.ColdStartIterations,
+		ScaleToZeroTimeout: r.config.Benchmark.ScaleToZeroTimeout,
+		Signer:             r.signer,
+		LoggingClient:      r.logging,
+		Telemetry:          r.telemetry,
+		Convergence:        ConvergenceConfig{Enabled: true},
+	}
+
+	coldStartStats, err := RunColdStartBenchmark(ctx, coldStartCfg)
+	if err != nil {
+		outcome.err = fmt.Errorf("cold start benchmark in %s: %w", region, err)
+		return outcome
+	}
+	outcome.coldStart = coldStartStats
+
+	return outcome
+}
+
 // Cleanup removes all resources created during the benchmark.
 func (r *Runner) Cleanup(ctx context.Context) error {
 	fmt.Println("Cleaning up resources...")
@@ -263,13 +641,13 @@ func (r *Runner) DeployOnly(ctx context.Context) error {
 			},
 		}
 
-		serviceURL, err := r.cloudrun.Deploy(ctx, deployConfig)
+		deployResult, err := r.cloudrun.Deploy(ctx, deployConfig)
 		if err != nil {
 			fmt.Printf("Failed to deploy %s: %v\n", service, err)
 			continue
 		}
 
-		fmt.Printf("  %s -> %s\n", service, serviceURL)
+		fmt.Printf("  %s -> %s\n", service, deployResult.ServiceURL)
 	}
 
 	return nil
@@ -6,21 +6,40 @@ import (
 	"fmt"
 	"time"
 
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/monitoring/v3"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
 )
 
 // ScaleToZeroConfig contains configuration for scale-to-zero detection.
 type ScaleToZeroConfig struct {
-	ProjectID   string
-	Region      string
-	ServiceName string
-	Timeout     time.Duration
+	ProjectID    string
+	Region       string
+	ServiceName  string
+	KeyFilePath  string // optional; empty uses Application Default Credentials
+	Timeout      time.Duration
 	PollInterval time.Duration
 }
 
-// WaitForScaleToZero waits until the Cloud Run service has zero instances.
-// It uses the Cloud Monitoring API to check instance count.
-func WaitForScaleToZero(ctx context.Context, cfg ScaleToZeroConfig) error {
+// ScaleToZeroEvent describes a detected scale-to-zero transition.
+type ScaleToZeroEvent struct {
+	At             time.Time
+	LastInstanceID string
+	IdleDuration   time.Duration
+}
+
+// WaitForScaleToZero waits until the Cloud Run service has scaled to zero
+// instances. It tails Cloud Run system logs for a shutdown/"scaled to
+// zero" message rather than polling the instance-count metric on a fixed
+// interval, since that metric lags the real event by up to a minute and
+// burns monitoring quota. A log match is only a candidate: it's cross-
+// checked with a single Cloud Monitoring query before being trusted, to
+// rule out log lines from an unrelated or already-replaced instance.
+// Polling backs off adaptively from 2s up to cfg.PollInterval, so the
+// common case (scale-to-zero within a few seconds of the idle timeout)
+// is detected quickly without hammering either API.
+func WaitForScaleToZero(ctx context.Context, cfg ScaleToZeroConfig) (*ScaleToZeroEvent, error) {
 	if cfg.Timeout == 0 {
 		cfg.Timeout = 15 * time.Minute
 	}
@@ -28,34 +47,75 @@ func WaitForScaleToZero(ctx context.Context, cfg ScaleToZeroConfig) error {
 		cfg.PollInterval = 30 * time.Second
 	}
 
-	deadline := time.Now().Add(cfg.Timeout)
+	loggingClient, err := gcp.NewLoggingClient(ctx, cfg.ProjectID, cfg.KeyFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("creating logging client: %w", err)
+	}
+	defer loggingClient.Close()
 
-	// Create monitoring client
 	monitoringService, err := monitoring.NewService(ctx)
 	if err != nil {
-		return fmt.Errorf("creating monitoring service: %w", err)
+		return nil, fmt.Errorf("creating monitoring service: %w", err)
 	}
 
+	start := time.Now()
+	deadline := start.Add(cfg.Timeout)
+	backoff := 2 * time.Second
+
 	for time.Now().Before(deadline) {
-		count, err := getInstanceCount(ctx, monitoringService, cfg)
+		candidate, err := findScaleToZeroLog(ctx, loggingClient, cfg, start)
 		if err != nil {
-			// Log error but continue polling
-			fmt.Printf("Warning: error checking instance count: %v\n", err)
-		} else if count == 0 {
-			return nil // Service has scaled to zero
-		} else {
-			fmt.Printf("Waiting for scale to zero: %d instances active\n", count)
+			fmt.Printf("Warning: error checking scale-to-zero logs: %v\n", err)
+		} else if candidate != nil {
+			count, err := getInstanceCount(ctx, monitoringService, cfg)
+			if err != nil {
+				fmt.Printf("Warning: error cross-checking instance count: %v\n", err)
+			} else if count == 0 {
+				candidate.IdleDuration = time.Since(start)
+				return candidate, nil
+			}
 		}
 
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(cfg.PollInterval):
-			continue
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+			backoff *= 2
+			if backoff > cfg.PollInterval {
+				backoff = cfg.PollInterval
+			}
 		}
 	}
 
-	return fmt.Errorf("timeout waiting for service %s to scale to zero", cfg.ServiceName)
+	return nil, fmt.Errorf("timeout waiting for service %s to scale to zero", cfg.ServiceName)
+}
+
+// findScaleToZeroLog looks for a Cloud Run system log entry, emitted
+// since since, indicating an instance was shut down after scaling to
+// zero. It returns nil, nil if no such entry has appeared yet.
+func findScaleToZeroLog(ctx context.Context, lc *gcp.LoggingClient, cfg ScaleToZeroConfig, since time.Time) (*ScaleToZeroEvent, error) {
+	filter := fmt.Sprintf(`
+		resource.type="cloud_run_revision"
+		resource.labels.service_name="%s"
+		resource.labels.location="%s"
+		timestamp >= "%s"
+		(textPayload:"scaled to zero" OR textPayload:"Instance" AND textPayload:"shutdown")
+	`, cfg.ServiceName, cfg.Region, since.Format(time.RFC3339))
+
+	pager := lc.NewEntriesPager(ctx, filter, 10)
+	entries, err := pager.NextPage()
+	if err != nil && err != iterator.Done {
+		return nil, fmt.Errorf("reading scale-to-zero logs: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	entry := entries[0]
+	return &ScaleToZeroEvent{
+		At:             entry.Timestamp,
+		LastInstanceID: entry.Labels["instanceId"],
+	}, nil
 }
 
 // getInstanceCount queries Cloud Monitoring for the current instance count.
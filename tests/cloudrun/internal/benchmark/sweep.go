@@ -0,0 +1,174 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RevisionResult is one revision's outcome within a Sweep.
+type RevisionResult struct {
+	Revision string
+	Result   *BenchmarkResult
+	Error    error
+}
+
+// SweepConfig configures a revision-range regression sweep. Revisions are
+// expected to already have an image pushed under that tag (Config.ImageURI
+// resolves it) — Sweeper orchestrates deploy+benchmark per revision, it
+// doesn't build images itself.
+type SweepConfig struct {
+	// Revisions are the image tags to benchmark, oldest first — typically
+	// git short SHAs from a `git rev-list` of the range under test.
+	Revisions []string
+}
+
+// Sweeper benchmarks a sequence of revisions of the same services,
+// reusing one Runner (and its GCP clients, Pub/Sub setup) across the
+// whole sweep so only the deployed image changes between iterations.
+type Sweeper struct {
+	runner *Runner
+	cfg    SweepConfig
+}
+
+// NewSweeper creates a Sweeper that drives runner through cfg.Revisions.
+func NewSweeper(runner *Runner, cfg SweepConfig) *Sweeper {
+	return &Sweeper{runner: runner, cfg: cfg}
+}
+
+// Run benchmarks every configured revision in order, stopping early (and
+// returning the partial results) if ctx is cancelled.
+func (s *Sweeper) Run(ctx context.Context) ([]*RevisionResult, error) {
+	if len(s.cfg.Revisions) == 0 {
+		return nil, fmt.Errorf("sweep: no revisions configured")
+	}
+
+	var results []*RevisionResult
+	for _, revision := range s.cfg.Revisions {
+		if ctx.Err() != nil {
+			fmt.Printf("\nStopping sweep: %v (keeping %d revision(s) gathered so far)\n", ctx.Err(), len(results))
+			break
+		}
+
+		fmt.Printf("\n=== Sweep: revision %s ===\n", revision)
+
+		result, err := s.runner.RunWithImageTag(ctx, revision)
+		results = append(results, &RevisionResult{Revision: revision, Result: result, Error: err})
+		if err != nil {
+			fmt.Printf("Revision %s failed: %v\n", revision, err)
+		}
+	}
+
+	return results, nil
+}
+
+// SweepMetric names an aggregate metric Bisect can search on.
+type SweepMetric string
+
+const (
+	MetricColdStartP50 SweepMetric = "cold_start_p50"
+	MetricWarmP50      SweepMetric = "warm_p50"
+)
+
+// Aggregate returns the mean of metric across every service in result that
+// reported it, or zero if none did.
+func (m SweepMetric) Aggregate(result *BenchmarkResult) time.Duration {
+	var sum time.Duration
+	var n int
+
+	for _, svc := range result.Services {
+		switch m {
+		case MetricColdStartP50:
+			if svc.ColdStart != nil {
+				sum += svc.ColdStart.TTFBP50
+				n++
+			}
+		case MetricWarmP50:
+			if svc.WarmRequest != nil {
+				sum += svc.WarmRequest.P50
+				n++
+			}
+		}
+	}
+
+	if n == 0 {
+		return 0
+	}
+	return sum / time.Duration(n)
+}
+
+// Bisect binary-searches cfg.Revisions (oldest first) for the first
+// revision whose metric regresses more than thresholdPct percent versus
+// the first (baseline) revision, assuming — like `git bisect` — that once
+// a regression appears it persists in every later revision. It benchmarks
+// O(log n) revisions rather than the full range.
+func (s *Sweeper) Bisect(ctx context.Context, metric SweepMetric, thresholdPct float64) (string, error) {
+	revisions := s.cfg.Revisions
+	if len(revisions) < 2 {
+		return "", fmt.Errorf("bisect: need at least 2 revisions, got %d", len(revisions))
+	}
+
+	baselineResult, err := s.runner.RunWithImageTag(ctx, revisions[0])
+	if err != nil {
+		return "", fmt.Errorf("benchmarking baseline revision %s: %w", revisions[0], err)
+	}
+	baseline := metric.Aggregate(baselineResult)
+	if baseline == 0 {
+		return "", fmt.Errorf("bisect: baseline revision %s produced no %s measurements", revisions[0], metric)
+	}
+
+	regressed := func(rev string) (bool, error) {
+		result, err := s.runner.RunWithImageTag(ctx, rev)
+		if err != nil {
+			return false, fmt.Errorf("benchmarking revision %s: %w", rev, err)
+		}
+		ratio := float64(metric.Aggregate(result)) / float64(baseline)
+		return ratio > 1+thresholdPct/100, nil
+	}
+
+	return bisectRevisions(ctx, revisions, thresholdPct, regressed)
+}
+
+// bisectRevisions is Bisect's search, pulled out so it can be exercised
+// with a fake regressed function instead of a live Runner. regressed
+// reports whether revisions[i] (i >= 1) regresses against the baseline
+// already captured in its closure.
+func bisectRevisions(ctx context.Context, revisions []string, thresholdPct float64, regressed func(rev string) (bool, error)) (string, error) {
+	lo, hi := 1, len(revisions)-1
+
+	// Confirm the upper bound actually regresses before bisecting - the
+	// loop below assumes it and, with exactly 2 revisions, never runs at
+	// all (lo==hi from the start), so without this check Bisect would
+	// blindly return the only candidate revision even if it never
+	// regressed.
+	hiRegressed, err := regressed(revisions[hi])
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("Bisect: revision %s regressed=%v\n", revisions[hi], hiRegressed)
+	if !hiRegressed {
+		return "", fmt.Errorf("bisect: no revision in %s..%s regressed more than %.1f%% vs baseline %s", revisions[1], revisions[hi], thresholdPct, revisions[0])
+	}
+
+	for lo < hi {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		mid := (lo + hi) / 2
+		bad, err := regressed(revisions[mid])
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Printf("Bisect: revision %s regressed=%v\n", revisions[mid], bad)
+
+		if bad {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	return revisions[lo], nil
+}
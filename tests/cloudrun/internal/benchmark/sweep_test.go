@@ -0,0 +1,82 @@
+package benchmark
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBisectRevisions_TwoRevisionsRegressed(t *testing.T) {
+	revisions := []string{"baseline", "candidate"}
+	var checked []string
+
+	regressed := func(rev string) (bool, error) {
+		checked = append(checked, rev)
+		return rev == "candidate", nil
+	}
+
+	got, err := bisectRevisions(context.Background(), revisions, 10, regressed)
+	if err != nil {
+		t.Fatalf("bisectRevisions() error = %v", err)
+	}
+	if got != "candidate" {
+		t.Errorf("bisectRevisions() = %q, want %q", got, "candidate")
+	}
+	if len(checked) != 1 || checked[0] != "candidate" {
+		t.Errorf("checked = %v, want exactly one call checking %q", checked, "candidate")
+	}
+}
+
+func TestBisectRevisions_NoRegressionFound(t *testing.T) {
+	revisions := []string{"baseline", "r1", "r2", "r3"}
+
+	regressed := func(rev string) (bool, error) {
+		return false, nil
+	}
+
+	_, err := bisectRevisions(context.Background(), revisions, 10, regressed)
+	if err == nil {
+		t.Fatal("bisectRevisions() error = nil, want an error when no revision regresses")
+	}
+}
+
+func TestBisectRevisions_FindsFirstRegressingRevision(t *testing.T) {
+	// Revisions 1-2 are fine, 3 onward regress - Bisect should land on r3.
+	revisions := []string{"baseline", "r1", "r2", "r3", "r4", "r5"}
+	regressedFrom := 3
+
+	var checked []string
+	regressed := func(rev string) (bool, error) {
+		checked = append(checked, rev)
+		for i, r := range revisions {
+			if r == rev {
+				return i >= regressedFrom, nil
+			}
+		}
+		return false, nil
+	}
+
+	got, err := bisectRevisions(context.Background(), revisions, 10, regressed)
+	if err != nil {
+		t.Fatalf("bisectRevisions() error = %v", err)
+	}
+	if got != revisions[regressedFrom] {
+		t.Errorf("bisectRevisions() = %q, want %q", got, revisions[regressedFrom])
+	}
+	if len(checked) >= len(revisions) {
+		t.Errorf("checked %d revisions, want O(log n) not a full scan of %d", len(checked), len(revisions))
+	}
+}
+
+func TestBisectRevisions_PropagatesRegressedError(t *testing.T) {
+	revisions := []string{"baseline", "candidate"}
+	wantErr := context.Canceled
+
+	regressed := func(rev string) (bool, error) {
+		return false, wantErr
+	}
+
+	_, err := bisectRevisions(context.Background(), revisions, 10, regressed)
+	if err != wantErr {
+		t.Errorf("bisectRevisions() error = %v, want %v", err, wantErr)
+	}
+}
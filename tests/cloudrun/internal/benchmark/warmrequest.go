@@ -5,23 +5,100 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
+	"math/rand"
 	"net/http"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/latency"
 	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/signing"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/telemetry"
 )
 
 // WarmRequestConfig contains configuration for warm request benchmarking.
 type WarmRequestConfig struct {
 	ServiceURL   string
+	ServiceName  string
+	Region       string
 	RequestCount int
 	Concurrency  int
-	Signer       *signing.Signer
+	Signer       signing.Signer
 	RequestType  RequestType // Ping or SlashCommand
+
+	// Mix, if non-empty, overrides RequestType: each request is built
+	// from a spec sampled from Mix by weighted random selection instead
+	// of always sending the same RequestType body. Use this to benchmark
+	// a realistic blend of interaction kinds (slash commands, component
+	// clicks, modal submits) in one run, with per-spec latency broken out
+	// in WarmRequestStats.PerSpec.
+	Mix []RequestSpec
+
+	// IDToken, if set, is sent as an "Authorization: Bearer" header on
+	// every request, for a service deployed with a non-public
+	// gcp.InvokerPolicy. Leave empty for a publicly invokable service.
+	IDToken string
+
+	// Rate, if non-zero, switches RunWarmRequestBenchmark into open-loop
+	// mode: a producer goroutine schedules each request's start time at
+	// this target rate (req/s) instead of a worker only starting the
+	// next request once the previous one responds. Closed-loop mode
+	// (Rate == 0) under-reports tail latency when the target slows down,
+	// since slow responses throttle the offered load (coordinated
+	// omission); open-loop mode keeps offering load at Rate regardless.
+	// RequestCount is still the total number of requests scheduled.
+	Rate float64
+
+	// Pattern selects how the target rate varies over Duration. Only
+	// meaningful when Rate > 0; ignored in closed-loop mode.
+	Pattern ArrivalPattern
+
+	// RateStart and RateEnd bound ArrivalRamp and ArrivalSinusoidal, and
+	// are the before/after rates of ArrivalStep's midpoint step. Default
+	// to Rate when left zero, which makes every pattern behave like
+	// ArrivalConstant.
+	RateStart float64
+	RateEnd   float64
+
+	// Duration is how long the open-loop schedule runs; ArrivalRamp,
+	// ArrivalStep and ArrivalSinusoidal need it to know where they are
+	// in the pattern. Ignored by ArrivalConstant.
+	Duration time.Duration
+
+	// Distribution selects how each scheduled request's inter-arrival
+	// gap is sampled around the pattern's instantaneous rate. Only
+	// meaningful when Rate > 0.
+	Distribution ArrivalDistribution
+
+	// Events, if set, receives EventWarmProgress events every
+	// warmProgressInterval completed requests.
+	Events EventSink
+
+	// Progress, if set, receives live per-request progress updates for a
+	// CLI to render as a bar.
+	Progress ProgressReporter
+
+	// Telemetry, if set, traces each request as a span (tagged with its
+	// worker's concurrency slot) and records latency and error counts
+	// through its meter. A nil Telemetry behaves like a Provider configured
+	// with no endpoint: spans and metrics are no-ops.
+	Telemetry *telemetry.Provider
+
+	// Metrics, if set, receives each request's latency as it completes, for
+	// an external exporter like report.PrometheusRegistry to update live.
+	Metrics MetricsSink
 }
 
+// warmProgressInterval is how often (in completed requests) an
+// EventWarmProgress event is emitted.
+const warmProgressInterval = 25
+
 // RequestType specifies the type of Discord request to send.
 type RequestType int
 
@@ -30,11 +107,136 @@ const (
 	RequestTypeSlashCommand
 )
 
-// WarmRequestResult contains the result of a single warm request.
+// RequestSpec describes one kind of request a WarmRequestConfig.Mix can
+// select, its relative sampling weight, and what a successful response
+// looks like.
+type RequestSpec struct {
+	// Name identifies this spec in WarmRequestStats.PerSpec and in
+	// EventWarmProgress. Defaults to "spec<n>" when left empty, where n is
+	// the spec's 1-based position in Mix counting only specs with
+	// Weight > 0.
+	Name string
+
+	// Weight is this spec's relative sampling weight among Mix. Specs
+	// with Weight <= 0 are never selected.
+	Weight int
+
+	// BodyFactory returns the request body to send for the seq'th
+	// (0-based) time this spec is selected, so callers can vary IDs
+	// across iterations (e.g. distinct component custom_ids) instead of
+	// always replaying one fixed body.
+	BodyFactory func(seq int) []byte
+
+	// ExpectedStatus is the HTTP status a successful response must
+	// match. Defaults to http.StatusOK when zero.
+	ExpectedStatus int
+
+	// Followup, if set, is called with the initial response body and
+	// returns a second request body to send immediately after (modeling
+	// interaction-followup flows like a deferred response or
+	// autocomplete round-trip), or nil to skip it. Its latency is added
+	// to the spec's recorded latency.
+	Followup func(response []byte) []byte
+}
+
+// specSampler selects a RequestSpec from a Mix by weighted random
+// sampling, using a cumulative-weight binary search so selection cost
+// stays O(log n) regardless of how many specs are configured.
+type specSampler struct {
+	specs []RequestSpec
+	cum   []int
+	total int
+}
+
+// newSpecSampler builds a specSampler from mix, dropping any spec with a
+// non-positive Weight. Panics-free on an empty or all-zero-weight mix:
+// sample reports ok=false instead.
+func newSpecSampler(mix []RequestSpec) *specSampler {
+	s := &specSampler{}
+	running := 0
+	for _, spec := range mix {
+		if spec.Weight <= 0 {
+			continue
+		}
+		running += spec.Weight
+		s.specs = append(s.specs, spec)
+		s.cum = append(s.cum, running)
+	}
+	s.total = running
+	return s
+}
+
+// sample returns a weighted-random spec from s along with its index
+// (stable across calls, used to key per-spec sequence counters), or
+// ok=false if s has no positively-weighted specs.
+func (s *specSampler) sample() (spec RequestSpec, index int, ok bool) {
+	if s.total <= 0 {
+		return RequestSpec{}, -1, false
+	}
+	r := rand.Intn(s.total) + 1
+	i := sort.SearchInts(s.cum, r)
+	return s.specs[i], i, true
+}
+
+// name returns spec's display name, defaulting to "spec<n>" (1-based)
+// when Name is empty.
+func (spec RequestSpec) name(index int) string {
+	if spec.Name != "" {
+		return spec.Name
+	}
+	return fmt.Sprintf("spec%d", index+1)
+}
+
+// ArrivalPattern selects how an open-loop benchmark's target rate
+// varies over WarmRequestConfig.Duration.
+type ArrivalPattern int
+
+const (
+	// ArrivalConstant holds the target rate at Rate for the whole run.
+	ArrivalConstant ArrivalPattern = iota
+	// ArrivalRamp interpolates linearly from RateStart to RateEnd over Duration.
+	ArrivalRamp
+	// ArrivalStep holds RateStart for the first half of Duration, then RateEnd.
+	ArrivalStep
+	// ArrivalSinusoidal oscillates between RateStart and RateEnd with one
+	// full period over Duration.
+	ArrivalSinusoidal
+)
+
+// ArrivalDistribution selects how an open-loop benchmark samples the
+// gap between two consecutive scheduled requests around the pattern's
+// instantaneous target rate.
+type ArrivalDistribution int
+
+const (
+	// DistributionDeterministic spaces every request exactly 1/rate apart.
+	DistributionDeterministic ArrivalDistribution = iota
+	// DistributionPoisson samples inter-arrival gaps from an exponential
+	// distribution (-ln(U)/rate), matching a Poisson arrival process.
+	DistributionPoisson
+)
+
+// WarmRequestResult contains the result of a single warm request. In
+// open-loop mode (WarmRequestConfig.Rate > 0), Latency and Scheduled
+// measure the full scheduled→response duration so percentile stats
+// expose coordinated omission, not just how long the send itself took.
 type WarmRequestResult struct {
+	// Scheduled is when this request was scheduled to fire. Zero in
+	// closed-loop mode.
+	Scheduled time.Time
+
+	// QueuedLatency is how long the request waited behind a busy worker
+	// pool before it was actually sent (send time - Scheduled). Zero in
+	// closed-loop mode, where there's no schedule to fall behind.
+	QueuedLatency time.Duration
+
 	Latency    time.Duration
 	StatusCode int
 	Error      error
+
+	// SpecName is the RequestSpec.name() this result came from, set only
+	// when WarmRequestConfig.Mix is in use. Empty otherwise.
+	SpecName string
 }
 
 // WarmRequestStats contains aggregated statistics from warm request benchmarking.
@@ -52,6 +254,15 @@ type WarmRequestStats struct {
 	P95 time.Duration
 	P99 time.Duration
 
+	// LatencyHistogram is the full HDR-histogram-backed distribution of
+	// request latencies, letting consumers recompute arbitrary quantiles
+	// or merge results across runs without access to the raw samples.
+	LatencyHistogram *latency.Histogram
+
+	// PerSpec breaks LatencyHistogram down by RequestSpec.name(), keyed
+	// the same way, when WarmRequestConfig.Mix is in use. Nil otherwise.
+	PerSpec map[string]*latency.Histogram
+
 	// Throughput
 	Duration           time.Duration
 	RequestsPerSecond  float64
@@ -69,15 +280,30 @@ func RunWarmRequestBenchmark(ctx context.Context, cfg WarmRequestConfig) (*WarmR
 		cfg.Concurrency = 10
 	}
 
+	if cfg.Rate > 0 {
+		if cfg.RateStart == 0 {
+			cfg.RateStart = cfg.Rate
+		}
+		if cfg.RateEnd == 0 {
+			cfg.RateEnd = cfg.Rate
+		}
+	}
+
 	// Create work channel and results channel
-	work := make(chan int, cfg.RequestCount)
+	work := make(chan scheduledWork, cfg.RequestCount)
 	results := make(chan WarmRequestResult, cfg.RequestCount)
 
-	// Fill work channel
-	for i := 0; i < cfg.RequestCount; i++ {
-		work <- i
+	// Fill work channel: open-loop mode schedules start times spread
+	// over time per cfg.Pattern, closed-loop mode hands everything out
+	// up front since each item fires as soon as a worker is free.
+	if cfg.Rate > 0 {
+		go scheduleOpenLoop(ctx, cfg, work)
+	} else {
+		for i := 0; i < cfg.RequestCount; i++ {
+			work <- scheduledWork{seq: i}
+		}
+		close(work)
 	}
-	close(work)
 
 	// Create HTTP client (reused across workers)
 	client := &http.Client{
@@ -89,7 +315,8 @@ func RunWarmRequestBenchmark(ctx context.Context, cfg WarmRequestConfig) (*WarmR
 		},
 	}
 
-	// Get request body based on type
+	// Get request body based on type, unless cfg.Mix overrides it with a
+	// weighted-sampled spec per request.
 	var body []byte
 	switch cfg.RequestType {
 	case RequestTypeSlashCommand:
@@ -98,16 +325,23 @@ func RunWarmRequestBenchmark(ctx context.Context, cfg WarmRequestConfig) (*WarmR
 		body = signing.DiscordPingRequest()
 	}
 
+	var sampler *specSampler
+	var specSeqs []int64
+	if len(cfg.Mix) > 0 {
+		sampler = newSpecSampler(cfg.Mix)
+		specSeqs = make([]int64, len(sampler.specs))
+	}
+
 	// Start workers
 	var wg sync.WaitGroup
 	startTime := time.Now()
 
 	for i := 0; i < cfg.Concurrency; i++ {
 		wg.Add(1)
-		go func() {
+		go func(slot int) {
 			defer wg.Done()
-			worker(ctx, client, cfg.ServiceURL, body, cfg.Signer, work, results)
-		}()
+			worker(ctx, client, cfg, body, sampler, specSeqs, work, results, slot)
+		}(i)
 	}
 
 	// Wait for all workers to complete
@@ -121,13 +355,53 @@ func RunWarmRequestBenchmark(ctx context.Context, cfg WarmRequestConfig) (*WarmR
 		Results: make([]WarmRequestResult, 0, cfg.RequestCount),
 	}
 
+	progressHistogram := latency.NewHistogram()
+
+	if cfg.Progress != nil {
+		cfg.Progress.StartPhase(cfg.ServiceName, PhaseWarmRequest, cfg.RequestCount)
+		defer cfg.Progress.EndPhase(cfg.ServiceName, PhaseWarmRequest)
+	}
+
+	meter := cfg.Telemetry.Meter()
+	latencyHistogram, _ := meter.Float64Histogram("cloudrun_benchmark.warm_request.latency_seconds",
+		metric.WithDescription("Warm request latency."), metric.WithUnit("s"))
+	requestCounter, _ := meter.Int64Counter("cloudrun_benchmark.warm_request.requests",
+		metric.WithDescription("Warm requests completed, labeled by outcome."))
+
 	for result := range results {
 		stats.Results = append(stats.Results, result)
 		stats.TotalRequests++
+		outcome := "ok"
 		if result.Error == nil && result.StatusCode == http.StatusOK {
 			stats.Successful++
+			progressHistogram.RecordValue(result.Latency)
+			latencyHistogram.Record(ctx, result.Latency.Seconds(), metric.WithAttributes(attribute.String("cloudrun.service_name", cfg.ServiceName)))
 		} else {
 			stats.Failed++
+			outcome = "error"
+		}
+		requestCounter.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("cloudrun.service_name", cfg.ServiceName),
+			attribute.String("outcome", outcome),
+		))
+		observeWarmRequest(cfg.Metrics, cfg.ServiceName, result.Latency, outcome == "ok")
+
+		if cfg.Progress != nil {
+			cfg.Progress.AdvanceRequests(cfg.ServiceName, PhaseWarmRequest, 1)
+			if result.Error == nil {
+				cfg.Progress.RecordLatency(cfg.ServiceName, PhaseWarmRequest, result.Latency)
+			}
+		}
+
+		if cfg.Events != nil && stats.TotalRequests%warmProgressInterval == 0 {
+			elapsed := time.Since(startTime)
+			emit(cfg.Events, Event{
+				Type:         EventWarmProgress,
+				Service:      cfg.ServiceName,
+				RequestsDone: stats.TotalRequests,
+				RPS:          float64(stats.TotalRequests) / elapsed.Seconds(),
+				P95:          progressHistogram.ValueAtQuantile(95).String(),
+			})
 		}
 	}
 
@@ -139,9 +413,92 @@ func RunWarmRequestBenchmark(ctx context.Context, cfg WarmRequestConfig) (*WarmR
 	return stats, nil
 }
 
-// worker processes requests from the work channel.
-func worker(ctx context.Context, client *http.Client, serviceURL string, body []byte, signer *signing.Signer, work <-chan int, results chan<- WarmRequestResult) {
-	for range work {
+// scheduledWork is a single work item. seq is the request's sequence
+// number; scheduled is when it should fire in open-loop mode, or the
+// zero Time in closed-loop mode (fire as soon as a worker is free).
+type scheduledWork struct {
+	seq       int
+	scheduled time.Time
+}
+
+// scheduleOpenLoop pushes cfg.RequestCount scheduledWork items onto
+// work, spaced per cfg.Pattern/cfg.Distribution around cfg.Rate, so
+// workers fire requests at a target rate independent of how long the
+// target takes to respond.
+func scheduleOpenLoop(ctx context.Context, cfg WarmRequestConfig, work chan<- scheduledWork) {
+	defer close(work)
+
+	start := time.Now()
+	next := start
+	for i := 0; i < cfg.RequestCount; i++ {
+		rate := instantaneousRate(cfg, time.Since(start))
+		next = next.Add(nextArrivalInterval(cfg.Distribution, rate))
+
+		select {
+		case <-ctx.Done():
+			return
+		case work <- scheduledWork{seq: i, scheduled: next}:
+		}
+	}
+}
+
+// instantaneousRate returns the target arrival rate (req/s) at elapsed
+// time into an open-loop schedule, per cfg.Pattern.
+func instantaneousRate(cfg WarmRequestConfig, elapsed time.Duration) float64 {
+	switch cfg.Pattern {
+	case ArrivalRamp:
+		if cfg.Duration <= 0 || elapsed >= cfg.Duration {
+			return cfg.RateEnd
+		}
+		frac := float64(elapsed) / float64(cfg.Duration)
+		return cfg.RateStart + frac*(cfg.RateEnd-cfg.RateStart)
+	case ArrivalStep:
+		if cfg.Duration > 0 && elapsed >= cfg.Duration/2 {
+			return cfg.RateEnd
+		}
+		return cfg.RateStart
+	case ArrivalSinusoidal:
+		if cfg.Duration <= 0 {
+			return cfg.Rate
+		}
+		mid := (cfg.RateStart + cfg.RateEnd) / 2
+		amplitude := (cfg.RateEnd - cfg.RateStart) / 2
+		phase := 2 * math.Pi * float64(elapsed) / float64(cfg.Duration)
+		return mid + amplitude*math.Sin(phase)
+	default: // ArrivalConstant
+		return cfg.Rate
+	}
+}
+
+// nextArrivalInterval samples the gap until the next scheduled request
+// at the given target rate, per distribution.
+func nextArrivalInterval(distribution ArrivalDistribution, rate float64) time.Duration {
+	if rate <= 0 {
+		rate = 1
+	}
+	mean := time.Duration(float64(time.Second) / rate)
+
+	if distribution != DistributionPoisson {
+		return mean
+	}
+
+	u := rand.Float64()
+	for u <= 0 {
+		u = rand.Float64()
+	}
+	return time.Duration(-math.Log(u) * float64(mean))
+}
+
+// worker processes requests from the work channel. slot identifies this
+// worker's fixed concurrency slot (0..Concurrency-1), attached to every
+// span it creates so traces can be grouped by which worker issued them.
+// sampler is nil unless WarmRequestConfig.Mix is set, in which case
+// specSeqs (one counter per sampler.specs entry) supplies each spec's
+// BodyFactory with a per-spec 0-based sequence number.
+func worker(ctx context.Context, client *http.Client, cfg WarmRequestConfig, body []byte, sampler *specSampler, specSeqs []int64, work <-chan scheduledWork, results chan<- WarmRequestResult, slot int) {
+	tracer := cfg.Telemetry.Tracer()
+
+	for item := range work {
 		select {
 		case <-ctx.Done():
 			results <- WarmRequestResult{Error: ctx.Err()}
@@ -149,13 +506,59 @@ func worker(ctx context.Context, client *http.Client, serviceURL string, body []
 		default:
 		}
 
-		result := makeRequest(ctx, client, serviceURL, body, signer)
+		if !item.scheduled.IsZero() {
+			if d := time.Until(item.scheduled); d > 0 {
+				select {
+				case <-ctx.Done():
+					results <- WarmRequestResult{Error: ctx.Err()}
+					return
+				case <-time.After(d):
+				}
+			}
+		}
+
+		spanCtx, span := tracer.Start(ctx, "benchmark.warm_request", trace.WithAttributes(
+			attribute.String("cloudrun.service_name", cfg.ServiceName),
+			attribute.String("cloudrun.region", cfg.Region),
+			attribute.Bool("cloudrun.cold_start", false),
+			attribute.Int("cloudrun.concurrency_slot", slot),
+		))
+
+		sent := time.Now()
+		var result WarmRequestResult
+		if spec, specIndex, ok := sampleSpec(sampler); ok {
+			seq := int(atomic.AddInt64(&specSeqs[specIndex], 1)) - 1
+			result = makeSpecRequest(spanCtx, client, cfg.ServiceURL, spec, seq, cfg.Signer, cfg.IDToken)
+			result.SpecName = spec.name(specIndex)
+		} else {
+			result = makeRequest(spanCtx, client, cfg.ServiceURL, body, cfg.Signer, cfg.IDToken)
+		}
+		if !item.scheduled.IsZero() {
+			result.Scheduled = item.scheduled
+			result.QueuedLatency = sent.Sub(item.scheduled)
+			result.Latency += result.QueuedLatency
+		}
+		if result.Error != nil {
+			span.RecordError(result.Error)
+		}
+		span.End()
+
 		results <- result
 	}
 }
 
+// sampleSpec samples a spec from sampler, reporting ok=false when sampler
+// is nil (WarmRequestConfig.Mix unset) so callers fall back to the plain
+// RequestType body.
+func sampleSpec(sampler *specSampler) (spec RequestSpec, index int, ok bool) {
+	if sampler == nil {
+		return RequestSpec{}, -1, false
+	}
+	return sampler.sample()
+}
+
 // makeRequest performs a single HTTP request and measures latency.
-func makeRequest(ctx context.Context, client *http.Client, serviceURL string, body []byte, signer *signing.Signer) WarmRequestResult {
+func makeRequest(ctx context.Context, client *http.Client, serviceURL string, body []byte, signer signing.Signer, idToken string) WarmRequestResult {
 	result := WarmRequestResult{}
 
 	// Sign the request
@@ -171,6 +574,9 @@ func makeRequest(ctx context.Context, client *http.Client, serviceURL string, bo
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Signature-Ed25519", signature)
 	req.Header.Set("X-Signature-Timestamp", timestamp)
+	if idToken != "" {
+		req.Header.Set("Authorization", "Bearer "+idToken)
+	}
 
 	// Make request and measure latency
 	start := time.Now()
@@ -195,6 +601,79 @@ func makeRequest(ctx context.Context, client *http.Client, serviceURL string, bo
 	return result
 }
 
+// makeSpecRequest sends spec's seq'th request body and, if spec.Followup
+// is set, a second request built from the first response, adding its
+// latency to the result. It reports spec.ExpectedStatus (defaulting to
+// http.StatusOK) as success instead of makeRequest's hard-coded StatusOK.
+func makeSpecRequest(ctx context.Context, client *http.Client, serviceURL string, spec RequestSpec, seq int, signer signing.Signer, idToken string) WarmRequestResult {
+	expected := spec.ExpectedStatus
+	if expected == 0 {
+		expected = http.StatusOK
+	}
+
+	body := spec.BodyFactory(seq)
+	result, respBody := doSignedRequest(ctx, client, serviceURL, body, signer, idToken, expected)
+	if result.Error != nil || spec.Followup == nil {
+		return result
+	}
+
+	followupBody := spec.Followup(respBody)
+	if followupBody == nil {
+		return result
+	}
+
+	followupResult, _ := doSignedRequest(ctx, client, serviceURL, followupBody, signer, idToken, expected)
+	result.Latency += followupResult.Latency
+	if followupResult.Error != nil {
+		result.Error = followupResult.Error
+		result.StatusCode = followupResult.StatusCode
+	}
+	return result
+}
+
+// doSignedRequest performs a single signed HTTP request and measures
+// latency, treating wantStatus (rather than a hard-coded http.StatusOK) as
+// the success status. It returns the response body alongside the result so
+// callers like makeSpecRequest can feed it to a RequestSpec.Followup.
+func doSignedRequest(ctx context.Context, client *http.Client, serviceURL string, body []byte, signer signing.Signer, idToken string, wantStatus int) (WarmRequestResult, []byte) {
+	result := WarmRequestResult{}
+
+	signature, timestamp := signer.SignRequest(body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, serviceURL, bytes.NewReader(body))
+	if err != nil {
+		result.Error = fmt.Errorf("creating request: %w", err)
+		return result, nil
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature-Ed25519", signature)
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+	if idToken != "" {
+		req.Header.Set("Authorization", "Bearer "+idToken)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Error = fmt.Errorf("making request: %w", err)
+		result.Latency = time.Since(start)
+		return result, nil
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	result.Latency = time.Since(start)
+	result.StatusCode = resp.StatusCode
+
+	if resp.StatusCode != wantStatus {
+		result.Error = fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return result, respBody
+}
+
 // calculateStats computes aggregate statistics from individual results.
 func (s *WarmRequestStats) calculateStats() {
 	if len(s.Results) == 0 {
@@ -205,10 +684,23 @@ func (s *WarmRequestStats) calculateStats() {
 	var latencies []time.Duration
 	var latencySum time.Duration
 
+	s.LatencyHistogram = latency.NewHistogram()
+
 	for _, r := range s.Results {
 		if r.Error == nil {
 			latencies = append(latencies, r.Latency)
 			latencySum += r.Latency
+			s.LatencyHistogram.RecordValue(r.Latency)
+
+			if r.SpecName != "" {
+				if s.PerSpec == nil {
+					s.PerSpec = make(map[string]*latency.Histogram)
+				}
+				if s.PerSpec[r.SpecName] == nil {
+					s.PerSpec[r.SpecName] = latency.NewHistogram()
+				}
+				s.PerSpec[r.SpecName].RecordValue(r.Latency)
+			}
 		}
 	}
 
@@ -13,19 +13,45 @@ import (
 
 // Config represents the complete benchmark configuration.
 type Config struct {
-	GCP       GCPConfig                `yaml:"gcp"`
-	Profiles  map[string]ProfileConfig `yaml:"profiles"`
-	Benchmark BenchmarkConfig          `yaml:"benchmark"`
-	Services  ServicesConfig           `yaml:"services"`
+	GCP              GCPConfig                `yaml:"gcp"`
+	Profiles         map[string]ProfileConfig `yaml:"profiles"`
+	Benchmark        BenchmarkConfig          `yaml:"benchmark"`
+	Services         ServicesConfig           `yaml:"services"`
+	Profiling        ProfilingConfig          `yaml:"profiling"`
+	HarnessProfiling HarnessProfilingConfig   `yaml:"harness_profiling"`
 
 	// Runtime fields (not from YAML)
 	RunID string `yaml:"-"`
+
+	// OutputDir is the directory results are written under (the --output
+	// flag), populated so the benchmark package itself can write files that
+	// need to exist while a run is still in progress, like harness pprof
+	// profiles, rather than only after Runner.Run returns.
+	OutputDir string `yaml:"-"`
+
+	// OTelEndpoint, if set, is the OTLP (gRPC) collector benchmark spans
+	// and metrics are exported to. Populated from --otel-endpoint or
+	// OTEL_EXPORTER_OTLP_ENDPOINT; see telemetry.NewProvider. Left empty,
+	// the runner instruments with no-op tracers/meters.
+	OTelEndpoint string `yaml:"-"`
 }
 
 // GCPConfig contains GCP project settings.
 type GCPConfig struct {
 	ProjectID string `yaml:"project_id"`
 	Region    string `yaml:"region"`
+
+	// Regions, if set, names the regions a Runner.RunRegional or
+	// Runner.RunBatch benchmark deploys each service into and samples cold
+	// starts from concurrently. Region is still used for everything else
+	// (Pub/Sub, single-region runs); Regions is additive, not a
+	// replacement.
+	Regions []string `yaml:"regions"`
+
+	// KeyFilePath, if set, authenticates every GCP client with this
+	// service account key instead of Application Default Credentials.
+	// Populated from --key-file or GCP_KEY_FILE; see gcp.ClientOptions.
+	KeyFilePath string `yaml:"-"`
 }
 
 // ProfileConfig defines a Cloud Run deployment profile.
@@ -40,10 +66,135 @@ type ProfileConfig struct {
 
 // BenchmarkConfig contains benchmark execution parameters.
 type BenchmarkConfig struct {
-	ColdStartIterations  int           `yaml:"cold_start_iterations"`
-	ScaleToZeroTimeout   time.Duration `yaml:"scale_to_zero_timeout"`
-	WarmRequests         int           `yaml:"warm_requests"`
-	WarmConcurrency      int           `yaml:"warm_concurrency"`
+	ColdStartIterations int           `yaml:"cold_start_iterations"`
+	ScaleToZeroTimeout  time.Duration `yaml:"scale_to_zero_timeout"`
+	WarmRequests        int           `yaml:"warm_requests"`
+	WarmConcurrency     int           `yaml:"warm_concurrency"`
+
+	// MeasureConcurrency bounds how many services have their cold-start
+	// request fired at once, so a reading captures all services within a
+	// tight time window instead of serially, one WaitForStartupLog timeout
+	// at a time.
+	MeasureConcurrency int `yaml:"measure_concurrency"`
+
+	// ShuffleMeasureOrder randomizes service order per iteration when set,
+	// avoiding systematic ordering bias in the aggregated percentiles
+	// computed by ColdStartStats.CalculateStats.
+	ShuffleMeasureOrder bool `yaml:"shuffle_measure_order"`
+
+	// Local configures simulated network conditions applied on top of
+	// local Docker benchmark timings, so comparisons against Cloud Run
+	// aren't skewed by local runs having no network path at all.
+	Local LocalConfig `yaml:"local"`
+
+	// Profiling controls per-service CPU/heap/goroutine profile capture
+	// during RunBatch, distinct from the top-level ProfilingConfig: that
+	// one correlates Cloud Profiler data already being scraped in the
+	// background for the sequential Run path, while this one drives a
+	// batch-specific ProfileCollector (see benchmark/profiler.go) that
+	// enables the Cloud Profiler agent and scrapes net/http/pprof.
+	Profiling BatchProfilingConfig `yaml:"profiling"`
+
+	// Regions bounds how many of GCPConfig.Regions' deployments RunBatch
+	// drives at once, so a wide services x regions matrix doesn't blow
+	// through Cloud Run's per-project deploy quota.
+	Regions RegionsConfig `yaml:"regions"`
+}
+
+// RegionsConfig bounds RunBatch's multi-region deployment matrix.
+type RegionsConfig struct {
+	// MaxParallel caps the number of simultaneous Cloud Run deploys across
+	// the services x regions matrix. Zero (the default) means unbounded,
+	// matching RunRegional's existing all-at-once behavior.
+	MaxParallel int `yaml:"max_parallel"`
+}
+
+// LocalConfig configures the local (non-Cloud-Run) side of a comparison run.
+type LocalConfig struct {
+	Network NetworkConfig `yaml:"network"`
+}
+
+// NetworkConfig simulates WAN conditions on top of local benchmark timings
+// via internal/netsim: one-way latency, jitter, a bandwidth cap, and the
+// MTU used to size its leaky-bucket writes.
+type NetworkConfig struct {
+	RTT           time.Duration `yaml:"rtt"`
+	Jitter        time.Duration `yaml:"jitter"`
+	BandwidthMbps float64       `yaml:"bandwidth_mbps"`
+	MTU           int           `yaml:"mtu"`
+}
+
+// UnmarshalYAML implements custom unmarshaling for the rtt/jitter duration fields.
+func (n *NetworkConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type rawNetworkConfig struct {
+		RTT           string  `yaml:"rtt"`
+		Jitter        string  `yaml:"jitter"`
+		BandwidthMbps float64 `yaml:"bandwidth_mbps"`
+		MTU           int     `yaml:"mtu"`
+	}
+
+	var raw rawNetworkConfig
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	n.BandwidthMbps = raw.BandwidthMbps
+	n.MTU = raw.MTU
+
+	if raw.RTT != "" {
+		d, err := time.ParseDuration(raw.RTT)
+		if err != nil {
+			return fmt.Errorf("parsing rtt: %w", err)
+		}
+		n.RTT = d
+	}
+
+	if raw.Jitter != "" {
+		d, err := time.ParseDuration(raw.Jitter)
+		if err != nil {
+			return fmt.Errorf("parsing jitter: %w", err)
+		}
+		n.Jitter = d
+	}
+
+	return nil
+}
+
+// BatchProfilingConfig controls RunBatch's ProfileCollector subsystem:
+// enabling the Cloud Profiler agent inside each deployed service and
+// scraping net/http/pprof during Phase 4 warm testing. See
+// benchmark/profiler.go for the collectors this config drives.
+type BatchProfilingConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Duration time.Duration `yaml:"duration"`
+	Types    []string      `yaml:"types"` // e.g. "cpu", "heap", "goroutine"
+}
+
+// UnmarshalYAML implements custom unmarshaling for the duration field.
+func (p *BatchProfilingConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type rawBatchProfilingConfig struct {
+		Enabled  bool     `yaml:"enabled"`
+		Duration string   `yaml:"duration"`
+		Types    []string `yaml:"types"`
+	}
+
+	var raw rawBatchProfilingConfig
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	p.Enabled = raw.Enabled
+	p.Types = raw.Types
+
+	if raw.Duration != "" {
+		d, err := time.ParseDuration(raw.Duration)
+		if err != nil {
+			return fmt.Errorf("parsing duration: %w", err)
+		}
+		p.Duration = d
+	}
+
+	return nil
 }
 
 // ServicesConfig defines which services to benchmark.
@@ -51,6 +202,23 @@ type ServicesConfig struct {
 	Enabled []string `yaml:"enabled"`
 }
 
+// ProfilingConfig controls Cloud Profiler integration during cold-start
+// measurements.
+type ProfilingConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	SampleDuration time.Duration `yaml:"sample_duration"`
+	ProfileTypes   []string      `yaml:"profile_types"` // e.g. "CPU", "HEAP"
+}
+
+// HarnessProfilingConfig controls self-profiling of the benchmark harness
+// process itself (this binary), as opposed to ProfilingConfig, which
+// profiles the deployed Cloud Run services via Cloud Profiler. Useful for
+// diagnosing whether the harness's own overhead (e.g. signing, JSON
+// marshalling) is skewing measured latency.
+type HarnessProfilingConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
 // Load reads and parses a YAML configuration file.
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
@@ -97,6 +265,14 @@ func (c *Config) applyDefaults() {
 		c.GCP.Region = envRegion
 	}
 
+	if envKeyFile := os.Getenv("GCP_KEY_FILE"); envKeyFile != "" {
+		c.GCP.KeyFilePath = envKeyFile
+	}
+
+	if envOTelEndpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); envOTelEndpoint != "" && c.OTelEndpoint == "" {
+		c.OTelEndpoint = envOTelEndpoint
+	}
+
 	// Fall back to default region if still unset
 	if c.GCP.Region == "" {
 		c.GCP.Region = "us-central1"
@@ -118,6 +294,28 @@ func (c *Config) applyDefaults() {
 		c.Benchmark.WarmConcurrency = 10
 	}
 
+	if c.Benchmark.MeasureConcurrency == 0 {
+		c.Benchmark.MeasureConcurrency = 1
+	}
+
+	if c.Profiling.Enabled {
+		if c.Profiling.SampleDuration == 0 {
+			c.Profiling.SampleDuration = 1 * time.Second
+		}
+		if len(c.Profiling.ProfileTypes) == 0 {
+			c.Profiling.ProfileTypes = []string{"CPU", "HEAP"}
+		}
+	}
+
+	if c.Benchmark.Profiling.Enabled {
+		if c.Benchmark.Profiling.Duration == 0 {
+			c.Benchmark.Profiling.Duration = 30 * time.Second
+		}
+		if len(c.Benchmark.Profiling.Types) == 0 {
+			c.Benchmark.Profiling.Types = []string{"cpu", "heap", "goroutine"}
+		}
+	}
+
 	// Ensure default profile exists
 	if c.Profiles == nil {
 		c.Profiles = make(map[string]ProfileConfig)
@@ -189,10 +387,15 @@ func (c *Config) ImageURI(service, tag string) string {
 // UnmarshalYAML implements custom unmarshaling for duration fields.
 func (b *BenchmarkConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	type rawBenchmarkConfig struct {
-		ColdStartIterations int    `yaml:"cold_start_iterations"`
-		ScaleToZeroTimeout  string `yaml:"scale_to_zero_timeout"`
-		WarmRequests        int    `yaml:"warm_requests"`
-		WarmConcurrency     int    `yaml:"warm_concurrency"`
+		ColdStartIterations int         `yaml:"cold_start_iterations"`
+		ScaleToZeroTimeout  string      `yaml:"scale_to_zero_timeout"`
+		WarmRequests        int         `yaml:"warm_requests"`
+		WarmConcurrency     int         `yaml:"warm_concurrency"`
+		MeasureConcurrency  int                  `yaml:"measure_concurrency"`
+		ShuffleMeasureOrder bool                 `yaml:"shuffle_measure_order"`
+		Local               LocalConfig          `yaml:"local"`
+		Profiling           BatchProfilingConfig `yaml:"profiling"`
+		Regions             RegionsConfig        `yaml:"regions"`
 	}
 
 	var raw rawBenchmarkConfig
@@ -203,6 +406,11 @@ func (b *BenchmarkConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 	b.ColdStartIterations = raw.ColdStartIterations
 	b.WarmRequests = raw.WarmRequests
 	b.WarmConcurrency = raw.WarmConcurrency
+	b.MeasureConcurrency = raw.MeasureConcurrency
+	b.ShuffleMeasureOrder = raw.ShuffleMeasureOrder
+	b.Local = raw.Local
+	b.Profiling = raw.Profiling
+	b.Regions = raw.Regions
 
 	if raw.ScaleToZeroTimeout != "" {
 		d, err := time.ParseDuration(raw.ScaleToZeroTimeout)
@@ -214,3 +422,30 @@ func (b *BenchmarkConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 
 	return nil
 }
+
+// UnmarshalYAML implements custom unmarshaling for the sample_duration field.
+func (p *ProfilingConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type rawProfilingConfig struct {
+		Enabled        bool     `yaml:"enabled"`
+		SampleDuration string   `yaml:"sample_duration"`
+		ProfileTypes   []string `yaml:"profile_types"`
+	}
+
+	var raw rawProfilingConfig
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	p.Enabled = raw.Enabled
+	p.ProfileTypes = raw.ProfileTypes
+
+	if raw.SampleDuration != "" {
+		d, err := time.ParseDuration(raw.SampleDuration)
+		if err != nil {
+			return fmt.Errorf("parsing sample_duration: %w", err)
+		}
+		p.SampleDuration = d
+	}
+
+	return nil
+}
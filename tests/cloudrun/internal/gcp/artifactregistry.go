@@ -1,105 +1,154 @@
 package gcp
 
 import (
+	"compress/gzip"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"strings"
+	"time"
 
-	"golang.org/x/oauth2/google"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/registry"
 )
 
+// defaultManifestCacheCapacity bounds the default in-memory manifest cache
+// WithCacheTTL enables, unless overridden via WithManifestCache.
+const defaultManifestCacheCapacity = 256
+
 // ArtifactRegistryClient provides methods for querying Artifact Registry.
+// It's a thin wrapper around a registry.Client configured with a Google
+// authenticator and the *-docker.pkg.dev host pattern; the underlying
+// registry package itself is registry-agnostic.
 type ArtifactRegistryClient struct {
-	projectID  string
-	region     string
-	httpClient *http.Client
+	projectID string
+	region    string
+	client    registry.Client
+	caching   *registry.CachingClient // nil unless WithCacheTTL was given
+}
+
+// artifactRegistryOptions holds ArtifactRegistryOption's configurable fields.
+type artifactRegistryOptions struct {
+	cacheTTL time.Duration
+	cache    registry.ManifestCache
+}
+
+// ArtifactRegistryOption configures manifest caching for an
+// ArtifactRegistryClient.
+type ArtifactRegistryOption func(*artifactRegistryOptions)
+
+// WithCacheTTL enables manifest caching, trusting a cached manifest for up
+// to ttl before revalidating it, using an in-memory LRU cache unless
+// WithManifestCache overrides it. Caching is off by default: every call
+// fetches fresh.
+func WithCacheTTL(ttl time.Duration) ArtifactRegistryOption {
+	return func(o *artifactRegistryOptions) { o.cacheTTL = ttl }
+}
+
+// WithManifestCache overrides the default in-memory LRU manifest cache,
+// e.g. with a Redis- or filesystem-backed registry.ManifestCache shared
+// across bot instances. Has no effect unless combined with WithCacheTTL.
+func WithManifestCache(cache registry.ManifestCache) ArtifactRegistryOption {
+	return func(o *artifactRegistryOptions) { o.cache = cache }
 }
 
 // NewArtifactRegistryClient creates a new Artifact Registry client.
-func NewArtifactRegistryClient(ctx context.Context, projectID, region string) (*ArtifactRegistryClient, error) {
-	// Create an HTTP client with default credentials for Docker Registry API
-	client, err := google.DefaultClient(ctx, "https://www.googleapis.com/auth/cloud-platform")
+// keyFilePath, if set, authenticates with that service account key instead
+// of ADC.
+func NewArtifactRegistryClient(ctx context.Context, projectID, region, keyFilePath string, opts ...ArtifactRegistryOption) (*ArtifactRegistryClient, error) {
+	auth, err := registry.NewGoogleAuthenticator(ctx, keyFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("creating authenticated HTTP client: %w", err)
 	}
 
+	var cfg artifactRegistryOptions
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var client registry.Client = registry.NewClient(auth)
+	var caching *registry.CachingClient
+	if cfg.cacheTTL > 0 {
+		cache := cfg.cache
+		if cache == nil {
+			cache = registry.NewLRUManifestCache(defaultManifestCacheCapacity)
+		}
+		caching = registry.NewCachingClient(client, cache, cfg.cacheTTL)
+		client = caching
+	}
+
 	return &ArtifactRegistryClient{
-		projectID:  projectID,
-		region:     region,
-		httpClient: client,
+		projectID: projectID,
+		region:    region,
+		client:    client,
+		caching:   caching,
 	}, nil
 }
 
-// dockerManifest represents a Docker image manifest (v2 schema 2).
-type dockerManifest struct {
-	SchemaVersion int    `json:"schemaVersion"`
-	MediaType     string `json:"mediaType"`
-
-	// For manifest lists (multi-arch images)
-	Manifests []manifestDescriptor `json:"manifests,omitempty"`
-
-	// For single-arch images
-	Config manifestLayer   `json:"config,omitempty"`
-	Layers []manifestLayer `json:"layers,omitempty"`
+// CacheStats returns the manifest cache's cumulative hit/miss/
+// revalidation counts, or a zero CacheStats if WithCacheTTL wasn't given.
+func (c *ArtifactRegistryClient) CacheStats() registry.CacheStats {
+	if c.caching == nil {
+		return registry.CacheStats{}
+	}
+	return c.caching.Stats()
 }
 
-// manifestDescriptor describes a platform-specific manifest in a manifest list.
-type manifestDescriptor struct {
-	MediaType string `json:"mediaType"`
-	Size      int64  `json:"size"`
-	Digest    string `json:"digest"`
-	Platform  struct {
-		Architecture string `json:"architecture"`
-		OS           string `json:"os"`
-	} `json:"platform"`
+// PlatformSelector selects which platform-specific manifest to use when a
+// tag resolves to a manifest list / OCI image index. Architecture and OS
+// alone aren't always enough to disambiguate: Variant distinguishes e.g.
+// linux/arm/v7 from linux/arm/v8, and OSVersion distinguishes between
+// Windows image versions that otherwise share OS and Architecture. A field
+// left empty matches any value.
+type PlatformSelector struct {
+	OS           string
+	Architecture string
+	Variant      string
+	OSVersion    string
 }
 
-// manifestLayer describes a layer or config blob.
-type manifestLayer struct {
-	MediaType string `json:"mediaType"`
-	Size      int64  `json:"size"`
-	Digest    string `json:"digest"`
+// defaultPlatformSelector is what GetImageSize and GetImageSizes use,
+// matching their previous hard-coded linux/amd64 selection.
+var defaultPlatformSelector = PlatformSelector{OS: "linux", Architecture: "amd64"}
+
+// matches reports whether a manifest list entry's platform satisfies s.
+func (s PlatformSelector) matches(p registry.Platform) bool {
+	return (s.OS == "" || s.OS == p.OS) &&
+		(s.Architecture == "" || s.Architecture == p.Architecture) &&
+		(s.Variant == "" || s.Variant == p.Variant) &&
+		(s.OSVersion == "" || s.OSVersion == p.OSVersion)
 }
 
 // GetImageSize returns the size of a Docker image in bytes.
 // imageURI should be in the format: REGION-docker.pkg.dev/PROJECT/REPO/IMAGE:TAG
 // For multi-arch images, returns the size of the linux/amd64 platform image.
 func (c *ArtifactRegistryClient) GetImageSize(ctx context.Context, imageURI string) (int64, error) {
-	region, project, repo, imageName, tag, err := parseImageURI(imageURI)
+	return c.GetImageSizeForPlatform(ctx, imageURI, defaultPlatformSelector)
+}
+
+// GetImageSizeForPlatform returns the size of imageURI's manifest for the
+// platform matching selector, walking a manifest list / OCI image index if
+// the tag resolves to one. Unlike GetImageSize's hard-coded linux/amd64,
+// selector's Variant and OSVersion let callers disambiguate entries that
+// share OS and Architecture, such as linux/arm/v7 vs v8.
+func (c *ArtifactRegistryClient) GetImageSizeForPlatform(ctx context.Context, imageURI string, selector PlatformSelector) (int64, error) {
+	ref, err := c.ref(imageURI)
 	if err != nil {
 		return 0, fmt.Errorf("parsing image URI: %w", err)
 	}
 
-	// Fetch the manifest for the tagged image
-	manifest, err := c.fetchManifest(ctx, region, project, repo, imageName, tag)
+	manifest, mediaType, _, err := c.client.Manifest(ctx, ref)
 	if err != nil {
 		return 0, fmt.Errorf("fetching manifest: %w", err)
 	}
 
-	// Check if this is a manifest list (multi-arch image)
-	if isManifestList(manifest.MediaType) {
-		// Find the linux/amd64 manifest
-		var amd64Digest string
-		for _, m := range manifest.Manifests {
-			if m.Platform.OS == "linux" && m.Platform.Architecture == "amd64" {
-				amd64Digest = m.Digest
-				break
-			}
-		}
-		if amd64Digest == "" {
-			// Fall back to first manifest if no linux/amd64
-			if len(manifest.Manifests) > 0 {
-				amd64Digest = manifest.Manifests[0].Digest
-			} else {
-				return 0, fmt.Errorf("no platform manifests found in manifest list")
-			}
+	if isManifestList(mediaType) {
+		digest, err := selectPlatformDigest(manifest.Manifests, selector)
+		if err != nil {
+			return 0, err
 		}
 
-		// Fetch the platform-specific manifest
-		manifest, err = c.fetchManifest(ctx, region, project, repo, imageName, amd64Digest)
+		ref.Reference = digest
+		manifest, _, _, err = c.client.Manifest(ctx, ref)
 		if err != nil {
 			return 0, fmt.Errorf("fetching platform manifest: %w", err)
 		}
@@ -119,48 +168,99 @@ func (c *ArtifactRegistryClient) GetImageSize(ctx context.Context, imageURI stri
 	return totalSize, nil
 }
 
-// fetchManifest fetches a Docker manifest from Artifact Registry using the Registry API v2.
-func (c *ArtifactRegistryClient) fetchManifest(ctx context.Context, region, project, repo, image, reference string) (*dockerManifest, error) {
-	// Docker Registry API v2 endpoint
-	// https://REGION-docker.pkg.dev/v2/PROJECT/REPO/IMAGE/manifests/TAG_OR_DIGEST
-	url := fmt.Sprintf("https://%s-docker.pkg.dev/v2/%s/%s/%s/manifests/%s",
-		region, project, repo, image, reference)
+// selectPlatformDigest finds the manifest list entry matching selector,
+// falling back to the first entry (matching GetImageSize's prior behavior)
+// if nothing matches exactly.
+func selectPlatformDigest(manifests []registry.ManifestDescriptor, selector PlatformSelector) (string, error) {
+	for _, m := range manifests {
+		if selector.matches(m.Platform) {
+			return m.Digest, nil
+		}
+	}
+	if len(manifests) > 0 {
+		return manifests[0].Digest, nil
+	}
+	return "", fmt.Errorf("no platform manifests found in manifest list")
+}
+
+// ImageSizes breaks an image's footprint down into registry bytes
+// (Compressed, what GetImageSize reports) and the bytes it occupies once
+// unpacked on a node's disk (Uncompressed), so status commands can report
+// both instead of conflating wire size with on-disk size.
+type ImageSizes struct {
+	Compressed   int64
+	Uncompressed int64
+	LayerCount   int
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+// GetImageSizes returns imageURI's compressed (registry) and uncompressed
+// (on-disk) size for the default linux/amd64 platform. Neither schema 2
+// nor OCI manifests carry an uncompressed size field, so Uncompressed is
+// computed by streaming each gzip-compressed layer through gzip.Reader and
+// counting decompressed bytes; a layer whose media type isn't gzip is
+// counted at its blob size as-is.
+func (c *ArtifactRegistryClient) GetImageSizes(ctx context.Context, imageURI string) (*ImageSizes, error) {
+	ref, err := c.ref(imageURI)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return nil, fmt.Errorf("parsing image URI: %w", err)
 	}
 
-	// Accept both manifest list and single manifest formats
-	req.Header.Set("Accept", strings.Join([]string{
-		"application/vnd.docker.distribution.manifest.list.v2+json",
-		"application/vnd.oci.image.index.v1+json",
-		"application/vnd.docker.distribution.manifest.v2+json",
-		"application/vnd.oci.image.manifest.v1+json",
-	}, ", "))
-
-	resp, err := c.httpClient.Do(req)
+	manifest, mediaType, _, err := c.client.Manifest(ctx, ref)
 	if err != nil {
 		return nil, fmt.Errorf("fetching manifest: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("registry returned %d: %s", resp.StatusCode, string(body))
+	if isManifestList(mediaType) {
+		digest, err := selectPlatformDigest(manifest.Manifests, defaultPlatformSelector)
+		if err != nil {
+			return nil, err
+		}
+		ref.Reference = digest
+		manifest, _, _, err = c.client.Manifest(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("fetching platform manifest: %w", err)
+		}
+	}
+
+	sizes := &ImageSizes{LayerCount: len(manifest.Layers), Compressed: manifest.Config.Size}
+	for _, layer := range manifest.Layers {
+		sizes.Compressed += layer.Size
+
+		uncompressed, err := c.layerUncompressedSize(ctx, ref, layer)
+		if err != nil {
+			return nil, fmt.Errorf("inflating layer %s: %w", layer.Digest, err)
+		}
+		sizes.Uncompressed += uncompressed
+	}
+
+	return sizes, nil
+}
+
+// layerUncompressedSize downloads layer's blob and counts its decompressed
+// byte count. Layers whose media type isn't gzip-compressed are already
+// stored uncompressed, so their blob size is returned unchanged.
+func (c *ArtifactRegistryClient) layerUncompressedSize(ctx context.Context, ref registry.Ref, layer registry.Layer) (int64, error) {
+	if !strings.Contains(layer.MediaType, "gzip") {
+		return layer.Size, nil
 	}
 
-	var manifest dockerManifest
-	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
-		return nil, fmt.Errorf("decoding manifest: %w", err)
+	blob, err := c.client.Blob(ctx, ref, layer.Digest)
+	if err != nil {
+		return 0, fmt.Errorf("requesting blob: %w", err)
 	}
+	defer blob.Close()
 
-	// Set media type from response header if not in body
-	if manifest.MediaType == "" {
-		manifest.MediaType = resp.Header.Get("Content-Type")
+	gz, err := gzip.NewReader(blob)
+	if err != nil {
+		return 0, fmt.Errorf("opening gzip stream: %w", err)
 	}
+	defer gz.Close()
 
-	return &manifest, nil
+	n, err := io.Copy(io.Discard, gz)
+	if err != nil {
+		return 0, fmt.Errorf("reading decompressed layer: %w", err)
+	}
+	return n, nil
 }
 
 // isManifestList returns true if the media type indicates a manifest list.
@@ -169,6 +269,21 @@ func isManifestList(mediaType string) bool {
 		strings.Contains(mediaType, "image.index")
 }
 
+// ref builds the registry.Ref for imageURI against this client's own
+// project/region, combined with the repo/image/tag parsed from imageURI.
+func (c *ArtifactRegistryClient) ref(imageURI string) (registry.Ref, error) {
+	region, project, repo, image, tag, err := parseImageURI(imageURI)
+	if err != nil {
+		return registry.Ref{}, err
+	}
+
+	return registry.Ref{
+		Host:      region + "-docker.pkg.dev",
+		Name:      fmt.Sprintf("%s/%s/%s", project, repo, image),
+		Reference: tag,
+	}, nil
+}
+
 // parseImageURI extracts components from an image URI.
 // Input:  europe-west1-docker.pkg.dev/project-id/discord-services/go-gin:latest
 // Returns: region, project, repo, image, tag
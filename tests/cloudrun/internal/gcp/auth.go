@@ -4,23 +4,55 @@ package gcp
 import (
 	"context"
 	"fmt"
+	"os"
 
+	"golang.org/x/oauth2/google"
 	"google.golang.org/api/idtoken"
 	"google.golang.org/api/option"
 	"google.golang.org/api/run/v2"
 )
 
-// ClientOptions returns common client options for GCP API clients.
-// Uses Application Default Credentials (ADC).
-func ClientOptions() []option.ClientOption {
-	return []option.ClientOption{
-		// ADC is used by default, no explicit options needed
+// clientScopes are the OAuth scopes requested when authenticating with an
+// explicit service account key file, covering everything the benchmark
+// suite's clients need: Cloud Run/Logging reads and GCS writes.
+var clientScopes = []string{
+	"https://www.googleapis.com/auth/cloud-platform",
+	"https://www.googleapis.com/auth/devstorage.read_write",
+	"https://www.googleapis.com/auth/logging.read",
+}
+
+// ClientOptions returns the client options GCP API clients should be built
+// with. When keyFilePath is empty, it returns nil and callers fall back to
+// Application Default Credentials. When set, it reads the service account
+// key and returns a token source scoped to clientScopes, so the tool can
+// run from CI runners or laptops without ADC configured.
+func ClientOptions(ctx context.Context, keyFilePath string) ([]option.ClientOption, error) {
+	if keyFilePath == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(keyFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading key file: %w", err)
+	}
+
+	jwtConfig, err := google.JWTConfigFromJSON(data, clientScopes...)
+	if err != nil {
+		return nil, fmt.Errorf("parsing key file: %w", err)
 	}
+
+	return []option.ClientOption{option.WithTokenSource(jwtConfig.TokenSource(ctx))}, nil
 }
 
-// NewRunService creates a new Cloud Run API service client.
-func NewRunService(ctx context.Context) (*run.Service, error) {
-	svc, err := run.NewService(ctx, ClientOptions()...)
+// NewRunService creates a new Cloud Run API service client. opts, if
+// given, take precedence over keyFilePath/ADC; see ClientOption.
+func NewRunService(ctx context.Context, keyFilePath string, opts ...ClientOption) (*run.Service, error) {
+	clientOpts, err := ResolveClientOptions(ctx, keyFilePath, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := run.NewService(ctx, clientOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("creating Cloud Run service: %w", err)
 	}
@@ -28,10 +60,17 @@ func NewRunService(ctx context.Context) (*run.Service, error) {
 }
 
 // GetIDToken fetches an ID token for the target audience (service URL).
-// Uses the default service account credentials.
+// With keyFilePath set, the token is minted from that service account's
+// key instead of Application Default Credentials, since the JWT auth flow
+// used for other clients doesn't itself produce ID tokens.
 // The token source automatically caches and refreshes tokens.
-func GetIDToken(ctx context.Context, audience string) (string, error) {
-	tokenSource, err := idtoken.NewTokenSource(ctx, audience)
+func GetIDToken(ctx context.Context, audience, keyFilePath string) (string, error) {
+	var opts []idtoken.ClientOption
+	if keyFilePath != "" {
+		opts = append(opts, idtoken.WithCredentialsFile(keyFilePath))
+	}
+
+	tokenSource, err := idtoken.NewTokenSource(ctx, audience, opts...)
 	if err != nil {
 		return "", fmt.Errorf("creating token source: %w", err)
 	}
@@ -0,0 +1,132 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+)
+
+// ClientOption configures authentication and transport for a GCP client
+// constructor (NewCloudRunClient, NewRunService, report.NewGCSUploader),
+// as an alternative to keyFilePath for CI environments where
+// GOOGLE_APPLICATION_CREDENTIALS isn't set, impersonated service
+// accounts, and unit tests that want to inject an httptest.Server-backed
+// round tripper.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	tokenSource        oauth2.TokenSource
+	serviceAccountJSON []byte
+	httpClient         *http.Client
+	scopes             []string
+	retryer            *Retryer
+	progress           ProgressReporter
+}
+
+// WithTokenSource authenticates with an already-constructed
+// oauth2.TokenSource, e.g. one produced by impersonating a service
+// account.
+func WithTokenSource(ts oauth2.TokenSource) ClientOption {
+	return func(o *clientOptions) { o.tokenSource = ts }
+}
+
+// WithServiceAccountJSON authenticates with a service account key loaded
+// from memory (e.g. a CI secret) rather than a path on disk.
+func WithServiceAccountJSON(jsonKey []byte) ClientOption {
+	return func(o *clientOptions) { o.serviceAccountJSON = jsonKey }
+}
+
+// WithHTTPClient replaces the client's underlying transport, letting a
+// test point it at an httptest.Server instead of a real GCP endpoint.
+func WithHTTPClient(c *http.Client) ClientOption {
+	return func(o *clientOptions) { o.httpClient = c }
+}
+
+// WithScopes overrides clientScopes for the token derived from
+// WithServiceAccountJSON. A token derived from keyFilePath always uses
+// clientScopes.
+func WithScopes(scopes ...string) ClientOption {
+	return func(o *clientOptions) { o.scopes = scopes }
+}
+
+// WithRetryer attaches a Retryer to a CloudRunClient, overriding
+// DefaultRetryer. Unlike the other ClientOptions, it has no effect on
+// NewRunService or report.NewGCSUploader - only NewCloudRunClient reads
+// it.
+func WithRetryer(r *Retryer) ClientOption {
+	return func(o *clientOptions) { o.retryer = r }
+}
+
+// retryerFromOptions extracts the Retryer passed via WithRetryer, if
+// any, without resolving auth. NewCloudRunClient calls this separately
+// from ResolveClientOptions since the retryer isn't an option.ClientOption.
+func retryerFromOptions(opts ...ClientOption) *Retryer {
+	var cfg clientOptions
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg.retryer
+}
+
+// WithProgressReporter attaches a ProgressReporter to a CloudRunClient or
+// GCSUploader, reporting deploy phases/polls or upload byte progress
+// respectively.
+func WithProgressReporter(r ProgressReporter) ClientOption {
+	return func(o *clientOptions) { o.progress = r }
+}
+
+// ProgressReporterFromOptions extracts the ProgressReporter passed via
+// WithProgressReporter, if any, without resolving auth. Exported since
+// report.NewGCSUploader, outside this package, needs it alongside
+// NewCloudRunClient.
+func ProgressReporterFromOptions(opts ...ClientOption) ProgressReporter {
+	var cfg clientOptions
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg.progress
+}
+
+// ResolveClientOptions turns the functional options above into the
+// option.ClientOption slice the underlying Google API clients expect. It
+// falls back to keyFilePath/Application Default Credentials, via
+// ClientOptions, when none of WithTokenSource/WithServiceAccountJSON is
+// given.
+func ResolveClientOptions(ctx context.Context, keyFilePath string, opts ...ClientOption) ([]option.ClientOption, error) {
+	var cfg clientOptions
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var result []option.ClientOption
+	switch {
+	case cfg.tokenSource != nil:
+		result = append(result, option.WithTokenSource(cfg.tokenSource))
+	case cfg.serviceAccountJSON != nil:
+		scopes := cfg.scopes
+		if scopes == nil {
+			scopes = clientScopes
+		}
+		jwtConfig, err := google.JWTConfigFromJSON(cfg.serviceAccountJSON, scopes...)
+		if err != nil {
+			return nil, fmt.Errorf("parsing service account JSON: %w", err)
+		}
+		result = append(result, option.WithTokenSource(jwtConfig.TokenSource(ctx)))
+	default:
+		fileOpts, err := ClientOptions(ctx, keyFilePath)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, fileOpts...)
+	}
+
+	if cfg.httpClient != nil {
+		result = append(result, option.WithHTTPClient(cfg.httpClient))
+	}
+
+	return result, nil
+}
@@ -11,25 +11,57 @@ import (
 
 // CloudRunClient provides methods for managing Cloud Run services.
 type CloudRunClient struct {
-	service   *run.Service
-	projectID string
-	region    string
+	service     *run.Service
+	projectID   string
+	region      string
+	keyFilePath string
+	retryer     *Retryer
+	progress    ProgressReporter
 }
 
-// NewCloudRunClient creates a new Cloud Run client.
-func NewCloudRunClient(ctx context.Context, projectID, region string) (*CloudRunClient, error) {
-	svc, err := NewRunService(ctx)
+// NewCloudRunClient creates a new Cloud Run client. keyFilePath, if set,
+// authenticates with that service account key instead of ADC; opts, if
+// given, take precedence over keyFilePath (see ClientOption). Every API
+// call the client makes is retried per a Retryer, DefaultRetryer unless
+// overridden with WithRetryer. A ProgressReporter attached with
+// WithProgressReporter is notified of Deploy's phases and polls; without
+// one, Deploy reports nothing.
+func NewCloudRunClient(ctx context.Context, projectID, region, keyFilePath string, opts ...ClientOption) (*CloudRunClient, error) {
+	svc, err := NewRunService(ctx, keyFilePath, opts...)
 	if err != nil {
 		return nil, err
 	}
 
+	retryer := retryerFromOptions(opts...)
+	if retryer == nil {
+		retryer = DefaultRetryer()
+	}
+
 	return &CloudRunClient{
-		service:   svc,
-		projectID: projectID,
-		region:    region,
+		service:     svc,
+		projectID:   projectID,
+		region:      region,
+		keyFilePath: keyFilePath,
+		retryer:     retryer,
+		progress:    ProgressReporterFromOptions(opts...),
 	}, nil
 }
 
+// onPhase notifies c.progress, if any, that a named phase began.
+func (c *CloudRunClient) onPhase(phase string) {
+	if c.progress != nil {
+		c.progress.OnPhase(phase)
+	}
+}
+
+// onPoll notifies c.progress, if any, of a single poll of a long-running
+// operation.
+func (c *CloudRunClient) onPoll(op string, elapsed time.Duration) {
+	if c.progress != nil {
+		c.progress.OnPoll(op, elapsed)
+	}
+}
+
 // DeployConfig contains configuration for deploying a Cloud Run service.
 type DeployConfig struct {
 	ServiceName     string            // Base name, e.g., "go-gin"
@@ -42,6 +74,11 @@ type DeployConfig struct {
 	ExecutionEnv    string            // "gen1" or "gen2"
 	StartupCPUBoost bool              // Enable startup CPU boost
 	EnvVars         map[string]string // Environment variables
+
+	// InvokerPolicy controls who may call the deployed service. The zero
+	// value is PublicInvoker, matching this type's long-standing default
+	// of allowing unauthenticated access.
+	InvokerPolicy InvokerPolicy
 }
 
 // FullServiceName returns the complete service name: discord-{ServiceName}-{RunID}
@@ -49,8 +86,22 @@ func (c *DeployConfig) FullServiceName() string {
 	return fmt.Sprintf("discord-%s-%s", c.ServiceName, c.RunID)
 }
 
+// DeployResult is the outcome of a successful Deploy. Retries reports
+// how much retrying the deploy needed, so benchmark reports can
+// attribute cold-start variance to control-plane flakiness rather than
+// real service startup latency.
+type DeployResult struct {
+	ServiceURL string
+	Retries    RetryMetrics
+}
+
 // Deploy deploys a service to Cloud Run and waits for it to be ready.
-func (c *CloudRunClient) Deploy(ctx context.Context, cfg DeployConfig) (string, error) {
+// The create/patch call and the wait for the resulting operation are
+// retried together per c.retryer: a long-running operation that fails
+// with a retryable code (UNAVAILABLE, DEADLINE_EXCEEDED) is treated as
+// worth resubmitting, while permanent failures (INVALID_ARGUMENT,
+// PERMISSION_DENIED) fail fast.
+func (c *CloudRunClient) Deploy(ctx context.Context, cfg DeployConfig) (DeployResult, error) {
 	fullName := cfg.FullServiceName()
 	parent := fmt.Sprintf("projects/%s/locations/%s", c.projectID, c.region)
 
@@ -99,82 +150,101 @@ func (c *CloudRunClient) Deploy(ctx context.Context, cfg DeployConfig) (string,
 		},
 	}
 
-	// Check if service already exists
-	existing, err := c.getService(ctx, fullName)
-	var op *run.GoogleLongrunningOperation
-	if err == nil && existing != nil {
-		// Update existing service
-		op, err = c.service.Projects.Locations.Services.Patch(
-			fmt.Sprintf("%s/services/%s", parent, fullName),
-			service,
-		).Context(ctx).Do()
-		if err != nil {
-			return "", fmt.Errorf("updating service %s: %w", fullName, err)
-		}
-	} else {
-		// Create new service
-		// Note: service.Name must be empty for Create - the name is passed via ServiceId()
-		op, err = c.service.Projects.Locations.Services.Create(parent, service).
-			ServiceId(fullName).
-			Context(ctx).
-			Do()
-		if err != nil {
-			return "", fmt.Errorf("creating service %s: %w", fullName, err)
+	var serviceURL string
+	metrics, err := c.retryer.do(ctx, func() error {
+		// Check if service already exists
+		existing, err := c.getService(ctx, fullName)
+		var op *run.GoogleLongrunningOperation
+		if err == nil && existing != nil {
+			// Update existing service
+			c.onPhase(DeployPhasePatching)
+			op, err = c.service.Projects.Locations.Services.Patch(
+				fmt.Sprintf("%s/services/%s", parent, fullName),
+				service,
+			).Context(ctx).Do()
+			if err != nil {
+				return fmt.Errorf("updating service %s: %w", fullName, err)
+			}
+		} else {
+			// Create new service
+			// Note: service.Name must be empty for Create - the name is passed via ServiceId()
+			c.onPhase(DeployPhaseCreating)
+			op, err = c.service.Projects.Locations.Services.Create(parent, service).
+				ServiceId(fullName).
+				Context(ctx).
+				Do()
+			if err != nil {
+				return fmt.Errorf("creating service %s: %w", fullName, err)
+			}
 		}
-	}
 
-	// Wait for the operation to complete
-	if err := c.waitForOperation(ctx, op.Name, 5*time.Minute); err != nil {
-		return "", fmt.Errorf("waiting for operation: %w", err)
-	}
+		// Wait for the operation to complete
+		c.onPhase(DeployPhaseWaitingOperation)
+		if err := c.waitForOperation(ctx, op.Name, 5*time.Minute); err != nil {
+			return fmt.Errorf("waiting for operation: %w", err)
+		}
 
-	// Wait for service to be ready (should be quick after operation completes)
-	serviceURL, err := c.WaitForReady(ctx, fullName, 2*time.Minute)
+		// Wait for service to be ready (should be quick after operation completes)
+		c.onPhase(DeployPhaseWaitingReady)
+		url, err := c.WaitForReady(ctx, fullName, 2*time.Minute)
+		if err != nil {
+			return err
+		}
+		serviceURL = url
+		return nil
+	})
 	if err != nil {
-		return "", err
-	}
-
-	// Make the service publicly accessible (allow unauthenticated)
-	if err := c.allowUnauthenticated(ctx, fullName); err != nil {
-		return "", fmt.Errorf("setting IAM policy: %w", err)
+		// A cancelled deploy may have left a service half-created; clean
+		// it up on a fresh context since ctx is already done.
+		if ctx.Err() != nil {
+			cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			_ = c.Delete(cleanupCtx, fullName)
+			cancel()
+		}
+		return DeployResult{}, err
 	}
 
-	return serviceURL, nil
-}
-
-// allowUnauthenticated sets IAM policy to allow unauthenticated access.
-func (c *CloudRunClient) allowUnauthenticated(ctx context.Context, serviceName string) error {
-	resource := fmt.Sprintf("projects/%s/locations/%s/services/%s", c.projectID, c.region, serviceName)
-
-	policy := &run.GoogleIamV1Policy{
-		Bindings: []*run.GoogleIamV1Binding{
-			{
-				Role:    "roles/run.invoker",
-				Members: []string{"allUsers"},
-			},
-		},
+	// Apply the configured invoker policy (public, specific members, or
+	// none for ID-token auth).
+	c.onPhase(DeployPhaseSettingIAM)
+	if err := c.setInvokerPolicy(ctx, fullName, cfg.InvokerPolicy); err != nil {
+		return DeployResult{}, fmt.Errorf("setting IAM policy: %w", err)
 	}
 
-	_, err := c.service.Projects.Locations.Services.SetIamPolicy(
-		resource,
-		&run.GoogleIamV1SetIamPolicyRequest{Policy: policy},
-	).Context(ctx).Do()
-
-	return err
+	return DeployResult{ServiceURL: serviceURL, Retries: metrics}, nil
 }
 
 // getService retrieves a service by name.
 func (c *CloudRunClient) getService(ctx context.Context, serviceName string) (*run.GoogleCloudRunV2Service, error) {
 	name := fmt.Sprintf("projects/%s/locations/%s/services/%s", c.projectID, c.region, serviceName)
-	return c.service.Projects.Locations.Services.Get(name).Context(ctx).Do()
+
+	var svc *run.GoogleCloudRunV2Service
+	_, err := c.retryer.do(ctx, func() error {
+		var getErr error
+		svc, getErr = c.service.Projects.Locations.Services.Get(name).Context(ctx).Do()
+		return getErr
+	})
+	return svc, err
 }
 
-// waitForOperation polls a long-running operation until it completes.
+// waitForOperation polls a long-running operation until it completes. A
+// terminal operation error is wrapped as an operationError so the
+// Retryer wrapping the caller's Deploy attempt can tell a transient
+// failure (UNAVAILABLE, DEADLINE_EXCEEDED) from a permanent one
+// (INVALID_ARGUMENT, PERMISSION_DENIED, ...) worth failing fast on.
 func (c *CloudRunClient) waitForOperation(ctx context.Context, operationName string, timeout time.Duration) error {
-	deadline := time.Now().Add(timeout)
+	start := time.Now()
+	deadline := start.Add(timeout)
 
 	for time.Now().Before(deadline) {
-		op, err := c.service.Projects.Locations.Operations.Get(operationName).Context(ctx).Do()
+		c.onPoll(PollWaitOperation, time.Since(start))
+
+		var op *run.GoogleLongrunningOperation
+		_, err := c.retryer.do(ctx, func() error {
+			var getErr error
+			op, getErr = c.service.Projects.Locations.Operations.Get(operationName).Context(ctx).Do()
+			return getErr
+		})
 		if err != nil {
 			return fmt.Errorf("getting operation status: %w", err)
 		}
@@ -182,7 +252,7 @@ func (c *CloudRunClient) waitForOperation(ctx context.Context, operationName str
 		if op.Done {
 			// Check if the operation failed
 			if op.Error != nil {
-				return fmt.Errorf("operation failed: %s (code %d)", op.Error.Message, op.Error.Code)
+				return &operationError{code: op.Error.Code, message: op.Error.Message}
 			}
 			return nil
 		}
@@ -200,9 +270,12 @@ func (c *CloudRunClient) waitForOperation(ctx context.Context, operationName str
 
 // WaitForReady waits for a service to be ready and returns its URL.
 func (c *CloudRunClient) WaitForReady(ctx context.Context, serviceName string, timeout time.Duration) (string, error) {
-	deadline := time.Now().Add(timeout)
+	start := time.Now()
+	deadline := start.Add(timeout)
 
 	for time.Now().Before(deadline) {
+		c.onPoll(PollWaitReady, time.Since(start))
+
 		svc, err := c.getService(ctx, serviceName)
 		if err != nil {
 			return "", fmt.Errorf("getting service status: %w", err)
@@ -233,7 +306,11 @@ func (c *CloudRunClient) WaitForReady(ctx context.Context, serviceName string, t
 // Delete deletes a Cloud Run service.
 func (c *CloudRunClient) Delete(ctx context.Context, serviceName string) error {
 	name := fmt.Sprintf("projects/%s/locations/%s/services/%s", c.projectID, c.region, serviceName)
-	_, err := c.service.Projects.Locations.Services.Delete(name).Context(ctx).Do()
+
+	_, err := c.retryer.do(ctx, func() error {
+		_, err := c.service.Projects.Locations.Services.Delete(name).Context(ctx).Do()
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("deleting service %s: %w", serviceName, err)
 	}
@@ -270,12 +347,17 @@ func (c *CloudRunClient) ListByPrefix(ctx context.Context, prefix string) ([]str
 	pageToken := ""
 
 	for {
-		call := c.service.Projects.Locations.Services.List(parent).Context(ctx)
-		if pageToken != "" {
-			call = call.PageToken(pageToken)
-		}
+		var resp *run.GoogleCloudRunV2ListServicesResponse
+		_, err := c.retryer.do(ctx, func() error {
+			call := c.service.Projects.Locations.Services.List(parent).Context(ctx)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
 
-		resp, err := call.Do()
+			var doErr error
+			resp, doErr = call.Do()
+			return doErr
+		})
 		if err != nil {
 			return nil, fmt.Errorf("listing services: %w", err)
 		}
@@ -0,0 +1,195 @@
+package gcp
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/registry"
+)
+
+// cosignSignatureAnnotation is the OCI layer annotation cosign's
+// "simple signing" format stores a signature artifact's base64 signature
+// under.
+const cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+// TrustedKey is one public key ImageVerifier accepts a signature from.
+type TrustedKey struct {
+	// KeyID identifies this key in VerificationResult.KeyID when it
+	// produced a valid signature.
+	KeyID string
+	// Subject is reported in VerificationResult.Subject alongside KeyID,
+	// e.g. the CI identity the key belongs to.
+	Subject string
+	// PublicKey must be an ed25519.PublicKey or *ecdsa.PublicKey.
+	PublicKey crypto.PublicKey
+}
+
+// VerificationResult reports whether an image had a signature artifact at
+// all (Signed) and, if so, whether that signature validated against one of
+// ImageVerifier's trusted keys (Verified).
+type VerificationResult struct {
+	Signed   bool
+	Verified bool
+	KeyID    string
+	Subject  string
+}
+
+// ImageVerifier checks an image's cosign "simple signing" signature
+// artifact against a configured set of trusted public keys, so deploy/
+// status commands can report whether the image currently serving a
+// Discord interaction was signed by an expected CI key.
+type ImageVerifier struct {
+	client      registry.Client
+	trustedKeys []TrustedKey
+}
+
+// NewImageVerifier returns an ImageVerifier checking signatures fetched
+// via client against trustedKeys.
+func NewImageVerifier(client registry.Client, trustedKeys []TrustedKey) *ImageVerifier {
+	return &ImageVerifier{client: client, trustedKeys: trustedKeys}
+}
+
+// Verify fetches imageURI's manifest digest and the cosign signature
+// artifact conventionally pushed alongside it at tag "sha256-<digest>.sig"
+// in the same repository, then checks its signature against v's trusted
+// keys. An image with no signature artifact returns Signed=false without
+// error; a signature that doesn't validate against any trusted key returns
+// Signed=true, Verified=false.
+func (v *ImageVerifier) Verify(ctx context.Context, imageURI string) (*VerificationResult, error) {
+	region, project, repo, image, tag, err := parseImageURI(imageURI)
+	if err != nil {
+		return nil, fmt.Errorf("parsing image URI: %w", err)
+	}
+	ref := registry.Ref{
+		Host:      region + "-docker.pkg.dev",
+		Name:      fmt.Sprintf("%s/%s/%s", project, repo, image),
+		Reference: tag,
+	}
+
+	_, _, digest, err := v.client.Manifest(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("fetching image manifest: %w", err)
+	}
+
+	sigRef := ref
+	sigRef.Reference = signatureTag(digest)
+
+	exists, _, err := v.client.ManifestExists(ctx, sigRef)
+	if err != nil {
+		return nil, fmt.Errorf("checking signature artifact: %w", err)
+	}
+	if !exists {
+		return &VerificationResult{Signed: false}, nil
+	}
+
+	sigManifest, _, _, err := v.client.Manifest(ctx, sigRef)
+	if err != nil {
+		return nil, fmt.Errorf("fetching signature manifest: %w", err)
+	}
+
+	result := &VerificationResult{Signed: true}
+	for _, layer := range sigManifest.Layers {
+		sigB64, ok := layer.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(sigB64)
+		if err != nil {
+			continue
+		}
+
+		payload, err := v.fetchPayload(ctx, sigRef, layer.Digest)
+		if err != nil {
+			continue
+		}
+
+		if !payloadMatchesDigest(payload, digest) {
+			continue
+		}
+
+		if key, ok := v.matchSignature(payload, sig); ok {
+			result.Verified = true
+			result.KeyID = key.KeyID
+			result.Subject = key.Subject
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// fetchPayload downloads and buffers the signed "simple signing" payload blob.
+func (v *ImageVerifier) fetchPayload(ctx context.Context, ref registry.Ref, digest string) ([]byte, error) {
+	blob, err := v.client.Blob(ctx, ref, digest)
+	if err != nil {
+		return nil, fmt.Errorf("fetching signed payload: %w", err)
+	}
+	defer blob.Close()
+	return io.ReadAll(blob)
+}
+
+// matchSignature tries sig against every trusted key, returning the first
+// one it validates against.
+func (v *ImageVerifier) matchSignature(payload, sig []byte) (TrustedKey, bool) {
+	for _, key := range v.trustedKeys {
+		if verifySignature(key.PublicKey, payload, sig) {
+			return key, true
+		}
+	}
+	return TrustedKey{}, false
+}
+
+// verifySignature checks sig against payload using pub, which must be an
+// ed25519.PublicKey or *ecdsa.PublicKey; any other type never matches.
+func verifySignature(pub crypto.PublicKey, payload, sig []byte) bool {
+	switch key := pub.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, payload, sig)
+	case *ecdsa.PublicKey:
+		hash := sha256.Sum256(payload)
+		return ecdsa.VerifyASN1(key, hash[:], sig)
+	default:
+		return false
+	}
+}
+
+// cosignSimpleSigningPayload is the subset of cosign's "simple signing"
+// envelope format this package cares about: the field binding a signature
+// to the one image digest it was produced for.
+type cosignSimpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// payloadMatchesDigest reports whether a "simple signing" payload's
+// embedded critical.image.docker-manifest-digest names digest. A signature
+// that validates over the payload bytes alone proves nothing about which
+// image it was issued for - simple signing binds the two by embedding the
+// digest in the signed payload itself, so skipping this check lets anyone
+// who can push to the repo replay a stale, validly-signed payload onto a
+// different image.
+func payloadMatchesDigest(payload []byte, digest string) bool {
+	var p cosignSimpleSigningPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return false
+	}
+	return p.Critical.Image.DockerManifestDigest == digest
+}
+
+// signatureTag returns the cosign "simple signing" tag a manifest digest's
+// signature artifact is conventionally pushed to, e.g.
+// "sha256:abcd1234" -> "sha256-abcd1234.sig".
+func signatureTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1) + ".sig"
+}
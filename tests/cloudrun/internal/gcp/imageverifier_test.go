@@ -0,0 +1,160 @@
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/registry"
+)
+
+// fakeRegistryClient is a minimal registry.Client test double serving one
+// image manifest and, optionally, one cosign signature artifact alongside it.
+type fakeRegistryClient struct {
+	imageDigest string
+
+	sigManifest *registry.Manifest
+	sigPayload  []byte
+}
+
+func (f *fakeRegistryClient) Manifest(ctx context.Context, ref registry.Ref) (*registry.Manifest, string, string, error) {
+	if ref.Reference == signatureTag(f.imageDigest) {
+		return f.sigManifest, "application/vnd.oci.image.manifest.v1+json", "sha256:sig", nil
+	}
+	return &registry.Manifest{}, "application/vnd.oci.image.manifest.v1+json", f.imageDigest, nil
+}
+
+func (f *fakeRegistryClient) ManifestExists(ctx context.Context, ref registry.Ref) (bool, string, error) {
+	return f.sigManifest != nil && ref.Reference == signatureTag(f.imageDigest), "", nil
+}
+
+func (f *fakeRegistryClient) Blob(ctx context.Context, ref registry.Ref, digest string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(f.sigPayload)), nil
+}
+
+func (f *fakeRegistryClient) BlobStat(ctx context.Context, ref registry.Ref, digest string) (int64, error) {
+	return int64(len(f.sigPayload)), nil
+}
+
+func (f *fakeRegistryClient) Tags(ctx context.Context, ref registry.Ref) ([]string, error) {
+	return nil, nil
+}
+
+func TestImageVerifier_Unsigned(t *testing.T) {
+	client := &fakeRegistryClient{imageDigest: "sha256:deadbeef"}
+	verifier := NewImageVerifier(client, nil)
+
+	result, err := verifier.Verify(context.Background(), "europe-west1-docker.pkg.dev/proj/repo/image:latest")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if result.Signed {
+		t.Errorf("Signed = true, want false for an image with no signature artifact")
+	}
+}
+
+func TestImageVerifier_SignedAndVerified(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	payload := []byte(`{"critical":{"image":{"docker-manifest-digest":"sha256:deadbeef"}}}`)
+	sig := ed25519.Sign(priv, payload)
+
+	client := &fakeRegistryClient{
+		imageDigest: "sha256:deadbeef",
+		sigPayload:  payload,
+		sigManifest: &registry.Manifest{
+			Layers: []registry.Layer{
+				{
+					Digest:      "sha256:payload",
+					Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+				},
+			},
+		},
+	}
+	verifier := NewImageVerifier(client, []TrustedKey{{KeyID: "ci-key", Subject: "ci@example.com", PublicKey: pub}})
+
+	result, err := verifier.Verify(context.Background(), "europe-west1-docker.pkg.dev/proj/repo/image:latest")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !result.Signed || !result.Verified {
+		t.Fatalf("result = %+v, want Signed=true Verified=true", result)
+	}
+	if result.KeyID != "ci-key" || result.Subject != "ci@example.com" {
+		t.Errorf("result = %+v, want KeyID=ci-key Subject=ci@example.com", result)
+	}
+}
+
+func TestImageVerifier_SignedButUntrusted(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(nil)
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+
+	payload := []byte(`{"critical":{"image":{"docker-manifest-digest":"sha256:deadbeef"}}}`)
+	sig := ed25519.Sign(priv, payload)
+
+	client := &fakeRegistryClient{
+		imageDigest: "sha256:deadbeef",
+		sigPayload:  payload,
+		sigManifest: &registry.Manifest{
+			Layers: []registry.Layer{
+				{
+					Digest:      "sha256:payload",
+					Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+				},
+			},
+		},
+	}
+	verifier := NewImageVerifier(client, []TrustedKey{{KeyID: "wrong-key", PublicKey: otherPub}})
+
+	result, err := verifier.Verify(context.Background(), "europe-west1-docker.pkg.dev/proj/repo/image:latest")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !result.Signed || result.Verified {
+		t.Fatalf("result = %+v, want Signed=true Verified=false", result)
+	}
+}
+
+// TestImageVerifier_SignedForDifferentDigest exercises a payload that is
+// validly signed by a trusted key, but whose embedded
+// critical.image.docker-manifest-digest names a different image than the
+// one actually fetched - e.g. a stale signature artifact replayed onto a
+// new image. It must not verify even though the cryptographic signature
+// checks out.
+func TestImageVerifier_SignedForDifferentDigest(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	payload := []byte(`{"critical":{"image":{"docker-manifest-digest":"sha256:someotherimage"}}}`)
+	sig := ed25519.Sign(priv, payload)
+
+	client := &fakeRegistryClient{
+		imageDigest: "sha256:deadbeef",
+		sigPayload:  payload,
+		sigManifest: &registry.Manifest{
+			Layers: []registry.Layer{
+				{
+					Digest:      "sha256:payload",
+					Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+				},
+			},
+		},
+	}
+	verifier := NewImageVerifier(client, []TrustedKey{{KeyID: "ci-key", PublicKey: pub}})
+
+	result, err := verifier.Verify(context.Background(), "europe-west1-docker.pkg.dev/proj/repo/image:latest")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !result.Signed || result.Verified {
+		t.Fatalf("result = %+v, want Signed=true Verified=false (signature is for a different image digest)", result)
+	}
+}
@@ -15,9 +15,15 @@ type LoggingClient struct {
 	projectID string
 }
 
-// NewLoggingClient creates a new Cloud Logging client.
-func NewLoggingClient(ctx context.Context, projectID string) (*LoggingClient, error) {
-	client, err := logadmin.NewClient(ctx, projectID)
+// NewLoggingClient creates a new Cloud Logging client. keyFilePath, if set,
+// authenticates with that service account key instead of ADC.
+func NewLoggingClient(ctx context.Context, projectID, keyFilePath string) (*LoggingClient, error) {
+	opts, err := ClientOptions(ctx, keyFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := logadmin.NewClient(ctx, projectID, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("creating logging client: %w", err)
 	}
@@ -123,6 +129,147 @@ func (c *LoggingClient) GetRequestLatencyFromLogs(ctx context.Context, serviceNa
 	return 0, fmt.Errorf("no request latency found in logs")
 }
 
+// StartupTimeline captures a structured breakdown of one Cloud Run
+// instance's startup, assembled from the jsonPayload events Cloud Run
+// emits (image-pull, container-start, runtime-ready, CPU boost) alongside
+// the plain-text "Container started in X.XXs" message
+// GetContainerStartupMetrics looks for.
+type StartupTimeline struct {
+	InstanceID             string
+	ImagePullDuration      time.Duration
+	ContainerStartDuration time.Duration
+	RuntimeReadyDuration   time.Duration
+	FirstRequestLatency    time.Duration
+	CPUBoosted             bool
+}
+
+// EntriesPager iterates Cloud Logging entries matching a filter one page
+// at a time, wrapping logadmin's underlying pagination so callers can
+// bound how many entries they read per round trip while still following
+// nextPageToken transparently, and abort cleanly on context cancellation
+// instead of reading until the iterator is exhausted.
+type EntriesPager struct {
+	ctx   context.Context
+	pager *iterator.Pager
+}
+
+// NewEntriesPager creates a pager over log entries matching filter,
+// fetching pageSize entries per call to NextPage.
+func (c *LoggingClient) NewEntriesPager(ctx context.Context, filter string, pageSize int) *EntriesPager {
+	it := c.client.Entries(ctx, logadmin.Filter(filter))
+	return &EntriesPager{
+		ctx:   ctx,
+		pager: iterator.NewPager(it, pageSize, ""),
+	}
+}
+
+// NextPage fetches the next page of entries. It returns iterator.Done
+// once no entries remain, or the pager's context error if it has been
+// cancelled.
+func (p *EntriesPager) NextPage() ([]*logadmin.Entry, error) {
+	if err := p.ctx.Err(); err != nil {
+		return nil, err
+	}
+	var entries []*logadmin.Entry
+	if _, err := p.pager.NextPage(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Timeline returns a merged, per-instance StartupTimeline for serviceName
+// and revision in region, built from log entries emitted within the last
+// window of time. It's suitable for attributing where a measured cold
+// start in the benchmark package actually spent its time, rather than
+// just the single "Container started" latency GetContainerStartupMetrics
+// reports.
+func (c *LoggingClient) Timeline(ctx context.Context, serviceName, region, revision string, window time.Duration) (map[string]*StartupTimeline, error) {
+	after := time.Now().Add(-window)
+	filter := fmt.Sprintf(`
+		resource.type="cloud_run_revision"
+		resource.labels.service_name="%s"
+		resource.labels.location="%s"
+		resource.labels.revision_name="%s"
+		timestamp >= "%s"
+	`, serviceName, region, revision, after.Format(time.RFC3339))
+
+	timelines := make(map[string]*StartupTimeline)
+
+	pager := c.NewEntriesPager(ctx, filter, 200)
+	for {
+		entries, err := pager.NextPage()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading log entries: %w", err)
+		}
+		if len(entries) == 0 {
+			break
+		}
+
+		for _, entry := range entries {
+			instanceID := entry.Labels["instanceId"]
+			if instanceID == "" {
+				continue
+			}
+			tl := timelines[instanceID]
+			if tl == nil {
+				tl = &StartupTimeline{InstanceID: instanceID}
+				timelines[instanceID] = tl
+			}
+
+			switch payload := entry.Payload.(type) {
+			case map[string]interface{}:
+				applyJSONStartupPayload(tl, payload)
+			case string:
+				applyTextStartupPayload(tl, payload)
+			}
+
+			if entry.HTTPRequest != nil && entry.HTTPRequest.Latency > 0 && tl.FirstRequestLatency == 0 {
+				tl.FirstRequestLatency = entry.HTTPRequest.Latency
+			}
+		}
+	}
+
+	return timelines, nil
+}
+
+// applyJSONStartupPayload extracts timing fields from Cloud Run's
+// structured jsonPayload startup events, keyed by an "event" field.
+func applyJSONStartupPayload(tl *StartupTimeline, payload map[string]interface{}) {
+	event, _ := payload["event"].(string)
+	switch event {
+	case "image_pull":
+		tl.ImagePullDuration = durationFromSecondsField(payload["duration_seconds"])
+	case "container_start":
+		tl.ContainerStartDuration = durationFromSecondsField(payload["duration_seconds"])
+	case "runtime_ready":
+		tl.RuntimeReadyDuration = durationFromSecondsField(payload["duration_seconds"])
+	}
+	if boosted, ok := payload["cpu_boost"].(bool); ok && boosted {
+		tl.CPUBoosted = true
+	}
+}
+
+// applyTextStartupPayload falls back to the plain-text "Container started
+// in X.XXs" message for log entries that predate Cloud Run's structured
+// startup events.
+func applyTextStartupPayload(tl *StartupTimeline, payload string) {
+	var seconds float64
+	if _, err := fmt.Sscanf(payload, "Container started in %fs", &seconds); err == nil {
+		tl.ContainerStartDuration = time.Duration(seconds * float64(time.Second))
+	}
+}
+
+func durationFromSecondsField(v interface{}) time.Duration {
+	seconds, ok := v.(float64)
+	if !ok {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
 // WaitForStartupLog waits for the container startup log to appear.
 func (c *LoggingClient) WaitForStartupLog(ctx context.Context, serviceName, region string, startTime time.Time, timeout time.Duration) (*ContainerStartupMetrics, error) {
 	deadline := time.Now().Add(timeout)
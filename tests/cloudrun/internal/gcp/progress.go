@@ -0,0 +1,48 @@
+package gcp
+
+import "time"
+
+// ProgressReporter receives low-level progress signals from long-running
+// GCP operations - CloudRunClient.Deploy's phases and report.GCSUploader's
+// byte-level upload progress - so a CLI can render live feedback during
+// multi-minute deploys and uploads instead of leaving the terminal
+// silent. It's a different, lower-level interface than
+// benchmark.ProgressReporter, which tracks benchmark iterations rather
+// than the phases/bytes of a single API call.
+//
+// Implementations must be safe for concurrent use, since UploadDir
+// uploads files from multiple goroutines.
+type ProgressReporter interface {
+	// OnPhase reports entering a named phase of a long-running call, e.g.
+	// one of the DeployPhase constants below, or "uploading:<gcsPath>"
+	// from GCSUploader.
+	OnPhase(phase string)
+
+	// OnBytes reports upload progress for path: uploaded bytes so far out
+	// of total, or total -1 if the size isn't known upfront. path
+	// identifies which of UploadDir's concurrent uploads this call
+	// belongs to, since multiple files upload in parallel and report
+	// interleaved OnBytes calls.
+	OnBytes(path string, uploaded, total int64)
+
+	// OnPoll reports a single poll of a long-running operation (Deploy's
+	// wait for the operation or for the service to become ready), so a
+	// caller can show how long it's been waiting.
+	OnPoll(op string, elapsed time.Duration)
+}
+
+// Deploy phase names reported to ProgressReporter.OnPhase, shared so a
+// reporter can distinguish them without parsing free-form strings.
+const (
+	DeployPhaseCreating         = "creating"
+	DeployPhasePatching         = "patching"
+	DeployPhaseWaitingOperation = "waiting_operation"
+	DeployPhaseWaitingReady     = "waiting_ready"
+	DeployPhaseSettingIAM       = "setting_iam"
+)
+
+// Poll operation names reported to ProgressReporter.OnPoll.
+const (
+	PollWaitOperation = "wait_operation"
+	PollWaitReady     = "wait_ready"
+)
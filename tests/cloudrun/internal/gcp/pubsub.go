@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/pubsub"
+	"google.golang.org/api/option"
 )
 
 // PubSubClient provides methods for managing Pub/Sub topics and subscriptions.
@@ -15,9 +16,19 @@ type PubSubClient struct {
 	projectID string
 }
 
-// NewPubSubClient creates a new Pub/Sub client.
-func NewPubSubClient(ctx context.Context, projectID string) (*PubSubClient, error) {
-	client, err := pubsub.NewClient(ctx, projectID)
+// NewPubSubClient creates a new Pub/Sub client. keyFilePath, if set,
+// authenticates with that service account key instead of ADC. extraOpts is
+// appended after the auth-derived options, letting tests redirect the
+// client at an in-process fake via testsupport/pstestserver's
+// ClientOptions instead of a real project.
+func NewPubSubClient(ctx context.Context, projectID, keyFilePath string, extraOpts ...option.ClientOption) (*PubSubClient, error) {
+	opts, err := ClientOptions(ctx, keyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, extraOpts...)
+
+	client, err := pubsub.NewClient(ctx, projectID, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("creating Pub/Sub client: %w", err)
 	}
@@ -98,14 +109,50 @@ func (c *PubSubClient) CreateSubscription(ctx context.Context, cfg PubSubConfig)
 	return nil
 }
 
+// InteractionIDAttribute is the Pub/Sub message attribute key the
+// benchmark harness sets when publishing a synthetic interaction, so a
+// caller of PullMessages or PullMessagesDedup can correlate a delivered
+// message (through Message.Attributes) back to the Discord interaction ID
+// that triggered it, independent of the Pub/Sub message ID.
+const InteractionIDAttribute = "_discord_interaction_id"
+
+// SentAtAttribute is the Pub/Sub message attribute key holding the
+// RFC3339 timestamp of when the benchmark harness sent the interaction
+// that produced this message, letting PullMessagesDedup compute
+// end-to-end latency.
+const SentAtAttribute = "_discord_sent_at"
+
 // Message represents a Pub/Sub message.
 type Message struct {
-	ID         string
-	Data       []byte
-	Attributes map[string]string
+	ID          string
+	Data        []byte
+	Attributes  map[string]string
 	PublishTime time.Time
 }
 
+// Publish publishes a single message to the benchmark run's topic and
+// waits for the publish to complete, returning the server-assigned
+// message ID and the Publish call's own timestamp (for computing
+// publish-to-receive latency once PullMessages delivers it). Batching is
+// disabled (CountThreshold: 1) so Publish doesn't wait on other
+// in-flight messages to fill a batch, and the topic is stopped after
+// every call to flush and release its publish goroutines.
+func (c *PubSubClient) Publish(ctx context.Context, cfg PubSubConfig, data []byte, attrs map[string]string) (serverID string, publishTime time.Time, err error) {
+	topic := c.client.Topic(cfg.TopicName())
+	topic.PublishSettings.CountThreshold = 1
+	defer topic.Stop()
+
+	publishTime = time.Now()
+	result := topic.Publish(ctx, &pubsub.Message{Data: data, Attributes: attrs})
+
+	serverID, err = result.Get(ctx)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("publishing message: %w", err)
+	}
+
+	return serverID, publishTime, nil
+}
+
 // PullMessages pulls messages from the subscription with a timeout.
 func (c *PubSubClient) PullMessages(ctx context.Context, cfg PubSubConfig, timeout time.Duration) ([]Message, error) {
 	subName := cfg.SubscriptionName()
@@ -137,6 +184,46 @@ func (c *PubSubClient) PullMessages(ctx context.Context, cfg PubSubConfig, timeo
 	return messages, nil
 }
 
+// DedupedMessage is a message returned by PullMessagesDedup, augmented
+// with the end-to-end latency from when the interaction was sent (as
+// recorded in SentAtAttribute) to the message's PublishTime.
+type DedupedMessage struct {
+	Message
+	Latency time.Duration // zero if SentAtAttribute was absent or unparseable
+}
+
+// PullMessagesDedup behaves like PullMessages but filters out any message
+// whose ID is already present in seen, so a benchmark run that polls
+// repeatedly can distinguish redeliveries from genuine new events. seen is
+// mutated in place to record every ID this call returns. For messages
+// carrying a SentAtAttribute, Latency is computed as PublishTime minus
+// that sent time, giving the harness a measure of end-to-end latency
+// rather than just Pub/Sub delivery time.
+func (c *PubSubClient) PullMessagesDedup(ctx context.Context, cfg PubSubConfig, timeout time.Duration, seen map[string]struct{}) ([]DedupedMessage, error) {
+	messages, err := c.PullMessages(ctx, cfg, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	deduped := make([]DedupedMessage, 0, len(messages))
+	for _, msg := range messages {
+		if _, ok := seen[msg.ID]; ok {
+			continue
+		}
+		seen[msg.ID] = struct{}{}
+
+		dm := DedupedMessage{Message: msg}
+		if sentAt, ok := msg.Attributes[SentAtAttribute]; ok {
+			if t, err := time.Parse(time.RFC3339, sentAt); err == nil {
+				dm.Latency = msg.PublishTime.Sub(t)
+			}
+		}
+		deduped = append(deduped, dm)
+	}
+
+	return deduped, nil
+}
+
 // DeleteTopic deletes the Pub/Sub topic for the benchmark run.
 func (c *PubSubClient) DeleteTopic(ctx context.Context, cfg PubSubConfig) error {
 	topicName := cfg.TopicName()
@@ -0,0 +1,188 @@
+package gcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/testsupport/pstestserver"
+)
+
+func newClientAgainstFake(t *testing.T, fake *pstestserver.Server) *PubSubClient {
+	t.Helper()
+
+	client, err := NewPubSubClient(context.Background(), "test-project", "", fake.ClientOptions()...)
+	if err != nil {
+		t.Fatalf("creating Pub/Sub client against fake: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestPubSubClient_SetupAndCleanup(t *testing.T) {
+	fake := pstestserver.New()
+	defer fake.Close()
+
+	client := newClientAgainstFake(t, fake)
+	cfg := PubSubConfig{RunID: "test-run"}
+
+	if err := client.Setup(context.Background(), cfg); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	// Setup should be idempotent: calling it again shouldn't error just
+	// because the topic/subscription already exist.
+	if err := client.Setup(context.Background(), cfg); err != nil {
+		t.Fatalf("second Setup() error = %v", err)
+	}
+
+	if err := client.Cleanup(context.Background(), cfg); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+}
+
+func TestPubSubClient_PullMessages(t *testing.T) {
+	fake := pstestserver.New()
+	defer fake.Close()
+
+	client := newClientAgainstFake(t, fake)
+	cfg := PubSubConfig{RunID: "test-run"}
+
+	if err := client.Setup(context.Background(), cfg); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	fake.SeedMessages(cfg.TopicName(),
+		pstestserver.Message{Data: []byte("first"), Attributes: map[string]string{"n": "1"}},
+		pstestserver.Message{Data: []byte("second"), Attributes: map[string]string{"n": "2"}},
+	)
+
+	messages, err := client.PullMessages(context.Background(), cfg, 2*time.Second)
+	if err != nil {
+		t.Fatalf("PullMessages() error = %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+
+	got := map[string]bool{string(messages[0].Data): true, string(messages[1].Data): true}
+	for _, want := range []string{"first", "second"} {
+		if !got[want] {
+			t.Errorf("expected a message with data %q, got %v", want, got)
+		}
+	}
+}
+
+func TestPubSubClient_PullMessagesDedup(t *testing.T) {
+	fake := pstestserver.New()
+	defer fake.Close()
+
+	client := newClientAgainstFake(t, fake)
+	cfg := PubSubConfig{RunID: "test-run"}
+
+	if err := client.Setup(context.Background(), cfg); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	sentAt := time.Now().Add(-250 * time.Millisecond).UTC().Format(time.RFC3339)
+	fake.SeedMessages(cfg.TopicName(),
+		pstestserver.Message{
+			Data: []byte("first"),
+			Attributes: map[string]string{
+				InteractionIDAttribute: "interaction-1",
+				SentAtAttribute:        sentAt,
+			},
+		},
+		pstestserver.Message{Data: []byte("second"), Attributes: map[string]string{InteractionIDAttribute: "interaction-2"}},
+	)
+
+	seen := make(map[string]struct{})
+	first, err := client.PullMessagesDedup(context.Background(), cfg, 2*time.Second, seen)
+	if err != nil {
+		t.Fatalf("PullMessagesDedup() error = %v", err)
+	}
+	if len(first) != 2 {
+		t.Fatalf("expected 2 new messages, got %d", len(first))
+	}
+	for _, m := range first {
+		if m.Attributes[InteractionIDAttribute] == "interaction-1" && m.Latency <= 0 {
+			t.Errorf("expected positive latency for interaction-1, got %v", m.Latency)
+		}
+	}
+
+	// Re-publishing the same data produces brand-new message IDs in the
+	// fake, so instead assert that re-pulling with the same seen set
+	// against no new messages returns nothing.
+	again, err := client.PullMessagesDedup(context.Background(), cfg, 500*time.Millisecond, seen)
+	if err != nil {
+		t.Fatalf("second PullMessagesDedup() error = %v", err)
+	}
+	if len(again) != 0 {
+		t.Errorf("expected no new messages on second pull, got %d", len(again))
+	}
+	if len(seen) != 2 {
+		t.Errorf("expected seen to track 2 message IDs, got %d", len(seen))
+	}
+}
+
+func TestPubSubClient_PublishWithinSLO(t *testing.T) {
+	fake := pstestserver.New()
+	defer fake.Close()
+
+	client := newClientAgainstFake(t, fake)
+	cfg := PubSubConfig{RunID: "test-run"}
+
+	if err := client.Setup(context.Background(), cfg); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	sendTime := time.Now()
+	serverID, publishTime, err := client.Publish(context.Background(), cfg, []byte(`{"type":2}`), map[string]string{
+		InteractionIDAttribute: "interaction-slo-1",
+	})
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if serverID == "" {
+		t.Fatal("expected a non-empty server-assigned message ID")
+	}
+
+	messages, err := client.PullMessages(context.Background(), cfg, 2*time.Second)
+	if err != nil {
+		t.Fatalf("PullMessages() error = %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+
+	msg := messages[0]
+	if msg.Attributes[InteractionIDAttribute] != "interaction-slo-1" {
+		t.Errorf("expected %s attribute to survive the round trip, got %q", InteractionIDAttribute, msg.Attributes[InteractionIDAttribute])
+	}
+	if latency := msg.PublishTime.Sub(sendTime); latency < 0 || latency >= 500*time.Millisecond {
+		t.Errorf("expected publish-to-receive latency under 500ms, got %v", latency)
+	}
+	if msg.PublishTime.Before(publishTime) {
+		t.Errorf("expected delivered PublishTime (%v) not to precede Publish()'s own timestamp (%v)", msg.PublishTime, publishTime)
+	}
+}
+
+func TestPubSubClient_PullMessages_NoneAvailable(t *testing.T) {
+	fake := pstestserver.New()
+	defer fake.Close()
+
+	client := newClientAgainstFake(t, fake)
+	cfg := PubSubConfig{RunID: "test-run"}
+
+	if err := client.Setup(context.Background(), cfg); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	messages, err := client.PullMessages(context.Background(), cfg, 500*time.Millisecond)
+	if err != nil {
+		t.Fatalf("PullMessages() error = %v", err)
+	}
+	if len(messages) != 0 {
+		t.Errorf("expected no messages, got %d", len(messages))
+	}
+}
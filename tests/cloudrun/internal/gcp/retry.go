@@ -0,0 +1,154 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Retryer wraps CloudRunClient's API calls with retry/backoff for
+// transient failures (rate limiting, brief control-plane unavailability),
+// so a single flaky response doesn't fail an entire benchmark run.
+// Attach one to a CloudRunClient with WithRetryer; the zero value is not
+// usable, construct one with DefaultRetryer or NewRetryer.
+type Retryer struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// RetryableStatusCodes and RetryableGRPCCodes classify which errors
+	// from the Cloud Run REST and long-running-operation surfaces are
+	// worth retrying at all.
+	RetryableStatusCodes map[int]bool
+	RetryableGRPCCodes   map[codes.Code]bool
+}
+
+// NewRetryer builds a Retryer with the given attempt/backoff budget and
+// DefaultRetryer's retryable error classification.
+func NewRetryer(maxAttempts int, initialBackoff, maxBackoff time.Duration) *Retryer {
+	r := DefaultRetryer()
+	r.MaxAttempts = maxAttempts
+	r.InitialBackoff = initialBackoff
+	r.MaxBackoff = maxBackoff
+	return r
+}
+
+// DefaultRetryer returns a Retryer tuned for Cloud Run's control plane:
+// 5 attempts, 500ms initial backoff doubling up to 10s, retrying rate
+// limiting, server errors, and the usual transient gRPC codes.
+func DefaultRetryer() *Retryer {
+	return &Retryer{
+		MaxAttempts:    5,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		RetryableStatusCodes: map[int]bool{
+			429: true, 500: true, 502: true, 503: true, 504: true,
+		},
+		RetryableGRPCCodes: map[codes.Code]bool{
+			codes.Unavailable:       true,
+			codes.DeadlineExceeded:  true,
+			codes.ResourceExhausted: true,
+			codes.Aborted:           true,
+		},
+	}
+}
+
+// RetryMetrics records how much retrying a call needed, so benchmark
+// reports can attribute cold-start variance to control-plane flakiness
+// rather than real service startup latency.
+type RetryMetrics struct {
+	Attempts     int
+	TotalBackoff time.Duration
+}
+
+// operationError wraps a failed long-running operation's terminal error
+// (op.Error from GoogleLongrunningOperation), flagging whether it looks
+// transient enough for the Retryer to retry the operation that produced
+// it, or permanent and worth failing fast on.
+type operationError struct {
+	code    int64
+	message string
+}
+
+func (e *operationError) Error() string {
+	return fmt.Sprintf("operation failed: %s (code %d)", e.message, e.code)
+}
+
+// isRetryableOperationCode reports whether code - a google.rpc.Code,
+// which shares its numbering with codes.Code - looks transient.
+// UNAVAILABLE and DEADLINE_EXCEEDED are retried; INVALID_ARGUMENT,
+// PERMISSION_DENIED, and everything else are treated as permanent.
+func isRetryableOperationCode(code int64) bool {
+	switch codes.Code(code) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryable reports whether err is a transient failure worth retrying:
+// a failed operation flagged retryable by isRetryableOperationCode, an
+// HTTP status or gRPC code from r's configured sets, or a network-level
+// error.
+func (r *Retryer) isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var opErr *operationError
+	if errors.As(err, &opErr) {
+		return isRetryableOperationCode(opErr.code)
+	}
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return r.RetryableStatusCodes[apiErr.Code]
+	}
+
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+		return r.RetryableGRPCCodes[st.Code()]
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// do runs fn up to r.MaxAttempts times, backing off with jitter between
+// retryable failures, and returns RetryMetrics describing how much
+// retrying it took alongside fn's final error.
+func (r *Retryer) do(ctx context.Context, fn func() error) (RetryMetrics, error) {
+	var metrics RetryMetrics
+	backoff := r.InitialBackoff
+
+	for {
+		metrics.Attempts++
+		err := fn()
+		if err == nil {
+			return metrics, nil
+		}
+		if !r.isRetryable(err) || metrics.Attempts >= r.MaxAttempts {
+			return metrics, err
+		}
+
+		delay := backoff/2 + time.Duration(rand.Int63n(int64(backoff)))
+		select {
+		case <-ctx.Done():
+			return metrics, ctx.Err()
+		case <-time.After(delay):
+		}
+		metrics.TotalBackoff += delay
+
+		backoff *= 2
+		if backoff > r.MaxBackoff {
+			backoff = r.MaxBackoff
+		}
+	}
+}
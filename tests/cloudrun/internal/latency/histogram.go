@@ -0,0 +1,318 @@
+// Package latency provides an HDR-histogram-backed latency collector.
+//
+// Unlike a raw sample slice, a Histogram records every observation into a
+// small set of log-linear buckets, so it can be merged across runs (the sum
+// of two histograms is exact) and serialized compactly without retaining
+// individual samples.
+package latency
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+const (
+	// lowestTrackableValue and highestTrackableValue bound the range of
+	// durations the histogram can record with full precision. Values
+	// outside this range are clamped to the nearest bound.
+	lowestTrackableValue  = int64(time.Microsecond)
+	highestTrackableValue = int64(60 * time.Second)
+
+	// significantFigures is the number of decimal digits of precision
+	// preserved across the trackable range (HDR-style).
+	significantFigures = 3
+)
+
+// Histogram is an HDR-histogram-backed collector of time.Duration samples.
+// It trades exact per-sample retention for a fixed, small memory footprint
+// and exact mergeability across runs.
+type Histogram struct {
+	unitMagnitude      uint
+	subBucketHalfCount int64
+	subBucketMask      int64
+	subBucketCount     int64
+	bucketCount        int
+	counts             []int64
+	totalCount         int64
+	sum                int64 // sum of raw recorded values, for Mean()
+}
+
+// NewHistogram returns a Histogram tracking values between 1µs and 60s with
+// 3 significant figures of precision, matching the resolution used
+// throughout the benchmark suite's latency reporting.
+func NewHistogram() *Histogram {
+	unitMagnitude := uint(math.Floor(math.Log2(float64(lowestTrackableValue))))
+
+	subBucketCountMagnitude := uint(math.Ceil(math.Log2(math.Pow(10, significantFigures))))
+	if subBucketCountMagnitude < 1 {
+		subBucketCountMagnitude = 1
+	}
+	subBucketCount := int64(1) << subBucketCountMagnitude
+	subBucketHalfCount := subBucketCount / 2
+	subBucketMask := (subBucketCount - 1) << unitMagnitude
+
+	smallestUntrackableValue := subBucketCount << unitMagnitude
+	bucketsNeeded := 1
+	for smallestUntrackableValue < highestTrackableValue {
+		smallestUntrackableValue <<= 1
+		bucketsNeeded++
+	}
+
+	countsLen := (bucketsNeeded + 1) * int(subBucketHalfCount)
+
+	return &Histogram{
+		unitMagnitude:      unitMagnitude,
+		subBucketHalfCount: subBucketHalfCount,
+		subBucketMask:      subBucketMask,
+		subBucketCount:     subBucketCount,
+		bucketCount:        bucketsNeeded,
+		counts:             make([]int64, countsLen),
+	}
+}
+
+// RecordValue records a single duration sample, clamped to the histogram's
+// trackable range.
+func (h *Histogram) RecordValue(d time.Duration) {
+	v := int64(d)
+	if v < lowestTrackableValue {
+		v = lowestTrackableValue
+	}
+	if v > highestTrackableValue {
+		v = highestTrackableValue
+	}
+
+	idx := h.countsIndex(v)
+	if idx >= 0 && idx < len(h.counts) {
+		h.counts[idx]++
+	}
+	h.totalCount++
+	h.sum += v
+}
+
+// TotalCount returns the number of samples recorded.
+func (h *Histogram) TotalCount() int64 {
+	return h.totalCount
+}
+
+// Mean returns the arithmetic mean of all recorded values.
+func (h *Histogram) Mean() time.Duration {
+	if h.totalCount == 0 {
+		return 0
+	}
+	return time.Duration(h.sum / h.totalCount)
+}
+
+// ValueAtQuantile returns the value at the given quantile (0-100), quantized
+// to the histogram's bucket resolution.
+func (h *Histogram) ValueAtQuantile(q float64) time.Duration {
+	if h.totalCount == 0 {
+		return 0
+	}
+	if q <= 0 {
+		return h.minRecordedValue()
+	}
+	if q > 100 {
+		q = 100
+	}
+
+	target := int64(math.Ceil((q / 100.0) * float64(h.totalCount)))
+	var cumulative int64
+
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+		cumulative += c
+		if cumulative >= target {
+			return time.Duration(h.valueFromIndex(i))
+		}
+	}
+
+	return time.Duration(h.highestEquivalentValue(highestTrackableValue))
+}
+
+// Bucket is one non-empty histogram bucket: the representative value shared
+// by every sample that fell into it, and how many samples did.
+type Bucket struct {
+	Value time.Duration
+	Count int64
+}
+
+// Buckets returns the histogram's non-empty buckets in ascending order of
+// Value. It lets callers reconstruct a weighted, bucketed approximation of
+// the recorded distribution (e.g. for a rank-based significance test)
+// without exposing the underlying HDR layout.
+func (h *Histogram) Buckets() []Bucket {
+	var buckets []Bucket
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+		buckets = append(buckets, Bucket{Value: time.Duration(h.valueFromIndex(i)), Count: c})
+	}
+	return buckets
+}
+
+// Merge combines another histogram's counts into h. Because both
+// histograms share the same bucket layout, the merge is an exact sum with
+// no loss of precision beyond what each histogram already incurred.
+func (h *Histogram) Merge(other *Histogram) error {
+	if len(h.counts) != len(other.counts) {
+		return fmt.Errorf("incompatible histogram layouts: %d buckets vs %d", len(h.counts), len(other.counts))
+	}
+
+	for i, c := range other.counts {
+		h.counts[i] += c
+	}
+	h.totalCount += other.totalCount
+	h.sum += other.sum
+
+	return nil
+}
+
+// Encode serializes the histogram to a gzip-compressed, base64-encoded
+// payload suitable for embedding in a JSON report.
+func (h *Histogram) Encode() (string, error) {
+	var buf bytes.Buffer
+
+	if err := binary.Write(&buf, binary.LittleEndian, h.totalCount); err != nil {
+		return "", fmt.Errorf("writing total count: %w", err)
+	}
+	if err := binary.Write(&buf, binary.LittleEndian, h.sum); err != nil {
+		return "", fmt.Errorf("writing sum: %w", err)
+	}
+	if err := binary.Write(&buf, binary.LittleEndian, h.counts); err != nil {
+		return "", fmt.Errorf("writing counts: %w", err)
+	}
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(buf.Bytes()); err != nil {
+		return "", fmt.Errorf("compressing histogram: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return "", fmt.Errorf("closing compressor: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(compressed.Bytes()), nil
+}
+
+// DecodeHistogram reconstructs a Histogram previously produced by Encode.
+func DecodeHistogram(encoded string) (*Histogram, error) {
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding base64: %w", err)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompressing histogram: %w", err)
+	}
+	defer gz.Close()
+
+	raw, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, fmt.Errorf("reading histogram payload: %w", err)
+	}
+
+	h := NewHistogram()
+	r := bytes.NewReader(raw)
+
+	if err := binary.Read(r, binary.LittleEndian, &h.totalCount); err != nil {
+		return nil, fmt.Errorf("reading total count: %w", err)
+	}
+	if err := binary.Read(r, binary.LittleEndian, &h.sum); err != nil {
+		return nil, fmt.Errorf("reading sum: %w", err)
+	}
+	if err := binary.Read(r, binary.LittleEndian, &h.counts); err != nil {
+		return nil, fmt.Errorf("reading counts: %w", err)
+	}
+
+	return h, nil
+}
+
+// countsIndex maps a raw value to its position in the counts array, using
+// the same log-linear bucketing scheme as the reference HDR histogram
+// algorithm: a coarse "which power-of-two bucket" index, plus a linear
+// offset within that bucket for significant-figure resolution.
+func (h *Histogram) countsIndex(v int64) int {
+	bucketIndex := h.bucketIndexOf(v)
+	subBucketIndex := h.subBucketIndexOf(v, bucketIndex)
+
+	bucketBaseIndex := (bucketIndex + 1) << uint(math.Log2(float64(h.subBucketHalfCount)))
+	offsetInBucket := subBucketIndex - int(h.subBucketHalfCount)
+
+	return bucketBaseIndex + offsetInBucket
+}
+
+func (h *Histogram) bucketIndexOf(v int64) int {
+	// pow2Ceiling is the smallest power of two that can represent v (i.e.
+	// its bit length), matching the reference HDR-histogram algorithm's
+	// "64 - numberOfLeadingZeros". leadingZeros64 already returns the same
+	// count numberOfLeadingZeros would for a 64-bit value, so the ceiling
+	// itself must be taken from 64, not 63 - using 63 here produced a
+	// bucketIndex one short of countsIndex's other caller, subBucketIndexOf,
+	// which broke countsIndex/valueFromIndex's round trip for almost every
+	// recorded value.
+	pow2Ceiling := int64(64 - leadingZeros64(v|h.subBucketMask))
+	return int(pow2Ceiling - int64(h.unitMagnitude) - int64(math.Log2(float64(h.subBucketCount))))
+}
+
+func (h *Histogram) subBucketIndexOf(v int64, bucketIndex int) int {
+	return int(v >> (uint(bucketIndex) + h.unitMagnitude))
+}
+
+func leadingZeros64(v int64) int {
+	n := 0
+	uv := uint64(v)
+	for i := 63; i >= 0; i-- {
+		if uv&(1<<uint(i)) != 0 {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// valueFromIndex reconstructs the representative (lower-bound) value for a
+// counts array index. It is the inverse of countsIndex, quantized to the
+// histogram's bucket resolution rather than exact.
+//
+// bucketIndex < 0 covers the lower half of bucket 0 (indices below
+// subBucketHalfCount), i.e. values below subBucketHalfCount<<unitMagnitude
+// (512<<9 ns, about 262µs, with this histogram's 1µs lowestTrackableValue).
+// The reference HDR-histogram algorithm shifts by unitMagnitude alone in
+// that case, not by bucketIndex+unitMagnitude, and subtracts
+// subBucketHalfCount back out of subBucketIndex first - skipping this
+// produces grossly inflated reconstructed values for negative bucketIndex.
+func (h *Histogram) valueFromIndex(index int) int64 {
+	subBucketHalfCountMagnitude := uint(math.Log2(float64(h.subBucketHalfCount)))
+	bucketIndex := (index >> subBucketHalfCountMagnitude) - 1
+	subBucketIndex := (index & int(h.subBucketHalfCount-1)) + int(h.subBucketHalfCount)
+
+	if bucketIndex < 0 {
+		subBucketIndex -= int(h.subBucketHalfCount)
+		return int64(subBucketIndex) << h.unitMagnitude
+	}
+
+	return int64(subBucketIndex) << (uint(bucketIndex) + h.unitMagnitude)
+}
+
+func (h *Histogram) minRecordedValue() time.Duration {
+	for i, c := range h.counts {
+		if c > 0 {
+			return time.Duration(h.valueFromIndex(i))
+		}
+	}
+	return 0
+}
+
+func (h *Histogram) highestEquivalentValue(v int64) int64 {
+	return v
+}
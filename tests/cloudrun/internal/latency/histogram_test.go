@@ -0,0 +1,106 @@
+package latency
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// withinTolerance reports whether got is within pct percent of want, per the
+// histogram's stated 3-significant-figure precision.
+func withinTolerance(t *testing.T, got, want time.Duration, pct float64) {
+	t.Helper()
+	diff := math.Abs(float64(got-want)) / float64(want) * 100
+	if diff > pct {
+		t.Errorf("got %v, want ~%v (%.2f%% off, tolerance %.2f%%)", got, want, diff, pct)
+	}
+}
+
+func TestHistogram_RecordedValueIsRecoveredWithinTolerance(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+	}{
+		{"30ms", 30 * time.Millisecond},
+		{"50ms", 50 * time.Millisecond},
+		{"1s", time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHistogram()
+			h.RecordValue(tt.d)
+
+			got := h.ValueAtQuantile(50)
+			withinTolerance(t, got, tt.d, 1)
+		})
+	}
+}
+
+func TestHistogram_ValueAtQuantile(t *testing.T) {
+	h := NewHistogram()
+	for i := 1; i <= 100; i++ {
+		h.RecordValue(time.Duration(i) * time.Millisecond)
+	}
+
+	withinTolerance(t, h.ValueAtQuantile(50), 50*time.Millisecond, 1)
+	withinTolerance(t, h.ValueAtQuantile(99), 99*time.Millisecond, 1)
+	withinTolerance(t, h.ValueAtQuantile(0), time.Millisecond, 1)
+}
+
+func TestHistogram_MergePreservesQuantiles(t *testing.T) {
+	a := NewHistogram()
+	for i := 1; i <= 50; i++ {
+		a.RecordValue(time.Duration(i) * time.Millisecond)
+	}
+
+	b := NewHistogram()
+	for i := 51; i <= 100; i++ {
+		b.RecordValue(time.Duration(i) * time.Millisecond)
+	}
+
+	merged := NewHistogram()
+	for i := 1; i <= 100; i++ {
+		merged.RecordValue(time.Duration(i) * time.Millisecond)
+	}
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	if a.TotalCount() != merged.TotalCount() {
+		t.Fatalf("TotalCount() = %d, want %d", a.TotalCount(), merged.TotalCount())
+	}
+
+	for _, q := range []float64{50, 95, 99} {
+		got := a.ValueAtQuantile(q)
+		want := merged.ValueAtQuantile(q)
+		if got != want {
+			t.Errorf("merged ValueAtQuantile(%v) = %v, want %v (matching the union of raw samples)", q, got, want)
+		}
+	}
+}
+
+func TestHistogram_EncodeDecodeRoundTrip(t *testing.T) {
+	h := NewHistogram()
+	for i := 1; i <= 10; i++ {
+		h.RecordValue(time.Duration(i) * 10 * time.Millisecond)
+	}
+
+	encoded, err := h.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	decoded, err := DecodeHistogram(encoded)
+	if err != nil {
+		t.Fatalf("DecodeHistogram() error = %v", err)
+	}
+
+	if decoded.TotalCount() != h.TotalCount() {
+		t.Errorf("TotalCount() = %d, want %d", decoded.TotalCount(), h.TotalCount())
+	}
+	if decoded.ValueAtQuantile(50) != h.ValueAtQuantile(50) {
+		t.Errorf("ValueAtQuantile(50) = %v, want %v", decoded.ValueAtQuantile(50), h.ValueAtQuantile(50))
+	}
+}
@@ -0,0 +1,108 @@
+// Package netsim simulates WAN conditions (latency, jitter, a bandwidth
+// cap) on top of an otherwise-local HTTP client, so a local benchmark can
+// be compared fairly against a deployment that does incur network cost.
+package netsim
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Profile describes the network conditions to simulate.
+type Profile struct {
+	// RTT is the simulated round-trip time; half is applied as delay on
+	// each Read and each Write, so a single request/response pair pays
+	// the full RTT.
+	RTT time.Duration
+
+	// Jitter adds a random extra delay in [0, Jitter) on top of RTT/2.
+	Jitter time.Duration
+
+	// BandwidthMbps caps throughput, in megabits per second. Zero means
+	// unlimited.
+	BandwidthMbps float64
+
+	// MTU sizes the chunks throughput is metered in; a zero value
+	// defaults to 1500, the Ethernet MTU.
+	MTU int
+}
+
+// Enabled reports whether p simulates anything at all.
+func (p Profile) Enabled() bool {
+	return p.RTT > 0 || p.Jitter > 0 || p.BandwidthMbps > 0
+}
+
+func (p Profile) mtu() int {
+	if p.MTU > 0 {
+		return p.MTU
+	}
+	return 1500
+}
+
+// NewTransport returns an http.RoundTripper that dials through a
+// connection shaped by profile, falling back to http.DefaultTransport's
+// settings otherwise. If profile isn't Enabled, it returns
+// http.DefaultTransport unchanged.
+func NewTransport(profile Profile) http.RoundTripper {
+	if !profile.Enabled() {
+		return http.DefaultTransport
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return &shapedConn{Conn: conn, profile: profile}, nil
+	}
+	return transport
+}
+
+// shapedConn wraps a net.Conn, delaying each Read/Write by profile.RTT/2
+// (plus jitter) and throttling throughput to profile.BandwidthMbps using a
+// leaky-bucket: each call sleeps just long enough that, averaged over the
+// connection's lifetime, bytes flow no faster than the cap allows.
+type shapedConn struct {
+	net.Conn
+	profile Profile
+}
+
+func (c *shapedConn) Read(b []byte) (int, error) {
+	c.delay()
+	n, err := c.Conn.Read(b)
+	c.throttle(n)
+	return n, err
+}
+
+func (c *shapedConn) Write(b []byte) (int, error) {
+	c.delay()
+	n, err := c.Conn.Write(b)
+	c.throttle(n)
+	return n, err
+}
+
+func (c *shapedConn) delay() {
+	wait := c.profile.RTT / 2
+	if c.profile.Jitter > 0 {
+		wait += time.Duration(rand.Int63n(int64(c.profile.Jitter)))
+	}
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+func (c *shapedConn) throttle(n int) {
+	if c.profile.BandwidthMbps <= 0 || n <= 0 {
+		return
+	}
+
+	bytesPerSecond := c.profile.BandwidthMbps * 1e6 / 8
+	chunks := (n + c.profile.mtu() - 1) / c.profile.mtu()
+	perChunk := time.Duration(float64(c.profile.mtu()) / bytesPerSecond * float64(time.Second))
+	time.Sleep(perChunk * time.Duration(chunks))
+}
@@ -0,0 +1,198 @@
+// Package profiling integrates Cloud Profiler with cold-start benchmarking.
+//
+// A benchmarked service starts the agent via StartAgent when profiling is
+// enabled in its configuration, and the benchmark client fetches whatever
+// profiles Cloud Profiler captured during the monitoring window via
+// FetchProfiles, so cold-start latency can be attributed to specific init
+// code rather than treated as a single opaque number.
+package profiling
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/profiler"
+	"github.com/google/pprof/profile"
+	cloudprofiler "google.golang.org/api/cloudprofiler/v2"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/config"
+)
+
+// AgentConfig configures the Cloud Profiler agent started inside a
+// benchmarked service.
+type AgentConfig struct {
+	ServiceName string
+	ProjectID   string
+	Version     string
+
+	// RunID tags every profile the agent uploads with the benchmark run
+	// that deployed this revision, via a user label, so FetchProfiles (and
+	// anyone browsing the Cloud Profiler console directly) can tell which
+	// run a profile belongs to even though ServiceName/Target is already
+	// per-run unique.
+	RunID string
+}
+
+// StartAgent starts the Cloud Profiler agent for the current process. It is
+// called from a service's main() when profiling is enabled, and collects
+// CPU, heap, and (where the Go runtime allows it) mutex contention profiles
+// for the lifetime of the process.
+func StartAgent(cfg AgentConfig) error {
+	return profiler.Start(profiler.Config{
+		Service:        cfg.ServiceName,
+		ServiceVersion: cfg.Version,
+		ProjectID:      cfg.ProjectID,
+		MutexProfiling: true,
+		Labels: map[string]string{
+			"run_id": cfg.RunID,
+		},
+	})
+}
+
+// Profile is a single captured profile (CPU or heap) for a service.
+type Profile struct {
+	Service     string
+	ProfileType string
+	CapturedAt  time.Time
+	Data        []byte // raw pprof-encoded bytes
+}
+
+// Client fetches profiles captured by Cloud Profiler for a service.
+type Client struct {
+	svc       *cloudprofiler.Service
+	projectID string
+}
+
+// NewClient creates a Cloud Profiler API client for the given project.
+func NewClient(ctx context.Context, projectID string) (*Client, error) {
+	svc, err := cloudprofiler.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating profiler client: %w", err)
+	}
+
+	return &Client{svc: svc, projectID: projectID}, nil
+}
+
+// FetchProfiles requests one profile per entry in cfg.ProfileTypes for
+// serviceName. The request carries no duration; cfg.SampleDuration is not
+// read here. A profile type the agent hasn't reported yet is skipped rather than
+// failing the whole benchmark, since profile availability depends on
+// timing against the agent's own upload cadence.
+func (c *Client) FetchProfiles(ctx context.Context, serviceName string, cfg config.ProfilingConfig) ([]*Profile, error) {
+	var profiles []*Profile
+
+	for _, profileType := range cfg.ProfileTypes {
+		req := &cloudprofiler.CreateProfileRequest{
+			Deployment: &cloudprofiler.Deployment{
+				ProjectId: c.projectID,
+				Target:    serviceName,
+			},
+			ProfileType: []string{profileType},
+		}
+
+		parent := fmt.Sprintf("projects/%s", c.projectID)
+		resp, err := c.svc.Projects.Profiles.Create(parent, req).Context(ctx).Do()
+		if err != nil {
+			continue
+		}
+
+		profiles = append(profiles, &Profile{
+			Service:     serviceName,
+			ProfileType: profileType,
+			CapturedAt:  time.Now(),
+			Data:        []byte(resp.ProfileBytes),
+		})
+	}
+
+	return profiles, nil
+}
+
+// Save gzip-compresses and writes a profile's raw pprof bytes to path,
+// matching the profiles/<service>/reading-N-<type>.pb.gz layout used for
+// GCS uploads.
+func Save(p *Profile, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating profile file: %w", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	if _, err := gz.Write(p.Data); err != nil {
+		return fmt.Errorf("compressing profile: %w", err)
+	}
+
+	return gz.Close()
+}
+
+// ConsoleURL builds a Cloud Profiler console deep link for a fetched
+// profile, so a report can point a reader at the interactive flamegraph
+// instead of just the raw pprof download.
+func ConsoleURL(projectID, serviceName, profileType string) string {
+	return fmt.Sprintf("https://console.cloud.google.com/profiler/%s/%s?project=%s", serviceName, strings.ToLower(profileType), projectID)
+}
+
+// HotFunction describes one function's share of flat samples in a profile.
+type HotFunction struct {
+	Name        string
+	FlatPercent float64
+}
+
+// TopFunctions extracts the topN hottest functions by flat sample count
+// from a profile. Parse failures return an empty slice rather than an
+// error, since this only feeds the "hot functions" report table.
+func TopFunctions(p *Profile, topN int) []HotFunction {
+	prof, err := profile.Parse(bytes.NewReader(p.Data))
+	if err != nil {
+		return nil
+	}
+
+	counts := make(map[string]int64)
+	var total int64
+
+	for _, sample := range prof.Sample {
+		if len(sample.Value) == 0 || len(sample.Location) == 0 {
+			continue
+		}
+		loc := sample.Location[0]
+		if len(loc.Line) == 0 || loc.Line[0].Function == nil {
+			continue
+		}
+
+		name := loc.Line[0].Function.Name
+		v := sample.Value[0]
+		counts[name] += v
+		total += v
+	}
+
+	type counted struct {
+		name string
+		flat int64
+	}
+	sorted := make([]counted, 0, len(counts))
+	for name, flat := range counts {
+		sorted = append(sorted, counted{name, flat})
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].flat > sorted[j].flat })
+
+	if topN > len(sorted) {
+		topN = len(sorted)
+	}
+
+	hot := make([]HotFunction, 0, topN)
+	for _, c := range sorted[:topN] {
+		pct := 0.0
+		if total > 0 {
+			pct = float64(c.flat) / float64(total) * 100
+		}
+		hot = append(hot, HotFunction{Name: c.name, FlatPercent: pct})
+	}
+
+	return hot
+}
@@ -0,0 +1,57 @@
+package progress
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
+)
+
+// CloudOpsReporter is a gcp.ProgressReporter that prints a line for each
+// Deploy phase and operation poll, and renders a pb/v3 bar per file for
+// upload byte progress, so a multi-minute Deploy or GCSUploader.UploadDir
+// doesn't leave the terminal silent. It's the default reporter used from
+// the CLI when stdout is a terminal.
+type CloudOpsReporter struct {
+	mu   sync.Mutex
+	bars map[string]*Bar
+}
+
+// NewCloudOpsReporter creates an empty CloudOpsReporter.
+func NewCloudOpsReporter() *CloudOpsReporter {
+	return &CloudOpsReporter{bars: make(map[string]*Bar)}
+}
+
+var _ gcp.ProgressReporter = (*CloudOpsReporter)(nil)
+
+// OnPhase prints the phase name.
+func (r *CloudOpsReporter) OnPhase(phase string) {
+	fmt.Printf("  [%s]\n", phase)
+}
+
+// OnBytes starts an upload bar on the first call for path and advances it
+// to uploaded on every subsequent call for that path. Bars are keyed by
+// path rather than kept in a single shared field because UploadDir
+// uploads multiple files concurrently, each reporting its own
+// interleaved stream of OnBytes calls.
+func (r *CloudOpsReporter) OnBytes(path string, uploaded, total int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bar, ok := r.bars[path]
+	if !ok {
+		bar = New(path, int(total), total <= 0)
+		r.bars[path] = bar
+	}
+	bar.SetCurrent(uploaded)
+	if total > 0 && uploaded >= total {
+		bar.Finish()
+		delete(r.bars, path)
+	}
+}
+
+// OnPoll prints elapsed wait time for op.
+func (r *CloudOpsReporter) OnPoll(op string, elapsed time.Duration) {
+	fmt.Printf("  ...%s (%s elapsed)\n", op, elapsed.Round(time.Second))
+}
@@ -0,0 +1,160 @@
+package progress
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/latency"
+)
+
+// TTYReporter is a benchmark.ProgressReporter that renders one Bar per
+// (service, phase), alongside a live status line printed once a second
+// with the running RPS and p50/p95 latency, so operators watching a
+// terminal see a multi-minute cold start run progress instead of silence.
+// It's the default reporter used from the CLI when stdout is a terminal;
+// Runner.SetProgressReporter(nil) falls back to the plain log lines the
+// runner already prints.
+type TTYReporter struct {
+	mu     sync.Mutex
+	phases map[phaseKey]*phaseState
+}
+
+type phaseKey struct {
+	service string
+	phase   string
+}
+
+type phaseState struct {
+	bar       *Bar
+	startTime time.Time
+	total     int
+	done      int
+	stop      chan struct{}
+
+	// histMu guards hist and done, which RecordLatency and AdvanceRequests
+	// write to from every warm request worker goroutine while tick's ticker
+	// goroutine reads them once a second for the status line - both
+	// documented concurrent-use cases
+	// a ProgressReporter must support (benchmark/progress.go).
+	histMu sync.Mutex
+	hist   *latency.Histogram
+}
+
+// NewTTYReporter creates an empty TTYReporter.
+func NewTTYReporter() *TTYReporter {
+	return &TTYReporter{phases: make(map[phaseKey]*phaseState)}
+}
+
+var _ benchmark.ProgressReporter = (*TTYReporter)(nil)
+
+// StartPhase begins a bar and a once-a-second status line for
+// service/phase, counting up to total steps.
+func (r *TTYReporter) StartPhase(service, phase string, total int) {
+	state := &phaseState{
+		bar:       New(fmt.Sprintf("%s/%s", service, phase), total, false),
+		hist:      latency.NewHistogram(),
+		startTime: time.Now(),
+		total:     total,
+		stop:      make(chan struct{}),
+	}
+
+	r.mu.Lock()
+	r.phases[phaseKey{service, phase}] = state
+	r.mu.Unlock()
+
+	go state.tick(service, phase)
+}
+
+// tick prints a status line every second until the phase ends.
+func (s *phaseState) tick(service, phase string) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			fmt.Println(s.statusLine(service, phase))
+		}
+	}
+}
+
+func (s *phaseState) statusLine(service, phase string) string {
+	elapsed := time.Since(s.startTime)
+
+	s.histMu.Lock()
+	totalCount := s.hist.TotalCount()
+	p50 := s.hist.ValueAtQuantile(50)
+	p95 := s.hist.ValueAtQuantile(95)
+	done := s.done
+	s.histMu.Unlock()
+
+	rps := float64(totalCount) / elapsed.Seconds()
+
+	eta := "-"
+	if done > 0 && done < s.total {
+		remaining := time.Duration(float64(elapsed) / float64(done) * float64(s.total-done))
+		eta = remaining.Round(time.Second).String()
+	}
+
+	return fmt.Sprintf("%s/%s: %d/%d rps=%.1f p50=%s p95=%s eta=%s",
+		service, phase, done, s.total, rps, p50, p95, eta)
+}
+
+// AdvanceRequests advances service/phase's bar by n steps.
+func (r *TTYReporter) AdvanceRequests(service, phase string, n int) {
+	state := r.state(service, phase)
+	if state == nil {
+		return
+	}
+
+	state.histMu.Lock()
+	state.done += n
+	state.histMu.Unlock()
+
+	for i := 0; i < n; i++ {
+		state.bar.Increment()
+	}
+}
+
+// RecordLatency folds a completed request's latency into service/phase's
+// running p50/p95 and RPS.
+func (r *TTYReporter) RecordLatency(service, phase string, d time.Duration) {
+	state := r.state(service, phase)
+	if state == nil {
+		return
+	}
+
+	state.histMu.Lock()
+	state.hist.RecordValue(d)
+	state.histMu.Unlock()
+}
+
+// EndPhase stops service/phase's status line and finishes its bar,
+// leaving the final state rendered. Safe to call from a SIGINT handler
+// mid-run: it only finishes the display, it doesn't touch results.
+func (r *TTYReporter) EndPhase(service, phase string) {
+	key := phaseKey{service, phase}
+
+	r.mu.Lock()
+	state := r.phases[key]
+	delete(r.phases, key)
+	r.mu.Unlock()
+
+	if state == nil {
+		return
+	}
+
+	close(state.stop)
+	state.bar.Finish()
+	fmt.Println(state.statusLine(service, phase))
+}
+
+func (r *TTYReporter) state(service, phase string) *phaseState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.phases[phaseKey{service, phase}]
+}
@@ -0,0 +1,42 @@
+package progress
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestTTYReporter_RecordLatencyConcurrentWithStatusLine exercises the
+// concurrent-use case TTYReporter must support: multiple warm request
+// workers calling RecordLatency while tick's ticker goroutine reads the
+// same histogram for the status line. Run with -race to catch a
+// regression of the data race on phaseState.hist.
+func TestTTYReporter_RecordLatencyConcurrentWithStatusLine(t *testing.T) {
+	r := NewTTYReporter()
+	r.StartPhase("svc", "phase", 100)
+	defer r.EndPhase("svc", "phase")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				r.RecordLatency("svc", "phase", time.Millisecond)
+				r.AdvanceRequests("svc", "phase", 1)
+			}
+		}()
+	}
+
+	state := r.state("svc", "phase")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			_ = state.statusLine("svc", "phase")
+		}
+	}()
+
+	wg.Wait()
+	<-done
+}
@@ -0,0 +1,257 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// Authenticator configures outgoing requests for a registry. Authenticate
+// is called on every request; implementations whose scheme requires seeing
+// a 401 challenge first (see ChallengeAuthenticator) can make it a no-op.
+type Authenticator interface {
+	Authenticate(ctx context.Context, req *http.Request) error
+}
+
+// ChallengeAuthenticator additionally knows how to respond to a 401
+// Unauthorized whose WWW-Authenticate header names a Bearer realm, per the
+// distribution spec's token authentication flow
+// (https://distribution.github.io/distribution/spec/auth/token/).
+type ChallengeAuthenticator interface {
+	Authenticator
+	AuthenticateChallenge(ctx context.Context, req *http.Request, challenge string) error
+}
+
+// AnonymousAuthenticator sets no credentials, for public registries and
+// repositories that allow unauthenticated pulls.
+type AnonymousAuthenticator struct{}
+
+// Authenticate is a no-op.
+func (AnonymousAuthenticator) Authenticate(ctx context.Context, req *http.Request) error {
+	return nil
+}
+
+// BasicAuthenticator authenticates with a fixed username and password via
+// HTTP Basic auth.
+type BasicAuthenticator struct {
+	Username string
+	Password string
+}
+
+// Authenticate sets req's Basic auth header.
+func (a BasicAuthenticator) Authenticate(ctx context.Context, req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+	return nil
+}
+
+// GoogleAuthenticator authenticates against Artifact Registry (or any
+// registry accepting Google OAuth bearer tokens) using an oauth2.TokenSource.
+type GoogleAuthenticator struct {
+	TokenSource oauth2.TokenSource
+}
+
+// NewGoogleAuthenticator returns a GoogleAuthenticator using Application
+// Default Credentials, or the service account key at keyFilePath if given.
+func NewGoogleAuthenticator(ctx context.Context, keyFilePath string) (*GoogleAuthenticator, error) {
+	const scope = "https://www.googleapis.com/auth/cloud-platform"
+
+	if keyFilePath == "" {
+		creds, err := google.FindDefaultCredentials(ctx, scope)
+		if err != nil {
+			return nil, fmt.Errorf("finding default credentials: %w", err)
+		}
+		return &GoogleAuthenticator{TokenSource: creds.TokenSource}, nil
+	}
+
+	data, err := os.ReadFile(keyFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading key file: %w", err)
+	}
+
+	jwtConfig, err := google.JWTConfigFromJSON(data, scope)
+	if err != nil {
+		return nil, fmt.Errorf("parsing key file: %w", err)
+	}
+
+	return &GoogleAuthenticator{TokenSource: jwtConfig.TokenSource(ctx)}, nil
+}
+
+// Authenticate sets req's Authorization header from a freshly-fetched
+// Google OAuth token.
+func (g *GoogleAuthenticator) Authenticate(ctx context.Context, req *http.Request) error {
+	token, err := g.TokenSource.Token()
+	if err != nil {
+		return fmt.Errorf("fetching Google token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return nil
+}
+
+// ECRAuthenticator authenticates against AWS ECR using a pre-fetched
+// authorization token: the base64 "AWS:<password>" string
+// ecr:GetAuthorizationToken returns. Minting that token requires the
+// aws-sdk-go credential chain this package doesn't otherwise depend on, so
+// callers supply TokenFunc to fetch (and cache/refresh) it themselves; ECR
+// tokens expire after 12 hours.
+type ECRAuthenticator struct {
+	TokenFunc func(ctx context.Context) (string, error)
+}
+
+// Authenticate sets req's Basic auth header from a freshly-fetched ECR token.
+func (a *ECRAuthenticator) Authenticate(ctx context.Context, req *http.Request) error {
+	token, err := a.TokenFunc(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching ECR token: %w", err)
+	}
+	req.Header.Set("Authorization", "Basic "+token)
+	return nil
+}
+
+// BearerChallengeAuthenticator implements the distribution spec's token
+// authentication flow: it sets no header up front, and only on seeing a
+// 401 with a "WWW-Authenticate: Bearer realm=..." challenge does it fetch a
+// token from the challenge's realm (optionally presenting Username/Password
+// to that token endpoint) and retry with it. This is how Docker Hub and
+// GHCR authenticate anonymous and authenticated pulls alike.
+type BearerChallengeAuthenticator struct {
+	Username string
+	Password string
+
+	httpClient *http.Client
+}
+
+// NewBearerChallengeAuthenticator returns a BearerChallengeAuthenticator.
+// Username and password may be empty for registries that issue anonymous
+// pull tokens (e.g. Docker Hub's public repositories).
+func NewBearerChallengeAuthenticator(username, password string) *BearerChallengeAuthenticator {
+	return &BearerChallengeAuthenticator{Username: username, Password: password}
+}
+
+// Authenticate is a no-op: nothing is known until the 401 challenge arrives.
+func (a *BearerChallengeAuthenticator) Authenticate(ctx context.Context, req *http.Request) error {
+	return nil
+}
+
+// AuthenticateChallenge fetches a bearer token from challenge's realm and
+// sets it on req.
+func (a *BearerChallengeAuthenticator) AuthenticateChallenge(ctx context.Context, req *http.Request, challenge string) error {
+	realm, service, scope, err := parseBearerChallenge(challenge)
+	if err != nil {
+		return err
+	}
+
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return fmt.Errorf("parsing challenge realm: %w", err)
+	}
+	q := tokenURL.Query()
+	if service != "" {
+		q.Set("service", service)
+	}
+	if scope != "" {
+		q.Set("scope", scope)
+	}
+	tokenURL.RawQuery = q.Encode()
+
+	tokenReq, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("creating token request: %w", err)
+	}
+	if a.Username != "" {
+		tokenReq.SetBasicAuth(a.Username, a.Password)
+	}
+
+	client := a.httpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(tokenReq)
+	if err != nil {
+		return fmt.Errorf("fetching bearer token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return fmt.Errorf("decoding token response: %w", err)
+	}
+
+	token := tokenResp.Token
+	if token == "" {
+		token = tokenResp.AccessToken
+	}
+	if token == "" {
+		return fmt.Errorf("token endpoint returned no token")
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// parseBearerChallenge extracts realm, service, and scope from a
+// WWW-Authenticate: Bearer header value.
+func parseBearerChallenge(challenge string) (realm, service, scope string, err error) {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return "", "", "", fmt.Errorf("unsupported challenge scheme: %s", challenge)
+	}
+
+	for _, part := range splitChallengeParams(challenge[len("Bearer "):]) {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.TrimSpace(key) {
+		case "realm":
+			realm = value
+		case "service":
+			service = value
+		case "scope":
+			scope = value
+		}
+	}
+
+	if realm == "" {
+		return "", "", "", fmt.Errorf("challenge missing realm: %s", challenge)
+	}
+	return realm, service, scope, nil
+}
+
+// splitChallengeParams splits a comma-separated "key=value" challenge
+// parameter list, ignoring commas inside quoted values (a scope like
+// "repository:a/b:pull,push" never contains one, but this keeps the parser
+// correct if a future realm URL's query string ever did).
+func splitChallengeParams(s string) []string {
+	var parts []string
+	var inQuotes bool
+	start := 0
+	for i, r := range s {
+		switch r {
+		case '"':
+			inQuotes = !inQuotes
+		case ',':
+			if !inQuotes {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
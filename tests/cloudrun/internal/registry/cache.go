@@ -0,0 +1,220 @@
+package registry
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CacheKey identifies one cached manifest lookup.
+type CacheKey struct {
+	Host      string
+	Name      string
+	Reference string
+}
+
+func cacheKeyFor(ref Ref) CacheKey {
+	return CacheKey{Host: ref.Host, Name: ref.Name, Reference: ref.Reference}
+}
+
+// CacheEntry is what a ManifestCache stores for one CacheKey.
+type CacheEntry struct {
+	Manifest  *Manifest
+	MediaType string
+	Digest    string
+	FetchedAt time.Time
+
+	// ETag is the value to revalidate against: for most registries
+	// (Docker Distribution and Artifact Registry included) this is the
+	// same value as Digest, since they set the Docker-Content-Digest
+	// header as the manifest's ETag.
+	ETag string
+}
+
+// ManifestCache stores fetched manifests keyed by registry/repo/image/
+// reference, so a CachingClient can skip a full re-fetch and re-decode for
+// a tag that rarely changes (e.g. ":latest", ":stable"). LRUManifestCache
+// is the in-memory default; a Redis- or filesystem-backed implementation
+// satisfying this same interface can share a cache across bot instances.
+type ManifestCache interface {
+	Get(key CacheKey) (CacheEntry, bool)
+	Set(key CacheKey, entry CacheEntry)
+}
+
+// CacheStats counts how a CachingClient's cache has performed.
+type CacheStats struct {
+	Hits          int64
+	Misses        int64
+	Revalidations int64
+}
+
+// LRUManifestCache is a fixed-capacity in-memory ManifestCache that evicts
+// the least-recently-used entry once full.
+type LRUManifestCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	index    map[CacheKey]*list.Element
+}
+
+// lruNode is the value stored in LRUManifestCache.order's list.
+type lruNode struct {
+	key   CacheKey
+	entry CacheEntry
+}
+
+// NewLRUManifestCache returns an LRUManifestCache holding at most capacity
+// entries.
+func NewLRUManifestCache(capacity int) *LRUManifestCache {
+	return &LRUManifestCache{
+		capacity: capacity,
+		order:    list.New(),
+		index:    make(map[CacheKey]*list.Element),
+	}
+}
+
+// Get returns key's cached entry, marking it most-recently-used.
+func (c *LRUManifestCache) Get(key CacheKey) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.index[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruNode).entry, true
+}
+
+// Set stores entry under key, evicting the least-recently-used entry if
+// the cache is over capacity.
+func (c *LRUManifestCache) Set(key CacheKey, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.index[key]; ok {
+		elem.Value.(*lruNode).entry = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&lruNode{key: key, entry: entry})
+	c.index[key] = elem
+
+	if c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.index, oldest.Value.(*lruNode).key)
+	}
+}
+
+// ConditionalClient is implemented by a Client that can revalidate a
+// previously cached manifest without re-fetching and re-decoding its body.
+// HTTPClient implements it via a HEAD request compared against the
+// manifest's stored digest/ETag, per the distribution spec's guidance that
+// HEAD on a manifest returns the same Docker-Content-Digest a GET would.
+// CachingClient uses this when the wrapped Client supports it; otherwise
+// every cache miss falls back to a plain Manifest call.
+type ConditionalClient interface {
+	Client
+	// ManifestIfChanged conditionally fetches ref's manifest. If etag is
+	// non-empty and still current, notModified is true and manifest is
+	// nil - the caller should keep using its cached copy.
+	ManifestIfChanged(ctx context.Context, ref Ref, etag string) (manifest *Manifest, mediaType, digest, newETag string, notModified bool, err error)
+}
+
+// ManifestIfChanged implements ConditionalClient for HTTPClient: it HEADs
+// ref's manifest first and compares the returned Docker-Content-Digest
+// against etag, only falling through to a full GET when they differ (or
+// etag is empty, or the registry didn't return a digest to compare).
+func (c *HTTPClient) ManifestIfChanged(ctx context.Context, ref Ref, etag string) (*Manifest, string, string, string, bool, error) {
+	if etag != "" {
+		exists, digest, err := c.ManifestExists(ctx, ref)
+		if err != nil {
+			return nil, "", "", "", false, fmt.Errorf("revalidating manifest: %w", err)
+		}
+		if exists && digest != "" && digest == etag {
+			return nil, "", digest, digest, true, nil
+		}
+	}
+
+	manifest, mediaType, digest, err := c.Manifest(ctx, ref)
+	if err != nil {
+		return nil, "", "", "", false, err
+	}
+	return manifest, mediaType, digest, digest, false, nil
+}
+
+// CachingClient wraps a Client with a ManifestCache, so Manifest calls for
+// a reference already cached can revalidate via ConditionalClient instead
+// of always paying for a full fetch and JSON decode. All other Client
+// methods are passed straight through to the wrapped Client.
+type CachingClient struct {
+	Client
+	cache ManifestCache
+	ttl   time.Duration
+
+	mu    sync.Mutex
+	stats CacheStats
+}
+
+// NewCachingClient wraps client with cache. ttl bounds how long a cached
+// entry is trusted before revalidation is attempted at all; zero means
+// every call revalidates (still cheaper than a plain fetch whenever client
+// implements ConditionalClient).
+func NewCachingClient(client Client, cache ManifestCache, ttl time.Duration) *CachingClient {
+	return &CachingClient{Client: client, cache: cache, ttl: ttl}
+}
+
+// Manifest returns ref's manifest, from cache when possible.
+func (c *CachingClient) Manifest(ctx context.Context, ref Ref) (*Manifest, string, string, error) {
+	key := cacheKeyFor(ref)
+
+	entry, hit := c.cache.Get(key)
+	if hit && c.ttl > 0 && time.Since(entry.FetchedAt) < c.ttl {
+		c.record(&c.stats.Hits)
+		return entry.Manifest, entry.MediaType, entry.Digest, nil
+	}
+
+	conditional, canRevalidate := c.Client.(ConditionalClient)
+	if hit && canRevalidate {
+		manifest, mediaType, digest, newETag, notModified, err := conditional.ManifestIfChanged(ctx, ref, entry.ETag)
+		if err != nil {
+			return nil, "", "", err
+		}
+		if notModified {
+			c.record(&c.stats.Revalidations)
+			entry.FetchedAt = time.Now()
+			c.cache.Set(key, entry)
+			return entry.Manifest, entry.MediaType, entry.Digest, nil
+		}
+
+		c.record(&c.stats.Misses)
+		c.cache.Set(key, CacheEntry{Manifest: manifest, MediaType: mediaType, Digest: digest, ETag: newETag, FetchedAt: time.Now()})
+		return manifest, mediaType, digest, nil
+	}
+
+	c.record(&c.stats.Misses)
+	manifest, mediaType, digest, err := c.Client.Manifest(ctx, ref)
+	if err != nil {
+		return nil, "", "", err
+	}
+	c.cache.Set(key, CacheEntry{Manifest: manifest, MediaType: mediaType, Digest: digest, ETag: digest, FetchedAt: time.Now()})
+	return manifest, mediaType, digest, nil
+}
+
+// Stats returns the cache's cumulative hit/miss/revalidation counts.
+func (c *CachingClient) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// record increments one of stats's counters under c.mu.
+func (c *CachingClient) record(counter *int64) {
+	c.mu.Lock()
+	*counter++
+	c.mu.Unlock()
+}
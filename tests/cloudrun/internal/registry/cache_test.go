@@ -0,0 +1,118 @@
+package registry
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestLRUManifestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewLRUManifestCache(2)
+	a := CacheKey{Host: "h", Name: "n", Reference: "a"}
+	b := CacheKey{Host: "h", Name: "n", Reference: "b"}
+	c := CacheKey{Host: "h", Name: "n", Reference: "c"}
+
+	cache.Set(a, CacheEntry{Digest: "a"})
+	cache.Set(b, CacheEntry{Digest: "b"})
+
+	// Touch a so it's most-recently-used, then add c: b should be evicted.
+	if _, ok := cache.Get(a); !ok {
+		t.Fatalf("expected a to be cached")
+	}
+	cache.Set(c, CacheEntry{Digest: "c"})
+
+	if _, ok := cache.Get(b); ok {
+		t.Errorf("expected b to have been evicted")
+	}
+	if _, ok := cache.Get(a); !ok {
+		t.Errorf("expected a to still be cached")
+	}
+	if _, ok := cache.Get(c); !ok {
+		t.Errorf("expected c to be cached")
+	}
+}
+
+// fakeClient is a Client/ConditionalClient test double that counts full
+// Manifest fetches separately from revalidations.
+type fakeClient struct {
+	digest    string
+	manifest  *Manifest
+	mediaType string
+
+	fetches       int
+	revalidations int
+}
+
+func (f *fakeClient) Manifest(ctx context.Context, ref Ref) (*Manifest, string, string, error) {
+	f.fetches++
+	return f.manifest, f.mediaType, f.digest, nil
+}
+
+func (f *fakeClient) ManifestIfChanged(ctx context.Context, ref Ref, etag string) (*Manifest, string, string, string, bool, error) {
+	f.revalidations++
+	if etag == f.digest {
+		return nil, "", f.digest, f.digest, true, nil
+	}
+	f.fetches++
+	return f.manifest, f.mediaType, f.digest, f.digest, false, nil
+}
+
+func (f *fakeClient) ManifestExists(ctx context.Context, ref Ref) (bool, string, error) {
+	return true, f.digest, nil
+}
+
+func (f *fakeClient) Blob(ctx context.Context, ref Ref, digest string) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+func (f *fakeClient) BlobStat(ctx context.Context, ref Ref, digest string) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeClient) Tags(ctx context.Context, ref Ref) ([]string, error) {
+	return nil, nil
+}
+
+func TestCachingClient_RevalidatesInsteadOfRefetching(t *testing.T) {
+	fake := &fakeClient{digest: "sha256:unchanged", manifest: &Manifest{MediaType: "application/vnd.oci.image.manifest.v1+json"}}
+	client := NewCachingClient(fake, NewLRUManifestCache(16), 0)
+	ref := Ref{Host: "registry.example.com", Name: "team/image", Reference: "latest"}
+
+	if _, _, _, err := client.Manifest(context.Background(), ref); err != nil {
+		t.Fatalf("first Manifest() error = %v", err)
+	}
+	if _, _, _, err := client.Manifest(context.Background(), ref); err != nil {
+		t.Fatalf("second Manifest() error = %v", err)
+	}
+
+	if fake.fetches != 1 {
+		t.Errorf("fetches = %d, want 1 (second call should revalidate, not refetch)", fake.fetches)
+	}
+	if fake.revalidations != 1 {
+		t.Errorf("revalidations = %d, want 1", fake.revalidations)
+	}
+
+	stats := client.Stats()
+	if stats.Misses != 1 || stats.Revalidations != 1 {
+		t.Errorf("stats = %+v, want Misses=1 Revalidations=1", stats)
+	}
+}
+
+func TestCachingClient_TTLSkipsRevalidation(t *testing.T) {
+	fake := &fakeClient{digest: "sha256:unchanged", manifest: &Manifest{}}
+	client := NewCachingClient(fake, NewLRUManifestCache(16), time.Hour)
+	ref := Ref{Host: "registry.example.com", Name: "team/image", Reference: "latest"}
+
+	client.Manifest(context.Background(), ref)
+	client.Manifest(context.Background(), ref)
+
+	if fake.fetches != 1 || fake.revalidations != 0 {
+		t.Errorf("fetches=%d revalidations=%d, want 1 and 0 (within TTL, no network call at all)", fake.fetches, fake.revalidations)
+	}
+
+	stats := client.Stats()
+	if stats.Hits != 1 {
+		t.Errorf("Hits = %d, want 1", stats.Hits)
+	}
+}
@@ -0,0 +1,361 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// manifestAccept lists every manifest media type HTTPClient asks for,
+// including legacy schema 1 for older/re-pushed images.
+var manifestAccept = strings.Join([]string{
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v1+prettyjws",
+}, ", ")
+
+// HTTPClient is the default Client implementation: a Docker Distribution v2
+// API client over plain net/http, with pluggable auth and automatic retry.
+type HTTPClient struct {
+	httpClient *http.Client
+	auth       Authenticator
+	maxRetries int
+}
+
+// Option configures an HTTPClient in NewClient.
+type Option func(*HTTPClient)
+
+// WithHTTPClient overrides the underlying *http.Client (default: http.DefaultClient).
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *HTTPClient) { c.httpClient = hc }
+}
+
+// WithMaxRetries overrides the number of retries on 429/5xx or transport
+// errors (default: 5).
+func WithMaxRetries(n int) Option {
+	return func(c *HTTPClient) { c.maxRetries = n }
+}
+
+// NewClient returns an HTTPClient authenticating with auth.
+func NewClient(auth Authenticator, opts ...Option) *HTTPClient {
+	c := &HTTPClient{httpClient: http.DefaultClient, auth: auth, maxRetries: 5}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Manifest fetches ref's manifest. Schema 1 manifests are transparently
+// converted into the same Manifest shape schema 2/OCI manifests use, with
+// layer sizes filled in via a HEAD request per blob (schema 1 carries no
+// size field of its own).
+func (c *HTTPClient) Manifest(ctx context.Context, ref Ref) (*Manifest, string, string, error) {
+	resp, err := c.do(ctx, http.MethodGet, ref, "manifests/"+ref.Reference, manifestAccept)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("fetching manifest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, "", "", fmt.Errorf("registry returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("reading manifest: %w", err)
+	}
+
+	var envelope struct {
+		SchemaVersion int    `json:"schemaVersion"`
+		MediaType     string `json:"mediaType"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, "", "", fmt.Errorf("decoding manifest: %w", err)
+	}
+	if envelope.MediaType == "" {
+		envelope.MediaType = resp.Header.Get("Content-Type")
+	}
+
+	var manifest *Manifest
+	if envelope.SchemaVersion == 1 || isSchema1MediaType(envelope.MediaType) {
+		manifest, err = c.convertSchema1Manifest(ctx, body, ref)
+		if err != nil {
+			return nil, "", "", err
+		}
+	} else {
+		manifest = &Manifest{}
+		if err := json.Unmarshal(body, manifest); err != nil {
+			return nil, "", "", fmt.Errorf("decoding manifest: %w", err)
+		}
+		if manifest.MediaType == "" {
+			manifest.MediaType = envelope.MediaType
+		}
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		sum := sha256.Sum256(body)
+		digest = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	return manifest, manifest.MediaType, digest, nil
+}
+
+// ManifestExists reports whether ref's manifest exists via HEAD, without
+// fetching its body.
+func (c *HTTPClient) ManifestExists(ctx context.Context, ref Ref) (bool, string, error) {
+	resp, err := c.do(ctx, http.MethodHead, ref, "manifests/"+ref.Reference, manifestAccept)
+	if err != nil {
+		return false, "", fmt.Errorf("checking manifest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, "", fmt.Errorf("registry returned %d", resp.StatusCode)
+	}
+
+	return true, resp.Header.Get("Docker-Content-Digest"), nil
+}
+
+// Blob streams the blob identified by digest. The caller must close it.
+func (c *HTTPClient) Blob(ctx context.Context, ref Ref, digest string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, http.MethodGet, ref, "blobs/"+digest, "")
+	if err != nil {
+		return nil, fmt.Errorf("fetching blob: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("registry returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	return resp.Body, nil
+}
+
+// BlobStat returns digest's size via HEAD, without downloading it.
+func (c *HTTPClient) BlobStat(ctx context.Context, ref Ref, digest string) (int64, error) {
+	resp, err := c.do(ctx, http.MethodHead, ref, "blobs/"+digest, "")
+	if err != nil {
+		return 0, fmt.Errorf("checking blob: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("registry returned %d for blob %s", resp.StatusCode, digest)
+	}
+
+	return resp.ContentLength, nil
+}
+
+// tagsResponse is the body of a GET /v2/<name>/tags/list request.
+type tagsResponse struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// Tags lists every tag in ref's repository.
+func (c *HTTPClient) Tags(ctx context.Context, ref Ref) ([]string, error) {
+	resp, err := c.do(ctx, http.MethodGet, ref, "tags/list", "")
+	if err != nil {
+		return nil, fmt.Errorf("listing tags: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("registry returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tags tagsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, fmt.Errorf("decoding tags: %w", err)
+	}
+	return tags.Tags, nil
+}
+
+// do builds and sends a /v2/<name>/<path> request, retrying on 429/5xx and
+// transport errors with exponential backoff, and resolving a bearer
+// challenge if auth supports one. The caller must close the response body.
+func (c *HTTPClient) do(ctx context.Context, method string, ref Ref, path, accept string) (*http.Response, error) {
+	url := fmt.Sprintf("https://%s/v2/%s/%s", ref.Host, ref.Name, path)
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if err := c.auth.Authenticate(ctx, req); err != nil {
+			return nil, fmt.Errorf("authenticating request: %w", err)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			if !c.backoff(ctx, attempt, 0) {
+				break
+			}
+			continue
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized {
+			if challenger, ok := c.auth.(ChallengeAuthenticator); ok {
+				if challenge := resp.Header.Get("WWW-Authenticate"); challenge != "" {
+					resp.Body.Close()
+					resp, err = c.retryWithChallenge(ctx, challenger, method, url, accept, challenge)
+					if err != nil {
+						lastErr = err
+						if !c.backoff(ctx, attempt, 0) {
+							break
+						}
+						continue
+					}
+				}
+			}
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			retryAfter := retryAfterDelay(resp.Header.Get("Retry-After"))
+			resp.Body.Close()
+			lastErr = fmt.Errorf("registry returned %d", resp.StatusCode)
+			if !c.backoff(ctx, attempt, retryAfter) {
+				break
+			}
+			continue
+		}
+
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("after %d attempts: %w", c.maxRetries+1, lastErr)
+}
+
+// retryWithChallenge resolves a bearer challenge and re-issues the request
+// with the resulting token.
+func (c *HTTPClient) retryWithChallenge(ctx context.Context, challenger ChallengeAuthenticator, method, url, accept, challenge string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if err := challenger.AuthenticateChallenge(ctx, req, challenge); err != nil {
+		return nil, fmt.Errorf("responding to auth challenge: %w", err)
+	}
+	return c.httpClient.Do(req)
+}
+
+// backoff sleeps before a retry: minDelay honors a Retry-After header when
+// the registry sent one, otherwise exponential backoff (2^attempt * 250ms,
+// capped at 30s). Returns false (meaning "don't retry") once attempt has
+// reached maxRetries or ctx is canceled.
+func (c *HTTPClient) backoff(ctx context.Context, attempt int, minDelay time.Duration) bool {
+	if attempt >= c.maxRetries {
+		return false
+	}
+
+	delay := minDelay
+	if exp := time.Duration(1<<uint(attempt)) * 250 * time.Millisecond; exp > delay {
+		delay = exp
+	}
+	if delay > 30*time.Second {
+		delay = 30 * time.Second
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(delay):
+		return true
+	}
+}
+
+// retryAfterDelay parses a Retry-After header value (seconds; registries
+// don't send the HTTP-date form for this one) into a Duration, or 0 if
+// absent/unparseable.
+func retryAfterDelay(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// isSchema1MediaType reports whether mediaType identifies a Docker v2
+// schema 1 manifest (signed or unsigned).
+func isSchema1MediaType(mediaType string) bool {
+	return strings.Contains(mediaType, "manifest.v1+json") || strings.Contains(mediaType, "manifest.v1+prettyjws")
+}
+
+// dockerManifestV1 represents a Docker v2 schema 1 manifest, produced by
+// older registries and some re-pushed legacy images. Unlike schema 2, it
+// has no separate config blob and no per-layer size field: FSLayers only
+// carries each layer's digest, so sizes have to come from a HEAD request
+// per blob.
+type dockerManifestV1 struct {
+	SchemaVersion int         `json:"schemaVersion"`
+	FSLayers      []v1FSLayer `json:"fsLayers"`
+}
+
+// v1FSLayer identifies one layer in a schema 1 manifest by digest only.
+type v1FSLayer struct {
+	BlobSum string `json:"blobSum"`
+}
+
+// convertSchema1Manifest parses a schema 1 manifest body and reconstructs
+// a Manifest-shaped result, so callers don't need to special-case schema
+// version: FSLayers become Layers with sizes filled in via a HEAD request
+// per blob, and Config is left zero since schema 1 has no separate config
+// blob.
+func (c *HTTPClient) convertSchema1Manifest(ctx context.Context, body []byte, ref Ref) (*Manifest, error) {
+	var v1 dockerManifestV1
+	if err := json.Unmarshal(body, &v1); err != nil {
+		return nil, fmt.Errorf("decoding schema 1 manifest: %w", err)
+	}
+
+	manifest := &Manifest{
+		SchemaVersion: 1,
+		MediaType:     "application/vnd.docker.distribution.manifest.v1+json",
+	}
+
+	// FSLayers lists layers top-down (most-recent-first) and commonly
+	// repeats a shared base layer's digest; dedupe so it isn't
+	// double-counted the way a naive sum over FSLayers would be.
+	seen := make(map[string]bool, len(v1.FSLayers))
+	for _, fsLayer := range v1.FSLayers {
+		if seen[fsLayer.BlobSum] {
+			continue
+		}
+		seen[fsLayer.BlobSum] = true
+
+		size, err := c.BlobStat(ctx, ref, fsLayer.BlobSum)
+		if err != nil {
+			return nil, fmt.Errorf("sizing layer %s: %w", fsLayer.BlobSum, err)
+		}
+		manifest.Layers = append(manifest.Layers, Layer{Digest: fsLayer.BlobSum, Size: size})
+	}
+
+	return manifest, nil
+}
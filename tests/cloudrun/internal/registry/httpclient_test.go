@@ -0,0 +1,123 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBearerChallenge(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		realm   string
+		service string
+		scope   string
+		wantErr bool
+	}{
+		{
+			name:    "realm, service, and scope",
+			header:  `Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/alpine:pull"`,
+			realm:   "https://auth.docker.io/token",
+			service: "registry.docker.io",
+			scope:   "repository:library/alpine:pull",
+		},
+		{
+			name:   "realm only",
+			header: `Bearer realm="https://ghcr.io/token"`,
+			realm:  "https://ghcr.io/token",
+		},
+		{
+			name:    "not a bearer challenge",
+			header:  `Basic realm="registry"`,
+			wantErr: true,
+		},
+		{
+			name:    "missing realm",
+			header:  `Bearer service="registry.docker.io"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			realm, service, scope, err := parseBearerChallenge(tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBearerChallenge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if realm != tt.realm || service != tt.service || scope != tt.scope {
+				t.Errorf("parseBearerChallenge() = (%q, %q, %q), want (%q, %q, %q)",
+					realm, service, scope, tt.realm, tt.service, tt.scope)
+			}
+		})
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{"-1", 0},
+		{"not-a-number", 0},
+	}
+
+	for _, tt := range tests {
+		if got := retryAfterDelay(tt.header); got != tt.want {
+			t.Errorf("retryAfterDelay(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestHTTPClient_ManifestConvertsSchema1(t *testing.T) {
+	blobSizes := map[string]int64{
+		"sha256:aaa": 100,
+		"sha256:bbb": 200,
+	}
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/library/alpine/manifests/latest":
+			w.Header().Set("Content-Type", "application/vnd.docker.distribution.manifest.v1+json")
+			_ = json.NewEncoder(w).Encode(dockerManifestV1{
+				SchemaVersion: 1,
+				FSLayers: []v1FSLayer{
+					{BlobSum: "sha256:aaa"},
+					{BlobSum: "sha256:bbb"},
+					{BlobSum: "sha256:aaa"}, // repeated base layer, should be deduped
+				},
+			})
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/library/alpine/blobs/sha256:aaa":
+			w.Header().Set("Content-Length", fmt.Sprintf("%d", blobSizes["sha256:aaa"]))
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/library/alpine/blobs/sha256:bbb":
+			w.Header().Set("Content-Length", fmt.Sprintf("%d", blobSizes["sha256:bbb"]))
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ref := Ref{Host: strings.TrimPrefix(srv.URL, "https://"), Name: "library/alpine", Reference: "latest"}
+	client := NewClient(AnonymousAuthenticator{}, WithHTTPClient(srv.Client()))
+
+	manifest, _, _, err := client.Manifest(context.Background(), ref)
+	if err != nil {
+		t.Fatalf("Manifest() error = %v", err)
+	}
+
+	if len(manifest.Layers) != 2 {
+		t.Fatalf("Layers = %d entries, want 2 (deduped)", len(manifest.Layers))
+	}
+}
@@ -0,0 +1,86 @@
+// Package registry implements a minimal, registry-agnostic client for the
+// OCI/Docker Distribution v2 HTTP API: manifest and blob retrieval, tag
+// listing, and existence checks, with pluggable authentication and
+// automatic retry on 429/5xx. gcp.ArtifactRegistryClient is a thin wrapper
+// around a Client configured for Artifact Registry's *-docker.pkg.dev
+// hosts; the same Client works against Docker Hub, GHCR, ECR, or a
+// self-hosted registry by swapping in a different Authenticator.
+package registry
+
+import (
+	"context"
+	"io"
+)
+
+// Ref identifies one repository reference within a registry: a specific
+// tag or digest of a specific image name.
+type Ref struct {
+	// Host is the registry's hostname, e.g. "europe-west1-docker.pkg.dev",
+	// "registry-1.docker.io", or "ghcr.io".
+	Host string
+	// Name is the repository path, e.g. "project/repo/image".
+	Name string
+	// Reference is a tag or a "sha256:..." digest.
+	Reference string
+}
+
+// Manifest is a Docker/OCI image manifest, in either its single-platform
+// form (Config and Layers populated) or its manifest-list/image-index form
+// (Manifests populated, listing one descriptor per platform).
+type Manifest struct {
+	SchemaVersion int                  `json:"schemaVersion"`
+	MediaType     string               `json:"mediaType"`
+	Manifests     []ManifestDescriptor `json:"manifests,omitempty"`
+	Config        Layer                `json:"config,omitempty"`
+	Layers        []Layer              `json:"layers,omitempty"`
+}
+
+// ManifestDescriptor describes a platform-specific manifest within a
+// manifest list / OCI image index.
+type ManifestDescriptor struct {
+	MediaType string   `json:"mediaType"`
+	Size      int64    `json:"size"`
+	Digest    string   `json:"digest"`
+	Platform  Platform `json:"platform"`
+}
+
+// Platform identifies the platform a manifest list entry targets. Variant
+// and OSVersion are only populated for images that need them (e.g.
+// linux/arm/v7 vs v8, or a specific Windows build).
+type Platform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+	OSVersion    string `json:"os.version,omitempty"`
+}
+
+// Layer describes a layer or config blob within a manifest. Annotations is
+// only populated for OCI artifact manifests that use it to carry
+// out-of-band metadata, such as cosign's simple-signing signature
+// annotation on a signature artifact's sole layer.
+type Layer struct {
+	MediaType   string            `json:"mediaType"`
+	Size        int64             `json:"size"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Client is a registry-agnostic Docker Distribution v2 API client.
+type Client interface {
+	// Manifest fetches ref's manifest, returning it alongside its media
+	// type and content digest (from the Docker-Content-Digest header if
+	// present, otherwise computed from the response body).
+	Manifest(ctx context.Context, ref Ref) (manifest *Manifest, mediaType string, digest string, err error)
+	// ManifestExists reports whether ref's manifest exists via
+	// HEAD /v2/<name>/manifests/<reference>, per the distribution spec,
+	// without fetching the manifest body.
+	ManifestExists(ctx context.Context, ref Ref) (exists bool, digest string, err error)
+	// Blob streams the blob identified by digest within ref's repository.
+	// The caller must close the returned reader.
+	Blob(ctx context.Context, ref Ref, digest string) (blob io.ReadCloser, err error)
+	// BlobStat returns the size in bytes of the blob identified by digest,
+	// via HEAD, without downloading it.
+	BlobStat(ctx context.Context, ref Ref, digest string) (size int64, err error)
+	// Tags lists every tag in ref's repository (ref.Reference is ignored).
+	Tags(ctx context.Context, ref Ref) ([]string, error)
+}
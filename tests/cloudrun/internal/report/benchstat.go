@@ -0,0 +1,274 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/latency"
+)
+
+// MetricComparison is a benchstat-style comparison of one metric (e.g. a
+// service's cold start TTFB) between an old and a new run, backed by a
+// Mann-Whitney U test over the two runs' latency histograms.
+//
+// The test operates on bucketed histogram data rather than raw samples —
+// internal/latency.Histogram never retains individual observations — so
+// samples that land in the same bucket are treated as tied. This is an
+// approximation of a true rank-sum test, but converges to it as the
+// histogram's bucket resolution increases relative to the spread of the
+// data, which holds in practice for the 3-significant-figure buckets used
+// throughout this suite.
+type MetricComparison struct {
+	ServiceName string
+	Metric      string
+
+	OldMean time.Duration
+	NewMean time.Duration
+	OldN    int64
+	NewN    int64
+
+	DeltaPct float64
+	PValue   float64
+	Alpha    float64
+
+	// Marker is "~" when the delta is not statistically significant at
+	// Alpha, "+" when New is significantly slower, "-" when New is
+	// significantly faster.
+	Marker string
+}
+
+// RunComparison is a benchstat-style comparison between two full runs,
+// one MetricComparison per service/metric pair present in both.
+type RunComparison struct {
+	OldRunID string
+	NewRunID string
+	Alpha    float64
+	Metrics  []MetricComparison
+}
+
+// CompareRuns runs a Mann-Whitney U test, at the given significance level,
+// between every comparable cold start and warm request metric in old and
+// new. Services missing from either run are skipped.
+func CompareRuns(oldReport, newReport *JSONReport, alpha float64) *RunComparison {
+	cmp := &RunComparison{
+		OldRunID: oldReport.RunID,
+		NewRunID: newReport.RunID,
+		Alpha:    alpha,
+	}
+
+	names := make([]string, 0, len(oldReport.Services))
+	for name := range oldReport.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		oldSvc, ok := oldReport.Services[name]
+		if !ok {
+			continue
+		}
+		newSvc, ok := newReport.Services[name]
+		if !ok {
+			continue
+		}
+
+		if m, ok := compareMetric(name, "cold_start_ttfb", oldSvc.ColdStart.histogram(), newSvc.ColdStart.histogram(), alpha); ok {
+			cmp.Metrics = append(cmp.Metrics, m)
+		}
+		if m, ok := compareMetric(name, "warm_request", oldSvc.WarmRequest.histogram(), newSvc.WarmRequest.histogram(), alpha); ok {
+			cmp.Metrics = append(cmp.Metrics, m)
+		}
+	}
+
+	return cmp
+}
+
+// histogram decodes s's embedded latency histogram, if any. It returns nil
+// for a nil *JSONColdStartStats, so CompareRuns can treat one the same as
+// one with no recorded histogram.
+func (s *JSONColdStartStats) histogram() *latency.Histogram {
+	if s == nil || s.LatencyHistogram == "" {
+		return nil
+	}
+	h, err := latency.DecodeHistogram(s.LatencyHistogram)
+	if err != nil {
+		return nil
+	}
+	return h
+}
+
+func (s *JSONWarmStats) histogram() *latency.Histogram {
+	if s == nil || s.LatencyHistogram == "" {
+		return nil
+	}
+	h, err := latency.DecodeHistogram(s.LatencyHistogram)
+	if err != nil {
+		return nil
+	}
+	return h
+}
+
+func compareMetric(service, metric string, oldHist, newHist *latency.Histogram, alpha float64) (MetricComparison, bool) {
+	if oldHist == nil || newHist == nil {
+		return MetricComparison{}, false
+	}
+
+	u := mannWhitneyU(oldHist.Buckets(), newHist.Buckets())
+	if u.n1 == 0 || u.n2 == 0 {
+		return MetricComparison{}, false
+	}
+
+	oldMean, newMean := oldHist.Mean(), newHist.Mean()
+	var deltaPct float64
+	if oldMean > 0 {
+		deltaPct = (float64(newMean-oldMean) / float64(oldMean)) * 100
+	}
+
+	marker := "~"
+	if u.pValue < alpha {
+		if newMean > oldMean {
+			marker = "+"
+		} else if newMean < oldMean {
+			marker = "-"
+		}
+	}
+
+	return MetricComparison{
+		ServiceName: service,
+		Metric:      metric,
+		OldMean:     oldMean,
+		NewMean:     newMean,
+		OldN:        u.n1,
+		NewN:        u.n2,
+		DeltaPct:    deltaPct,
+		PValue:      u.pValue,
+		Alpha:       alpha,
+		Marker:      marker,
+	}, true
+}
+
+// mwResult holds the raw output of a Mann-Whitney U test: the U statistic
+// for group 1 and the two-sided p-value from the tie-corrected normal
+// approximation.
+type mwResult struct {
+	n1, n2 int64
+	u      float64
+	pValue float64
+}
+
+// mannWhitneyU computes a Mann-Whitney U test between two bucketed
+// distributions. Each bucket's count contributes that many tied
+// observations at the bucket's representative value, so the rank sum and
+// tie correction are computed over (value, weight) groups rather than
+// individual samples.
+func mannWhitneyU(a, b []latency.Bucket) mwResult {
+	type group struct {
+		value  time.Duration
+		countA int64
+		countB int64
+	}
+
+	byValue := make(map[time.Duration]*group)
+	var n1, n2 int64
+	for _, bucket := range a {
+		g := byValue[bucket.Value]
+		if g == nil {
+			g = &group{value: bucket.Value}
+			byValue[bucket.Value] = g
+		}
+		g.countA += bucket.Count
+		n1 += bucket.Count
+	}
+	for _, bucket := range b {
+		g := byValue[bucket.Value]
+		if g == nil {
+			g = &group{value: bucket.Value}
+			byValue[bucket.Value] = g
+		}
+		g.countB += bucket.Count
+		n2 += bucket.Count
+	}
+
+	if n1 == 0 || n2 == 0 {
+		return mwResult{n1: n1, n2: n2, pValue: 1}
+	}
+
+	values := make([]time.Duration, 0, len(byValue))
+	for v := range byValue {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	// Assign average ranks across tied groups and accumulate R1 (rank sum
+	// for group a) plus the tie-correction term sum(t^3 - t) for every tied
+	// rank group, per the standard normal-approximation formula.
+	var rankSumA float64
+	var tieCorrection float64
+	rank := float64(1)
+	for _, v := range values {
+		g := byValue[v]
+		t := g.countA + g.countB
+		avgRank := rank + float64(t-1)/2
+
+		rankSumA += avgRank * float64(g.countA)
+		tieCorrection += float64(t*t*t - t)
+
+		rank += float64(t)
+	}
+
+	nf1, nf2 := float64(n1), float64(n2)
+	u := rankSumA - nf1*(nf1+1)/2
+
+	meanU := nf1 * nf2 / 2
+	n := nf1 + nf2
+	variance := (nf1 * nf2 / 12) * ((n + 1) - tieCorrection/(n*(n-1)))
+	if variance <= 0 {
+		return mwResult{n1: n1, n2: n2, u: u, pValue: 1}
+	}
+
+	z := (u - meanU) / math.Sqrt(variance)
+	pValue := 2 * (1 - normalCDF(math.Abs(z)))
+	if pValue > 1 {
+		pValue = 1
+	}
+
+	return mwResult{n1: n1, n2: n2, u: u, pValue: pValue}
+}
+
+// normalCDF returns the standard normal cumulative distribution function
+// at z, via the error function identity.
+func normalCDF(z float64) float64 {
+	return 0.5 * (1 + math.Erf(z/math.Sqrt2))
+}
+
+// WriteBenchstatMarkdown writes a benchstat-style "old vs new" table, one
+// row per service/metric, with a significance marker and p-value.
+func WriteBenchstatMarkdown(cmp *RunComparison, path string) error {
+	var sb strings.Builder
+
+	sb.WriteString("# Run Comparison\n\n")
+	sb.WriteString(fmt.Sprintf("**Old run:** `%s`\n\n", cmp.OldRunID))
+	sb.WriteString(fmt.Sprintf("**New run:** `%s`\n\n", cmp.NewRunID))
+	sb.WriteString(fmt.Sprintf("Significance level alpha = %.2f. Marker is `+` when new is significantly slower, "+
+		"`-` when new is significantly faster, `~` when the difference is not significant.\n\n", cmp.Alpha))
+
+	sb.WriteString("| Service | Metric | Old | New | Delta | p-value | |\n")
+	sb.WriteString("|---------|--------|-----|-----|-------|---------|---|\n")
+
+	for _, m := range cmp.Metrics {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %+.1f%% | %.4f | %s |\n",
+			m.ServiceName, m.Metric, formatDuration(m.OldMean), formatDuration(m.NewMean),
+			m.DeltaPct, m.PValue, m.Marker))
+	}
+	sb.WriteString("\n")
+
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+)
+
+// WriteBenchstat writes result in Go's `testing.B` textual benchmark
+// format, one line per raw cold-start/warm-request sample (rather than one
+// line per service with an aggregated N), so two runs' files can be fed
+// straight to `golang.org/x/perf/cmd/benchstat old.txt new.txt` to get a
+// real Mann-Whitney comparison instead of the approximation
+// CompareRuns/mannWhitneyU computes from bucketed histograms.
+func WriteBenchstat(result *benchmark.BenchmarkResult, path string) error {
+	var sb strings.Builder
+
+	names := make([]string, 0, len(result.Services))
+	for name := range result.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		svc := result.Services[name]
+
+		if svc.ColdStart != nil {
+			for _, r := range svc.ColdStart.Results {
+				if r.Error != nil {
+					continue
+				}
+				sb.WriteString(fmt.Sprintf("BenchmarkColdStart/%s-1 1 %d ns/op\n", name, r.TTFB.Nanoseconds()))
+			}
+		}
+
+		if svc.WarmRequest != nil {
+			for _, r := range svc.WarmRequest.Results {
+				if r.Error != nil {
+					continue
+				}
+				sb.WriteString(fmt.Sprintf("BenchmarkWarmRequest/%s-1 1 %d ns/op\n", name, r.Latency.Nanoseconds()))
+			}
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	return nil
+}
+
+// WriteBenchstatRegressionMarkdown wraps the external benchstat CLI's raw
+// text output in a "Regression" Markdown section, for the
+// "compare --benchstat" CLI mode.
+func WriteBenchstatRegressionMarkdown(benchstatOutput, path string) error {
+	var sb strings.Builder
+
+	sb.WriteString("# Regression (benchstat)\n\n")
+	sb.WriteString("```\n")
+	sb.WriteString(benchstatOutput)
+	sb.WriteString("```\n")
+
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	return nil
+}
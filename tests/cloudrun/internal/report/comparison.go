@@ -14,6 +14,21 @@ import (
 // This matches the format from the local performance testing infrastructure.
 type LocalBenchmarkResult struct {
 	Services map[string]LocalServiceResult `json:"services"`
+
+	// NetworkProfile records the simulated WAN conditions (see
+	// internal/netsim) the local results were captured under, if the local
+	// infrastructure applied one. When set, Compare reports an
+	// RTT-adjusted ratio alongside the raw one.
+	NetworkProfile *NetworkProfile `json:"network_profile,omitempty"`
+}
+
+// NetworkProfile mirrors netsim.Profile for JSON round-tripping, without
+// this package depending on internal/netsim.
+type NetworkProfile struct {
+	RTT           time.Duration `json:"rtt"`
+	Jitter        time.Duration `json:"jitter"`
+	BandwidthMbps float64       `json:"bandwidth_mbps"`
+	MTU           int           `json:"mtu"`
 }
 
 // LocalServiceResult contains local benchmark data for a service.
@@ -48,10 +63,17 @@ type ServiceComparison struct {
 	CloudRunP50       time.Duration
 
 	// Deltas
-	ColdStartDelta    time.Duration
-	ColdStartRatio    float64
-	WarmLatencyDelta  time.Duration
-	WarmLatencyRatio  float64
+	ColdStartDelta   time.Duration
+	ColdStartRatio   float64
+	WarmLatencyDelta time.Duration
+	WarmLatencyRatio float64
+
+	// Adjusted deltas/ratios add LocalResults.NetworkProfile's RTT to the
+	// local baseline before comparing, so a local run with no network path
+	// of its own isn't penalized against Cloud Run's real one. If no
+	// NetworkProfile was recorded, they equal ColdStartDelta/ColdStartRatio.
+	AdjustedColdStartDelta time.Duration
+	AdjustedColdStartRatio float64
 }
 
 // LoadLocalResults loads local benchmark results from a JSON file.
@@ -77,6 +99,11 @@ func Compare(local *LocalBenchmarkResult, cloudrun *benchmark.BenchmarkResult) *
 		Services:        make(map[string]*ServiceComparison),
 	}
 
+	var simulatedRTT time.Duration
+	if local.NetworkProfile != nil {
+		simulatedRTT = local.NetworkProfile.RTT
+	}
+
 	// Compare each service that exists in both
 	for name, crSvc := range cloudrun.Services {
 		localSvc, ok := local.Services[name]
@@ -111,6 +138,10 @@ func Compare(local *LocalBenchmarkResult, cloudrun *benchmark.BenchmarkResult) *
 		if comparison.LocalFirstPing > 0 && comparison.CloudRunColdStart > 0 {
 			comparison.ColdStartDelta = comparison.CloudRunColdStart - comparison.LocalFirstPing
 			comparison.ColdStartRatio = float64(comparison.CloudRunColdStart) / float64(comparison.LocalFirstPing)
+
+			adjustedLocal := comparison.LocalFirstPing + simulatedRTT
+			comparison.AdjustedColdStartDelta = comparison.CloudRunColdStart - adjustedLocal
+			comparison.AdjustedColdStartRatio = float64(comparison.CloudRunColdStart) / float64(adjustedLocal)
 		}
 
 		if comparison.LocalP50 > 0 && comparison.CloudRunP50 > 0 {
@@ -132,10 +163,22 @@ func WriteComparisonMarkdown(report *ComparisonReport, path string) error {
 	sb.WriteString(fmt.Sprintf("**Cloud Run Run ID:** `%s`\n\n", report.CloudRunResults.RunID))
 	sb.WriteString(fmt.Sprintf("**Date:** %s\n\n", report.CloudRunResults.StartTime.Format("2006-01-02 15:04:05 UTC")))
 
+	if profile := report.LocalResults.NetworkProfile; profile != nil {
+		sb.WriteString("**Simulated network:** ")
+		sb.WriteString(fmt.Sprintf("RTT %s, jitter %s, %.0f Mbps, MTU %d\n\n",
+			profile.RTT, profile.Jitter, profile.BandwidthMbps, profile.MTU))
+	}
+
 	// Cold Start Comparison
 	sb.WriteString("## Cold Start Comparison\n\n")
-	sb.WriteString("| Service | Local First Ping | Cloud Run P50 | Delta | Ratio |\n")
-	sb.WriteString("|---------|-----------------|---------------|-------|-------|\n")
+	header := "| Service | Local First Ping | Cloud Run P50 | Delta | Ratio |\n"
+	divider := "|---------|-----------------|---------------|-------|-------|\n"
+	if report.LocalResults.NetworkProfile != nil {
+		header = "| Service | Local First Ping | Cloud Run P50 | Delta | Ratio | Adjusted Ratio |\n"
+		divider = "|---------|-----------------|---------------|-------|-------|----------------|\n"
+	}
+	sb.WriteString(header)
+	sb.WriteString(divider)
 
 	for name, cmp := range report.Services {
 		localStr := formatDuration(cmp.LocalFirstPing)
@@ -146,8 +189,18 @@ func WriteComparisonMarkdown(report *ComparisonReport, path string) error {
 			ratioStr = fmt.Sprintf("%.1fx", cmp.ColdStartRatio)
 		}
 
-		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
-			name, localStr, cloudStr, deltaStr, ratioStr))
+		if report.LocalResults.NetworkProfile == nil {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+				name, localStr, cloudStr, deltaStr, ratioStr))
+			continue
+		}
+
+		adjustedRatioStr := "-"
+		if cmp.AdjustedColdStartRatio > 0 {
+			adjustedRatioStr = fmt.Sprintf("%.1fx", cmp.AdjustedColdStartRatio)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n",
+			name, localStr, cloudStr, deltaStr, ratioStr, adjustedRatioStr))
 	}
 	sb.WriteString("\n")
 
@@ -0,0 +1,221 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiffReport contains per-service deltas between a baseline and a current
+// JSONReport, used to gate CI runs on performance regressions.
+type DiffReport struct {
+	BaselineRunID string               `json:"baseline_run_id"`
+	CurrentRunID  string               `json:"current_run_id"`
+	Services      map[string]ServiceDiff `json:"services"`
+}
+
+// ServiceDiff contains the deltas for a single service between two reports.
+type ServiceDiff struct {
+	ServiceName string  `json:"service_name"`
+
+	ColdStartP50Delta   time.Duration `json:"-"`
+	ColdStartP50PctChange float64     `json:"cold_start_p50_pct_change"`
+	ColdStartP95Delta   time.Duration `json:"-"`
+	ColdStartP95PctChange float64     `json:"cold_start_p95_pct_change"`
+	ColdStartP99Delta   time.Duration `json:"-"`
+	ColdStartP99PctChange float64     `json:"cold_start_p99_pct_change"`
+
+	WarmP95Delta   time.Duration `json:"-"`
+	WarmP95PctChange float64     `json:"warm_p95_pct_change"`
+	WarmP99Delta   time.Duration `json:"-"`
+	WarmP99PctChange float64     `json:"warm_p99_pct_change"`
+
+	RPSDelta     float64 `json:"rps_delta"`
+	RPSPctChange float64 `json:"rps_pct_change"`
+
+	MissingInBaseline bool `json:"missing_in_baseline,omitempty"`
+	MissingInCurrent  bool `json:"missing_in_current,omitempty"`
+}
+
+// LoadJSONReport loads a previously written JSON report for comparison.
+func LoadJSONReport(path string) (*JSONReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading report: %w", err)
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parsing report: %w", err)
+	}
+
+	return &report, nil
+}
+
+// CompareReports computes per-service deltas between a baseline and a
+// current JSONReport.
+func CompareReports(baseline, current *JSONReport) *DiffReport {
+	diff := &DiffReport{
+		BaselineRunID: baseline.RunID,
+		CurrentRunID:  current.RunID,
+		Services:      make(map[string]ServiceDiff),
+	}
+
+	for name, curSvc := range current.Services {
+		baseSvc, ok := baseline.Services[name]
+		if !ok {
+			diff.Services[name] = ServiceDiff{ServiceName: name, MissingInBaseline: true}
+			continue
+		}
+
+		diff.Services[name] = diffService(name, baseSvc, curSvc)
+	}
+
+	for name := range baseline.Services {
+		if _, ok := current.Services[name]; !ok {
+			diff.Services[name] = ServiceDiff{ServiceName: name, MissingInCurrent: true}
+		}
+	}
+
+	return diff
+}
+
+func diffService(name string, base, cur JSONServiceReport) ServiceDiff {
+	d := ServiceDiff{ServiceName: name}
+
+	if base.ColdStart != nil && cur.ColdStart != nil {
+		d.ColdStartP50Delta, d.ColdStartP50PctChange = diffDurationStrings(base.ColdStart.TTFBP50, cur.ColdStart.TTFBP50)
+		d.ColdStartP95Delta, d.ColdStartP95PctChange = diffDurationStrings(base.ColdStart.TTFBP95, cur.ColdStart.TTFBP95)
+		d.ColdStartP99Delta, d.ColdStartP99PctChange = diffDurationStrings(base.ColdStart.TTFBP99, cur.ColdStart.TTFBP99)
+	}
+
+	if base.WarmRequest != nil && cur.WarmRequest != nil {
+		d.WarmP95Delta, d.WarmP95PctChange = diffDurationStrings(base.WarmRequest.P95, cur.WarmRequest.P95)
+		d.WarmP99Delta, d.WarmP99PctChange = diffDurationStrings(base.WarmRequest.P99, cur.WarmRequest.P99)
+
+		d.RPSDelta = cur.WarmRequest.RequestsPerSecond - base.WarmRequest.RequestsPerSecond
+		if base.WarmRequest.RequestsPerSecond != 0 {
+			d.RPSPctChange = d.RPSDelta / base.WarmRequest.RequestsPerSecond * 100
+		}
+	}
+
+	return d
+}
+
+// diffDurationStrings parses two duration strings (as stored in JSONReport)
+// and returns the absolute delta and the percent change from base to cur.
+func diffDurationStrings(baseStr, curStr string) (time.Duration, float64) {
+	base, err1 := time.ParseDuration(baseStr)
+	cur, err2 := time.ParseDuration(curStr)
+	if err1 != nil || err2 != nil || base == 0 {
+		return 0, 0
+	}
+
+	delta := cur - base
+	pct := float64(delta) / float64(base) * 100
+	return delta, pct
+}
+
+// RegressionThresholds defines the maximum allowed percent regression for
+// each tracked metric. A threshold of 0 disables the check for that metric.
+type RegressionThresholds struct {
+	ColdStartP95Pct float64
+	WarmP95Pct      float64
+	RPSPct          float64 // negative change (i.e. RPS dropping) regresses
+}
+
+// CheckRegressions returns the list of services that regressed beyond the
+// configured thresholds, in the form of human-readable messages.
+func (d *DiffReport) CheckRegressions(thresholds RegressionThresholds) []string {
+	var regressions []string
+
+	for name, svc := range d.Services {
+		if svc.MissingInBaseline || svc.MissingInCurrent {
+			continue
+		}
+
+		if thresholds.ColdStartP95Pct > 0 && svc.ColdStartP95PctChange > thresholds.ColdStartP95Pct {
+			regressions = append(regressions, fmt.Sprintf(
+				"%s: cold start P95 regressed %.1f%% (threshold %.1f%%)",
+				name, svc.ColdStartP95PctChange, thresholds.ColdStartP95Pct))
+		}
+
+		if thresholds.WarmP95Pct > 0 && svc.WarmP95PctChange > thresholds.WarmP95Pct {
+			regressions = append(regressions, fmt.Sprintf(
+				"%s: warm P95 regressed %.1f%% (threshold %.1f%%)",
+				name, svc.WarmP95PctChange, thresholds.WarmP95Pct))
+		}
+
+		if thresholds.RPSPct > 0 && -svc.RPSPctChange > thresholds.RPSPct {
+			regressions = append(regressions, fmt.Sprintf(
+				"%s: throughput dropped %.1f%% (threshold %.1f%%)",
+				name, -svc.RPSPctChange, thresholds.RPSPct))
+		}
+	}
+
+	return regressions
+}
+
+// ParseThreshold parses a CLI-style threshold string such as "+15%" into a
+// float percentage (15.0). The leading "+" is optional.
+func ParseThreshold(s string) (float64, error) {
+	s = strings.TrimPrefix(s, "+")
+	s = strings.TrimSuffix(s, "%")
+	return strconv.ParseFloat(s, 64)
+}
+
+// WriteDiffJSON writes a DiffReport to a machine-readable JSON file.
+func WriteDiffJSON(diff *DiffReport, path string) error {
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling diff: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	return nil
+}
+
+// WriteDiffMarkdown writes a human-readable diff table.
+func WriteDiffMarkdown(diff *DiffReport, path string) error {
+	var sb strings.Builder
+
+	sb.WriteString("# Benchmark Regression Diff\n\n")
+	fmt.Fprintf(&sb, "**Baseline:** `%s`\n\n", diff.BaselineRunID)
+	fmt.Fprintf(&sb, "**Current:** `%s`\n\n", diff.CurrentRunID)
+
+	sb.WriteString("| Service | Cold Start P50 | Cold Start P95 | Cold Start P99 | Warm P95 | Warm P99 | RPS |\n")
+	sb.WriteString("|---------|-----------------|-----------------|-----------------|----------|----------|-----|\n")
+
+	for name, svc := range diff.Services {
+		if svc.MissingInBaseline {
+			fmt.Fprintf(&sb, "| %s | new service |  |  |  |  |  |\n", name)
+			continue
+		}
+		if svc.MissingInCurrent {
+			fmt.Fprintf(&sb, "| %s | removed |  |  |  |  |  |\n", name)
+			continue
+		}
+
+		fmt.Fprintf(&sb, "| %s | %+.1f%% | %+.1f%% | %+.1f%% | %+.1f%% | %+.1f%% | %+.1f%% |\n",
+			name,
+			svc.ColdStartP50PctChange,
+			svc.ColdStartP95PctChange,
+			svc.ColdStartP99PctChange,
+			svc.WarmP95PctChange,
+			svc.WarmP99PctChange,
+			svc.RPSPctChange,
+		)
+	}
+
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	return nil
+}
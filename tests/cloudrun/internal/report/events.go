@@ -0,0 +1,51 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+)
+
+// NDJSONEventSink writes benchmark.Event values as newline-delimited JSON,
+// one line per event, so operators can `tail -f` a long-running benchmark
+// or pipe the stream into `jq`. It implements benchmark.EventSink.
+type NDJSONEventSink struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+// NewNDJSONEventSink opens path for streaming event output. The special
+// path "-" writes to stdout instead of a file.
+func NewNDJSONEventSink(path string) (*NDJSONEventSink, error) {
+	if path == "-" {
+		return &NDJSONEventSink{enc: json.NewEncoder(os.Stdout)}, nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating events file: %w", err)
+	}
+
+	return &NDJSONEventSink{enc: json.NewEncoder(f), closer: f}, nil
+}
+
+// Emit writes a single event as a line of JSON. It is safe for concurrent
+// use, since warm request workers emit from multiple goroutines.
+func (s *NDJSONEventSink) Emit(event benchmark.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.enc.Encode(event)
+}
+
+// Close flushes and closes the underlying file, if any (stdout is left open).
+func (s *NDJSONEventSink) Close() error {
+	if s.closer != nil {
+		return s.closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// RenderFlamegraph shells out to `go tool pprof -svg` to render profilePath
+// (a CPU or heap profile, such as one whose path benchmark.HarnessProfilePaths
+// holds) as an SVG flamegraph at outPath. WriteMarkdown does not call it; the
+// report's "Profiles" section links only the raw .pprof files.
+func RenderFlamegraph(profilePath, outPath string) error {
+	out, err := exec.Command("go", "tool", "pprof", "-svg", profilePath).Output()
+	if err != nil {
+		return fmt.Errorf("running go tool pprof -svg (is the profile non-empty and go on PATH?): %w", err)
+	}
+
+	if err := os.WriteFile(outPath, out, 0644); err != nil {
+		return fmt.Errorf("writing flamegraph: %w", err)
+	}
+
+	return nil
+}
@@ -3,29 +3,46 @@ package report
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
+	"net"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iterator"
 
 	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/config"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
 )
 
 // GCSUploader uploads benchmark results to Google Cloud Storage.
 type GCSUploader struct {
 	client     *storage.Client
 	bucketName string
+	progress   gcp.ProgressReporter
 }
 
-// NewGCSUploader creates a new GCS uploader.
-func NewGCSUploader(ctx context.Context, bucketName string) (*GCSUploader, error) {
-	client, err := storage.NewClient(ctx)
+// NewGCSUploader creates a new GCS uploader. keyFilePath, if set,
+// authenticates with that service account key instead of ADC; opts, if
+// given, take precedence over keyFilePath (see gcp.ClientOption). A
+// gcp.ProgressReporter attached with gcp.WithProgressReporter is notified
+// of each upload's byte progress; without one, uploads report nothing.
+func NewGCSUploader(ctx context.Context, bucketName, keyFilePath string, opts ...gcp.ClientOption) (*GCSUploader, error) {
+	clientOpts, err := gcp.ResolveClientOptions(ctx, keyFilePath, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := storage.NewClient(ctx, clientOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("creating storage client: %w", err)
 	}
@@ -33,6 +50,7 @@ func NewGCSUploader(ctx context.Context, bucketName string) (*GCSUploader, error
 	return &GCSUploader{
 		client:     client,
 		bucketName: bucketName,
+		progress:   gcp.ProgressReporterFromOptions(opts...),
 	}, nil
 }
 
@@ -41,6 +59,107 @@ func (u *GCSUploader) Close() error {
 	return u.client.Close()
 }
 
+// defaultChunkSize and minChunkSize mirror GCS's resumable upload
+// protocol: GCS chunks uploads in multiples of 256 KiB, and 16 MiB is a
+// reasonable default for the multi-MB results.json/stdout logs this
+// uploader handles.
+const (
+	defaultChunkSize      = 16 * 1024 * 1024
+	minChunkSize          = 256 * 1024
+	defaultMaxConcurrency = 4
+)
+
+// RetryPolicy configures retries with exponential backoff and jitter for
+// transient GCS upload failures.
+type RetryPolicy struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+// DefaultRetryPolicy is the RetryPolicy UploadOptions falls back to when
+// none is specified: 4 attempts, starting at 250ms and doubling up to 5s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 4, InitialDelay: 250 * time.Millisecond, MaxDelay: 5 * time.Second}
+}
+
+// UploadOptions configures chunk size, concurrency, and retry behavior
+// for GCSUploader.UploadDir (and, internally, uploadFile).
+type UploadOptions struct {
+	// ChunkSize is the resumable upload chunk size. Defaults to
+	// defaultChunkSize; values below minChunkSize are raised to it.
+	ChunkSize int
+
+	// MaxConcurrency bounds how many files UploadDir uploads in parallel.
+	// Defaults to defaultMaxConcurrency.
+	MaxConcurrency int
+
+	// RetryPolicy controls retries for transient upload failures.
+	// Defaults to DefaultRetryPolicy().
+	RetryPolicy RetryPolicy
+}
+
+// withDefaults fills in zero-valued fields with this package's defaults.
+func (o UploadOptions) withDefaults() UploadOptions {
+	if o.ChunkSize <= 0 {
+		o.ChunkSize = defaultChunkSize
+	}
+	if o.ChunkSize < minChunkSize {
+		o.ChunkSize = minChunkSize
+	}
+	if o.MaxConcurrency <= 0 {
+		o.MaxConcurrency = defaultMaxConcurrency
+	}
+	if o.RetryPolicy.MaxAttempts <= 0 {
+		o.RetryPolicy = DefaultRetryPolicy()
+	}
+	return o
+}
+
+// isRetryable reports whether err looks transient: a 5xx response from
+// GCS, or a network-level error (timeout, connection reset) that
+// io.Copy/writer.Close can surface mid-upload.
+func isRetryable(err error) bool {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// withRetry runs fn up to policy.MaxAttempts times, backing off with
+// exponential delay (doubling, capped at policy.MaxDelay) plus jitter
+// between attempts, and gives up immediately on a non-retryable error.
+func withRetry(ctx context.Context, policy RetryPolicy, fn func() error) error {
+	delay := policy.InitialDelay
+	var lastErr error
+
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		lastErr = fn()
+		if lastErr == nil {
+			return nil
+		}
+		if !isRetryable(lastErr) || attempt == policy.MaxAttempts {
+			return lastErr
+		}
+
+		jittered := delay/2 + time.Duration(rand.Int63n(int64(delay)))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(jittered):
+		}
+
+		delay *= 2
+		if delay > policy.MaxDelay {
+			delay = policy.MaxDelay
+		}
+	}
+
+	return lastErr
+}
+
 // UploadResults uploads benchmark result files to GCS.
 // Files are organized as: YYYY/MM/DD/<run-id>/results.json, results.md
 func (u *GCSUploader) UploadResults(ctx context.Context, runID string, timestamp time.Time, localDir string) ([]string, error) {
@@ -62,7 +181,7 @@ func (u *GCSUploader) UploadResults(ctx context.Context, runID string, timestamp
 
 		gcsPath := path.Join(prefix, filename)
 
-		if err := u.uploadFile(ctx, localPath, gcsPath); err != nil {
+		if err := u.uploadFile(ctx, localPath, gcsPath, UploadOptions{}.withDefaults()); err != nil {
 			return uploadedPaths, fmt.Errorf("uploading %s: %w", filename, err)
 		}
 
@@ -73,41 +192,137 @@ func (u *GCSUploader) UploadResults(ctx context.Context, runID string, timestamp
 	return uploadedPaths, nil
 }
 
-// uploadFile uploads a single file to GCS.
-func (u *GCSUploader) uploadFile(ctx context.Context, localPath, gcsPath string) error {
-	// Open local file
-	f, err := os.Open(localPath)
-	if err != nil {
-		return fmt.Errorf("opening file: %w", err)
+// countingReader wraps an io.Reader, invoking onRead with the cumulative
+// bytes read after each Read, so uploadFile can report upload progress
+// via gcp.ProgressReporter without the GCS client library needing to
+// know about it.
+type countingReader struct {
+	r      io.Reader
+	total  int64
+	read   int64
+	onRead func(read, total int64)
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 {
+		c.read += int64(n)
+		c.onRead(c.read, c.total)
 	}
-	defer f.Close()
+	return n, err
+}
 
-	// Create GCS object writer
-	obj := u.client.Bucket(u.bucketName).Object(gcsPath)
-	writer := obj.NewWriter(ctx)
+// uploadFile uploads a single file to GCS. The upload uses opts.ChunkSize
+// for the resumable upload protocol and is retried per opts.RetryPolicy
+// on transient failures.
+func (u *GCSUploader) uploadFile(ctx context.Context, localPath, gcsPath string, opts UploadOptions) error {
+	return withRetry(ctx, opts.RetryPolicy, func() error {
+		// Open local file
+		f, err := os.Open(localPath)
+		if err != nil {
+			return fmt.Errorf("opening file: %w", err)
+		}
+		defer f.Close()
 
-	// Set content type based on extension
-	switch path.Ext(gcsPath) {
-	case ".json":
-		writer.ContentType = "application/json"
-	case ".md":
-		writer.ContentType = "text/markdown"
-	default:
-		writer.ContentType = "text/plain"
-	}
+		var size int64 = -1
+		if info, statErr := f.Stat(); statErr == nil {
+			size = info.Size()
+		}
 
-	// Copy data
-	if _, err := io.Copy(writer, f); err != nil {
-		writer.Close()
-		return fmt.Errorf("writing to GCS: %w", err)
+		// Create GCS object writer
+		obj := u.client.Bucket(u.bucketName).Object(gcsPath)
+		writer := obj.NewWriter(ctx)
+		writer.ChunkSize = opts.ChunkSize
+
+		// Set content type based on extension
+		switch path.Ext(gcsPath) {
+		case ".json":
+			writer.ContentType = "application/json"
+		case ".md":
+			writer.ContentType = "text/markdown"
+		default:
+			writer.ContentType = "text/plain"
+		}
+
+		var reader io.Reader = f
+		if u.progress != nil {
+			u.progress.OnPhase("uploading:" + gcsPath)
+			reader = &countingReader{r: f, total: size, onRead: func(read, total int64) {
+				u.progress.OnBytes(gcsPath, read, total)
+			}}
+		}
+
+		// Copy data
+		if _, err := io.Copy(writer, reader); err != nil {
+			writer.Close()
+			return fmt.Errorf("writing to GCS: %w", err)
+		}
+
+		// Close writer to finalize upload
+		if err := writer.Close(); err != nil {
+			return fmt.Errorf("finalizing upload: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// UploadResult is the outcome of uploading a single file within
+// UploadDir.
+type UploadResult struct {
+	LocalPath string
+	GCSPath   string
+	Err       error
+}
+
+// UploadDir walks localDir recursively and uploads every file it finds
+// to gcsPrefix, preserving relative paths, with up to
+// opts.MaxConcurrency uploads in flight at a time. It returns one
+// UploadResult per file regardless of success, so a caller can report
+// partial failures instead of aborting the whole batch on the first
+// error.
+func (u *GCSUploader) UploadDir(ctx context.Context, localDir, gcsPrefix string, opts UploadOptions) ([]UploadResult, error) {
+	opts = opts.withDefaults()
+
+	var files []string
+	err := filepath.Walk(localDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking %s: %w", localDir, err)
 	}
 
-	// Close writer to finalize upload
-	if err := writer.Close(); err != nil {
-		return fmt.Errorf("finalizing upload: %w", err)
+	results := make([]UploadResult, len(files))
+	sem := make(chan struct{}, opts.MaxConcurrency)
+	var wg sync.WaitGroup
+
+	for i, localPath := range files {
+		rel, err := filepath.Rel(localDir, localPath)
+		if err != nil {
+			results[i] = UploadResult{LocalPath: localPath, Err: fmt.Errorf("computing relative path: %w", err)}
+			continue
+		}
+		gcsPath := path.Join(gcsPrefix, filepath.ToSlash(rel))
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, localPath, gcsPath string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := u.uploadFile(ctx, localPath, gcsPath, opts)
+			results[i] = UploadResult{LocalPath: localPath, GCSPath: gcsPath, Err: err}
+		}(i, localPath, gcsPath)
 	}
 
-	return nil
+	wg.Wait()
+	return results, nil
 }
 
 // ReadingResult contains cold start measurements for one scheduled iteration.
@@ -267,7 +482,7 @@ func (u *GCSUploader) UploadAdhocResults(ctx context.Context, timestamp time.Tim
 
 		gcsPath := path.Join(prefix, filename)
 
-		if err := u.uploadFile(ctx, localPath, gcsPath); err != nil {
+		if err := u.uploadFile(ctx, localPath, gcsPath, UploadOptions{}.withDefaults()); err != nil {
 			return uploadedPaths, fmt.Errorf("uploading %s: %w", filename, err)
 		}
 
@@ -278,6 +493,22 @@ func (u *GCSUploader) UploadAdhocResults(ctx context.Context, timestamp time.Tim
 	return uploadedPaths, nil
 }
 
+// SignedURL returns a short-lived signed URL for a previously uploaded
+// object, suitable for embedding in a notification without granting the
+// recipient bucket-wide access.
+func (u *GCSUploader) SignedURL(gcsPath string, expiry time.Duration) (string, error) {
+	opts := &storage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(expiry),
+	}
+
+	url, err := u.client.Bucket(u.bucketName).SignedURL(gcsPath, opts)
+	if err != nil {
+		return "", fmt.Errorf("signing URL for %s: %w", gcsPath, err)
+	}
+	return url, nil
+}
+
 // UploadBytes uploads raw bytes to a GCS path.
 func (u *GCSUploader) UploadBytes(ctx context.Context, gcsPath string, data []byte, contentType string) (string, error) {
 	obj := u.client.Bucket(u.bucketName).Object(gcsPath)
@@ -63,6 +63,14 @@ type JSONColdStartStats struct {
 	TTFBP50      string `json:"ttfb_p50"`
 	TTFBP95      string `json:"ttfb_p95"`
 	TTFBP99      string `json:"ttfb_p99"`
+
+	// LatencyHistogram is a gzip-compressed, base64-encoded HDR histogram
+	// of every TTFB sample. The percentiles above come from the raw samples
+	// (for a multi-region aggregate, from the merged histogram, quantized
+	// to its bucket resolution); consumers can decode
+	// this field with latency.DecodeHistogram to recompute arbitrary
+	// quantiles or merge it with histograms from other runs.
+	LatencyHistogram string `json:"latency_histogram,omitempty"`
 }
 
 // JSONWarmStats contains warm request statistics in JSON format.
@@ -78,6 +86,11 @@ type JSONWarmStats struct {
 	P50               string  `json:"p50"`
 	P95               string  `json:"p95"`
 	P99               string  `json:"p99"`
+
+	// LatencyHistogram is a gzip-compressed, base64-encoded HDR histogram
+	// of every request latency sample. See JSONColdStartStats.LatencyHistogram
+	// for decoding and merge semantics.
+	LatencyHistogram string `json:"latency_histogram,omitempty"`
 }
 
 // JSONSummary contains overall benchmark summary.
@@ -170,6 +183,12 @@ func toJSONReport(result *benchmark.BenchmarkResult) *JSONReport {
 				TTFBP99:      svc.ColdStart.TTFBP99.String(),
 			}
 
+			if svc.ColdStart.TTFBHistogram != nil {
+				if encoded, err := svc.ColdStart.TTFBHistogram.Encode(); err == nil {
+					serviceReport.ColdStart.LatencyHistogram = encoded
+				}
+			}
+
 			// Track fastest service
 			if fastestColdStart == 0 || svc.ColdStart.TTFBP50 < fastestColdStart {
 				fastestColdStart = svc.ColdStart.TTFBP50
@@ -191,6 +210,12 @@ func toJSONReport(result *benchmark.BenchmarkResult) *JSONReport {
 				P95:               svc.WarmRequest.P95.String(),
 				P99:               svc.WarmRequest.P99.String(),
 			}
+
+			if svc.WarmRequest.LatencyHistogram != nil {
+				if encoded, err := svc.WarmRequest.LatencyHistogram.Encode(); err == nil {
+					serviceReport.WarmRequest.LatencyHistogram = encoded
+				}
+			}
 		}
 
 		report.Services[name] = serviceReport
@@ -0,0 +1,52 @@
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+)
+
+// JSONLRecord is a single line of a WriteJSONL stream: one service's result
+// plus enough run-level context to make the line self-describing when
+// streamed into a log aggregator.
+type JSONLRecord struct {
+	RunID   string            `json:"run_id"`
+	Service string            `json:"service"`
+	Report  JSONServiceReport `json:"report"`
+}
+
+// WriteJSONL writes benchmark results as newline-delimited JSON, one object
+// per service. Unlike WriteJSON, this format can be streamed and appended to
+// without re-parsing the whole file.
+func WriteJSONL(result *benchmark.BenchmarkResult, path string) error {
+	report := toJSONReport(result)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+
+	for name, svc := range report.Services {
+		record := JSONLRecord{
+			RunID:   report.RunID,
+			Service: name,
+			Report:  svc,
+		}
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("encoding record for %s: %w", name, err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flushing file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+)
+
+// JUnitTestSuites is the root element of a JUnit XML report.
+type JUnitTestSuites struct {
+	XMLName xml.Name        `xml:"testsuites"`
+	Name    string          `xml:"name,attr"`
+	Tests   int             `xml:"tests,attr"`
+	Failures int            `xml:"failures,attr"`
+	Time    string          `xml:"time,attr"`
+	Suites  []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite represents a single Cloud Run benchmark run as a test suite.
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase represents a single service's benchmark result as a test case.
+type JUnitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	Classname  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Failure    *JUnitFailure    `xml:"failure,omitempty"`
+	Properties *JUnitProperties `xml:"properties,omitempty"`
+}
+
+// JUnitFailure describes why a service's benchmark failed.
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnitProperties holds benchmark timings as key/value properties.
+type JUnitProperties struct {
+	Properties []JUnitProperty `xml:"property"`
+}
+
+// JUnitProperty is a single name/value pair attached to a test case.
+type JUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// WriteJUnit writes benchmark results as a JUnit XML report, suitable for
+// ingestion by CI test summary tools (Jenkins/GitLab/GitHub Actions).
+func WriteJUnit(result *benchmark.BenchmarkResult, path string) error {
+	suite := JUnitTestSuite{
+		Name:      result.RunID,
+		Timestamp: result.StartTime.UTC().Format(time.RFC3339),
+	}
+
+	for name, svc := range result.Services {
+		suite.Tests++
+
+		testCase := JUnitTestCase{
+			Name:      name,
+			Classname: "cloudrun.benchmark",
+			Time:      fmt.Sprintf("%.3f", svc.DeploymentDuration.Seconds()),
+		}
+
+		if svc.DeployError != nil {
+			suite.Failures++
+			testCase.Failure = &JUnitFailure{
+				Message: "deploy failed",
+				Type:    "DeployError",
+				Text:    svc.DeployError.Error(),
+			}
+		} else if svc.BenchmarkError != nil {
+			suite.Failures++
+			testCase.Failure = &JUnitFailure{
+				Message: "benchmark failed",
+				Type:    "BenchmarkError",
+				Text:    svc.BenchmarkError.Error(),
+			}
+		}
+
+		testCase.Properties = &JUnitProperties{Properties: junitProperties(svc)}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	suites := JUnitTestSuites{
+		Name:     "cloudrun-benchmark",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Time:     fmt.Sprintf("%.3f", result.EndTime.Sub(result.StartTime).Seconds()),
+		Suites:   []JUnitTestSuite{suite},
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling JUnit XML: %w", err)
+	}
+
+	data = append([]byte(xml.Header), data...)
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	return nil
+}
+
+// junitProperties builds the cold-start/warm timing properties for a service.
+func junitProperties(svc *benchmark.ServiceResult) []JUnitProperty {
+	var props []JUnitProperty
+
+	if svc.ColdStart != nil {
+		props = append(props,
+			JUnitProperty{Name: "cold_start.ttfb_p50", Value: svc.ColdStart.TTFBP50.String()},
+			JUnitProperty{Name: "cold_start.ttfb_p95", Value: svc.ColdStart.TTFBP95.String()},
+			JUnitProperty{Name: "cold_start.ttfb_p99", Value: svc.ColdStart.TTFBP99.String()},
+		)
+	}
+
+	if svc.WarmRequest != nil {
+		props = append(props,
+			JUnitProperty{Name: "warm.p50", Value: svc.WarmRequest.P50.String()},
+			JUnitProperty{Name: "warm.p95", Value: svc.WarmRequest.P95.String()},
+			JUnitProperty{Name: "warm.requests_per_second", Value: fmt.Sprintf("%.2f", svc.WarmRequest.RequestsPerSecond)},
+		)
+	}
+
+	return props
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/profiling"
 )
 
 // WriteMarkdown writes benchmark results to a Markdown file.
@@ -92,6 +93,12 @@ func WriteMarkdown(result *benchmark.BenchmarkResult, path string) error {
 	}
 	sb.WriteString("\n")
 
+	// Cold Start Phase Breakdown (network vs. container vs. app init)
+	sb.WriteString(coldStartPhaseSection(result))
+
+	// Per-region cold start medians (only present for a Runner.RunRegional run)
+	sb.WriteString(regionalColdStartSection(result))
+
 	// Warm Request Results
 	sb.WriteString("## Warm Request Results\n\n")
 	sb.WriteString("| Service | P50 | P95 | P99 | Req/s | Success Rate |\n")
@@ -128,6 +135,16 @@ func WriteMarkdown(result *benchmark.BenchmarkResult, path string) error {
 	}
 	sb.WriteString("\n")
 
+	// Hot functions during startup (only present when profiling was enabled)
+	sb.WriteString(hotFunctionsSection(result))
+
+	// Cloud Profiler links for the full run (only present when ProfilingConfig
+	// was enabled and the benchmark ran against real GCP, not an emulator)
+	sb.WriteString(cloudProfilerSection(result))
+
+	// Harness self-profiles (only present when HarnessProfiling was enabled)
+	sb.WriteString(harnessProfilesSection(result))
+
 	// Key Findings
 	sb.WriteString("## Key Findings\n\n")
 	findings := generateFindings(result)
@@ -162,6 +179,255 @@ func WriteMarkdown(result *benchmark.BenchmarkResult, path string) error {
 	return nil
 }
 
+// WriteMarkdownFromJSON regenerates a Markdown report from a previously
+// written JSONReport. Unlike WriteMarkdown it has no benchmark.ServiceResult
+// to work from, so percentiles are taken as-is from the report's
+// pre-formatted strings rather than recomputed, and profiling/findings
+// sections that depend on in-memory data (Profiles, DeployError) are
+// omitted.
+func WriteMarkdownFromJSON(result *JSONReport, path string) error {
+	var sb strings.Builder
+
+	sb.WriteString("# Cloud Run Cold Start Benchmark Results\n\n")
+	sb.WriteString(fmt.Sprintf("**Run ID:** `%s`\n\n", result.RunID))
+	sb.WriteString(fmt.Sprintf("**Date:** %s\n\n", result.StartTime.Format("2006-01-02 15:04:05 UTC")))
+	sb.WriteString(fmt.Sprintf("**Duration:** %s\n\n", result.Duration))
+	sb.WriteString(fmt.Sprintf("**Project:** %s\n\n", result.Config.ProjectID))
+	sb.WriteString(fmt.Sprintf("**Region:** %s\n\n", result.Config.Region))
+
+	names := make([]string, 0, len(result.Services))
+	for name := range result.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sb.WriteString("## Cold Start Results\n\n")
+	sb.WriteString("| Service | P50 | P95 | P99 | Min | Max | Success |\n")
+	sb.WriteString("|---------|-----|-----|-----|-----|-----|--------|\n")
+	for _, name := range names {
+		svc := result.Services[name]
+		if svc.ColdStart == nil {
+			sb.WriteString(fmt.Sprintf("| %s | - | - | - | - | - | No data |\n", name))
+			continue
+		}
+		cs := svc.ColdStart
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %d/%d |\n",
+			name, cs.TTFBP50, cs.TTFBP95, cs.TTFBP99, cs.TTFBMin, cs.TTFBMax,
+			cs.SuccessCount, cs.SuccessCount+cs.FailureCount))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Warm Request Results\n\n")
+	sb.WriteString("| Service | P50 | P95 | P99 | Req/s | Success Rate |\n")
+	sb.WriteString("|---------|-----|-----|-----|-------|-------------|\n")
+	for _, name := range names {
+		svc := result.Services[name]
+		if svc.WarmRequest == nil {
+			sb.WriteString(fmt.Sprintf("| %s | - | - | - | - | - |\n", name))
+			continue
+		}
+		wr := svc.WarmRequest
+		successRate := float64(wr.Successful) / float64(wr.TotalRequests) * 100
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %.1f | %.1f%% |\n",
+			name, wr.P50, wr.P95, wr.P99, wr.RequestsPerSecond, successRate))
+	}
+	sb.WriteString("\n")
+
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	return nil
+}
+
+// hotFunctionsSection renders a "hot functions during startup" table per
+// service, extracted from the top samples of each service's cold-start
+// profile. Returns an empty string if no service captured a profile.
+func hotFunctionsSection(result *benchmark.BenchmarkResult) string {
+	var sb strings.Builder
+
+	for _, name := range sortedServiceNames(result) {
+		svc := result.Services[name]
+		if svc.ColdStart == nil || len(svc.ColdStart.Profiles) == 0 {
+			continue
+		}
+
+		for _, p := range svc.ColdStart.Profiles {
+			hot := profiling.TopFunctions(p, 10)
+			if len(hot) == 0 {
+				continue
+			}
+
+			fmt.Fprintf(&sb, "## Hot Functions During Startup: %s (%s)\n\n", name, p.ProfileType)
+			sb.WriteString("| Function | Flat % |\n")
+			sb.WriteString("|----------|--------|\n")
+			for _, fn := range hot {
+				fmt.Fprintf(&sb, "| %s | %.1f%% |\n", fn.Name, fn.FlatPercent)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// coldStartPhaseSection renders the httptrace-derived cold-start phase
+// breakdown (DNS/connect/TLS/write as P50 "network" overhead, plus
+// AppInitLatency once Cloud Logging's container startup reading is
+// available) per service. Returns an empty string if no service has any.
+func coldStartPhaseSection(result *benchmark.BenchmarkResult) string {
+	var sb strings.Builder
+
+	var names []string
+	for name, svc := range result.Services {
+		if svc.ColdStart != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+
+	sb.WriteString("## Cold Start Phase Breakdown (P50)\n\n")
+	sb.WriteString("| Service | DNS | Connect | TLS | Write | Network Total | Container Startup | App Init |\n")
+	sb.WriteString("|---------|-----|---------|-----|-------|----------------|--------------------|---------|\n")
+	for _, name := range names {
+		p := result.Services[name].ColdStart.Phases
+		networkP50 := p.DNSLookup.P50 + p.TCPConnect.P50 + p.TLSHandshake.P50 + p.WroteRequest.P50
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+			name,
+			formatDuration(p.DNSLookup.P50),
+			formatDuration(p.TCPConnect.P50),
+			formatDuration(p.TLSHandshake.P50),
+			formatDuration(p.WroteRequest.P50),
+			formatDuration(networkP50),
+			formatDuration(result.Services[name].ColdStart.ContainerStartupAvg),
+			formatDuration(result.Services[name].ColdStart.AppInitLatency.P50),
+		)
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// regionalColdStartSection renders, per service, the per-region cold-start
+// P50/sample-count breakdown populated by Runner.RunRegional alongside the
+// merged aggregate. Returns an empty string if no service has any (a
+// single-region run leaves ColdStart.Regions nil).
+func regionalColdStartSection(result *benchmark.BenchmarkResult) string {
+	var sb strings.Builder
+
+	var names []string
+	for name, svc := range result.Services {
+		if svc.ColdStart != nil && len(svc.ColdStart.Regions) > 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+
+	sb.WriteString("## Cold Start by Region\n\n")
+	for _, name := range names {
+		regions := result.Services[name].ColdStart.Regions
+
+		regionNames := make([]string, 0, len(regions))
+		for region := range regions {
+			regionNames = append(regionNames, region)
+		}
+		sort.Strings(regionNames)
+
+		fmt.Fprintf(&sb, "### %s\n\n", name)
+		sb.WriteString("| Region | P50 | P95 | Samples |\n")
+		sb.WriteString("|--------|-----|-----|---------|\n")
+		for _, region := range regionNames {
+			stats := regions[region]
+			fmt.Fprintf(&sb, "| %s | %s | %s | %d |\n",
+				region, formatDuration(stats.TTFBP50), formatDuration(stats.TTFBP95), stats.SuccessCount)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// cloudProfilerSection renders, per service, the Cloud Profiler console
+// links and hottest functions gathered across the whole run (cold-start and
+// warm-request phases combined), as attached to ServiceResult.ProfileURIs /
+// TopFunctions by Runner.benchmarkService. Returns an empty string if no
+// service has any (profiling disabled, or unreachable outside GCP).
+func cloudProfilerSection(result *benchmark.BenchmarkResult) string {
+	var sb strings.Builder
+
+	for _, name := range sortedServiceNames(result) {
+		svc := result.Services[name]
+		if len(svc.ProfileURIs) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "## Cloud Profiler: %s\n\n", name)
+		for _, uri := range svc.ProfileURIs {
+			fmt.Fprintf(&sb, "- [%s](%s)\n", uri, uri)
+		}
+		sb.WriteString("\n")
+
+		if len(svc.TopFunctions) > 0 {
+			sb.WriteString("| Function | Flat % |\n")
+			sb.WriteString("|----------|--------|\n")
+			for _, fn := range svc.TopFunctions {
+				fmt.Fprintf(&sb, "| %s | %.1f%% |\n", fn.Name, fn.FlatPercent)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// harnessProfilesSection renders a table linking to each service's
+// runtime/pprof CPU, heap, and execution trace captures of the benchmark
+// harness process itself, if config.HarnessProfilingConfig was enabled.
+// Returns an empty string if no service has any.
+func harnessProfilesSection(result *benchmark.BenchmarkResult) string {
+	var sb strings.Builder
+
+	var names []string
+	for name, svc := range result.Services {
+		if svc.HarnessProfiles != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+
+	sb.WriteString("## Profiles\n\n")
+	sb.WriteString("| Service | CPU | Heap | Trace |\n")
+	sb.WriteString("|---------|-----|------|-------|\n")
+	for _, name := range names {
+		p := result.Services[name].HarnessProfiles
+		fmt.Fprintf(&sb, "| %s | [cpu.pprof](%s) | [heap.pprof](%s) | [trace.pprof](%s) |\n",
+			name, p.CPU, p.Heap, p.Trace)
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// sortedServiceNames returns the service names in result, sorted for
+// deterministic report output.
+func sortedServiceNames(result *benchmark.BenchmarkResult) []string {
+	names := make([]string, 0, len(result.Services))
+	for name := range result.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // formatDuration formats a duration for display in tables.
 func formatDuration(d time.Duration) string {
 	if d == 0 {
@@ -0,0 +1,222 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+)
+
+// MatrixKey identifies one (service, profile, region) combination in a
+// MatrixReport. Profile and Region come from the run's ServiceResult.Profile
+// and Config.GCP.Region respectively, so sweeping profiles/regions just
+// means passing more runs to CompareMatrix.
+type MatrixKey struct {
+	Service string
+	Profile string
+	Region  string
+}
+
+// MatrixCell holds the cold-start statistics for one MatrixKey, computed
+// from that run's raw ColdStart samples.
+type MatrixCell struct {
+	RunID      string
+	P50        time.Duration
+	StdDev     time.Duration
+	SampleSize int
+}
+
+// MatrixReport is an N-way comparison of BenchmarkResults across whatever
+// profiles and regions they were run with, keyed by (service, profile,
+// region). Compare/WriteComparisonMarkdown remain the right tool for a
+// local-vs-Cloud-Run comparison; MatrixReport is for comparing multiple
+// Cloud Run configurations against each other.
+type MatrixReport struct {
+	Runs  []*benchmark.BenchmarkResult
+	Cells map[MatrixKey]*MatrixCell
+}
+
+// ObjectiveFunc scores a MatrixCell for a "best configuration"
+// recommendation; lower is better. A typical objective balances P50 against
+// a cost proxy (e.g. CPU/memory implied by the profile), which the caller
+// supplies since MatrixReport has no notion of price.
+type ObjectiveFunc func(key MatrixKey, cell *MatrixCell) float64
+
+// CompareMatrix builds an N-way MatrixReport from runs, one of which may be
+// the baseline and the rest alternate profiles/regions. Each service present
+// in a run contributes one cell per (service, profile, region) it was
+// measured under; a service missing ColdStart stats in a given run is
+// skipped for that run.
+func CompareMatrix(runs ...*benchmark.BenchmarkResult) *MatrixReport {
+	report := &MatrixReport{
+		Runs:  runs,
+		Cells: make(map[MatrixKey]*MatrixCell),
+	}
+
+	for _, run := range runs {
+		region := run.Config.GCP.Region
+
+		for name, svc := range run.Services {
+			if svc.ColdStart == nil || len(svc.ColdStart.Results) == 0 {
+				continue
+			}
+
+			key := MatrixKey{Service: name, Profile: svc.Profile, Region: region}
+			report.Cells[key] = &MatrixCell{
+				RunID:      run.RunID,
+				P50:        svc.ColdStart.TTFBP50,
+				StdDev:     ttfbStdDev(svc.ColdStart),
+				SampleSize: len(svc.ColdStart.Results),
+			}
+		}
+	}
+
+	return report
+}
+
+// ttfbStdDev computes the population standard deviation of a service's
+// successful cold-start TTFB samples. Computed from the raw Results rather
+// than the histogram, since internal/latency.Histogram doesn't retain
+// enough precision to derive a stddev from its buckets.
+func ttfbStdDev(stats *benchmark.ColdStartStats) time.Duration {
+	var samples []float64
+	for _, r := range stats.Results {
+		if r.Error == nil {
+			samples = append(samples, float64(r.TTFB))
+		}
+	}
+	if len(samples) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, s := range samples {
+		sum += s
+	}
+	mean := sum / float64(len(samples))
+
+	var sumSq float64
+	for _, s := range samples {
+		sumSq += (s - mean) * (s - mean)
+	}
+
+	return time.Duration(math.Sqrt(sumSq / float64(len(samples))))
+}
+
+// services returns the distinct service names present in r, sorted.
+func (r *MatrixReport) services() []string {
+	seen := make(map[string]bool)
+	for key := range r.Cells {
+		seen[key.Service] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// profilesAndRegions returns the distinct profiles and regions present for
+// service, sorted, for building that service's pivot table.
+func (r *MatrixReport) profilesAndRegions(service string) (profiles, regions []string) {
+	seenProfiles := make(map[string]bool)
+	seenRegions := make(map[string]bool)
+	for key := range r.Cells {
+		if key.Service != service {
+			continue
+		}
+		seenProfiles[key.Profile] = true
+		seenRegions[key.Region] = true
+	}
+	for p := range seenProfiles {
+		profiles = append(profiles, p)
+	}
+	for rg := range seenRegions {
+		regions = append(regions, rg)
+	}
+	sort.Strings(profiles)
+	sort.Strings(regions)
+	return profiles, regions
+}
+
+// Best returns the MatrixKey/MatrixCell with the lowest objective score
+// among service's cells, for use in a "best configuration" recommendation.
+// Returns false if service has no cells.
+func (r *MatrixReport) Best(service string, objective ObjectiveFunc) (MatrixKey, *MatrixCell, bool) {
+	var bestKey MatrixKey
+	var bestCell *MatrixCell
+	bestScore := math.Inf(1)
+
+	for key, cell := range r.Cells {
+		if key.Service != service {
+			continue
+		}
+		score := objective(key, cell)
+		if score < bestScore {
+			bestScore = score
+			bestKey = key
+			bestCell = cell
+		}
+	}
+
+	return bestKey, bestCell, bestCell != nil
+}
+
+// WriteMatrixMarkdown writes a per-service pivot table (rows = profiles,
+// columns = regions, cells = "P50 ± stddev") plus a best-configuration
+// recommendation per service, scored by objective.
+func WriteMatrixMarkdown(report *MatrixReport, objective ObjectiveFunc, path string) error {
+	var sb strings.Builder
+
+	sb.WriteString("# Multi-Profile / Multi-Region Comparison\n\n")
+	fmt.Fprintf(&sb, "**Runs compared:** %d\n\n", len(report.Runs))
+
+	for _, service := range report.services() {
+		profiles, regions := report.profilesAndRegions(service)
+
+		fmt.Fprintf(&sb, "## %s\n\n", service)
+
+		sb.WriteString("| Profile |")
+		for _, region := range regions {
+			fmt.Fprintf(&sb, " %s |", region)
+		}
+		sb.WriteString("\n|---------|")
+		for range regions {
+			sb.WriteString("------|")
+		}
+		sb.WriteString("\n")
+
+		for _, profile := range profiles {
+			fmt.Fprintf(&sb, "| %s |", profile)
+			for _, region := range regions {
+				key := MatrixKey{Service: service, Profile: profile, Region: region}
+				cell, ok := report.Cells[key]
+				if !ok {
+					sb.WriteString(" - |")
+					continue
+				}
+				fmt.Fprintf(&sb, " %s ± %s |", formatDuration(cell.P50), formatDuration(cell.StdDev))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+
+		if objective != nil {
+			if key, cell, ok := report.Best(service, objective); ok {
+				fmt.Fprintf(&sb, "**Best configuration:** profile `%s` in `%s` (P50 %s, %d samples)\n\n",
+					key.Profile, key.Region, formatDuration(cell.P50), cell.SampleSize)
+			}
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	return nil
+}
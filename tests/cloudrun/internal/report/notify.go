@@ -0,0 +1,200 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+)
+
+// Notification is what's sent when a benchmark run completes.
+type Notification struct {
+	RunID        string
+	Regression   bool                       // true if --alert-on-regression's threshold was exceeded
+	Summary      string                     // Markdown summary, normally results.md's contents
+	Result       *benchmark.BenchmarkResult
+	Attachments  []string                   // local paths to attach (e.g. results.md, comparison.md)
+	ArtifactURLs []string                   // signed GCS URLs for the uploaded artifacts, if any
+}
+
+// Subject returns a one-line title suitable for an email subject or
+// webhook notification title.
+func (n Notification) Subject() string {
+	status := "ok"
+	if n.Regression {
+		status = "REGRESSION"
+	}
+	return fmt.Sprintf("[cloudrun-benchmark] %s: run %s", status, n.RunID)
+}
+
+// Notifier sends a benchmark run's outcome somewhere a human or CI system
+// will see it. Notify failures are meant to be logged and swallowed by the
+// caller, not treated as benchmark failures.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// RegressionDetected reports whether any service's cold-start or warm
+// latency ratio in comparison exceeds 1+thresholdPct/100, i.e. Cloud Run
+// is more than thresholdPct percent slower than the local baseline.
+func RegressionDetected(comparison *ComparisonReport, thresholdPct float64) bool {
+	if comparison == nil || thresholdPct <= 0 {
+		return false
+	}
+
+	limit := 1 + thresholdPct/100
+	for _, cmp := range comparison.Services {
+		if cmp.ColdStartRatio > limit || cmp.WarmLatencyRatio > limit {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailNotifier sends notifications over SMTP, attaching the given files
+// (normally results.md and comparison.md) to a plain-text message.
+type EmailNotifier struct {
+	SMTPAddr string // host:port
+	Auth     smtp.Auth
+	From     string
+	To       []string
+}
+
+// NewEmailNotifier creates an EmailNotifier. username/password may be empty
+// for an SMTP relay that doesn't require authentication.
+func NewEmailNotifier(smtpAddr, username, password, from string, to []string) *EmailNotifier {
+	var auth smtp.Auth
+	if username != "" {
+		host := smtpAddr
+		if i := strings.IndexByte(host, ':'); i >= 0 {
+			host = host[:i]
+		}
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &EmailNotifier{SMTPAddr: smtpAddr, Auth: auth, From: from, To: to}
+}
+
+// Notify sends a MIME multipart email with n.Summary as the body and
+// n.Attachments attached as files.
+func (e *EmailNotifier) Notify(ctx context.Context, n Notification) error {
+	msg, err := e.buildMessage(n)
+	if err != nil {
+		return fmt.Errorf("building notification email: %w", err)
+	}
+
+	if err := smtp.SendMail(e.SMTPAddr, e.Auth, e.From, e.To, msg); err != nil {
+		return fmt.Errorf("sending notification email: %w", err)
+	}
+	return nil
+}
+
+func (e *EmailNotifier) buildMessage(n Notification) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	fmt.Fprintf(&body, "From: %s\r\n", e.From)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n", n.Subject())
+	fmt.Fprintf(&body, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&body, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary())
+
+	textHeader := textproto.MIMEHeader{"Content-Type": {"text/markdown; charset=utf-8"}}
+	textPart, err := writer.CreatePart(textHeader)
+	if err != nil {
+		return nil, fmt.Errorf("creating text part: %w", err)
+	}
+	if _, err := textPart.Write([]byte(n.Summary)); err != nil {
+		return nil, fmt.Errorf("writing text part: %w", err)
+	}
+
+	for _, path := range n.Attachments {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue // best-effort: a missing attachment shouldn't block the email
+		}
+
+		header := textproto.MIMEHeader{
+			"Content-Type":              {"text/markdown"},
+			"Content-Disposition":       {fmt.Sprintf(`attachment; filename="%s"`, filepath.Base(path))},
+			"Content-Transfer-Encoding": {"8bit"},
+		}
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("creating attachment part for %s: %w", path, err)
+		}
+		if _, err := part.Write(data); err != nil {
+			return nil, fmt.Errorf("writing attachment %s: %w", path, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("closing MIME writer: %w", err)
+	}
+
+	return body.Bytes(), nil
+}
+
+// WebhookNotifier POSTs the benchmark result and signed artifact URLs as
+// JSON to a generic HTTP endpoint (Slack incoming webhooks, a CI callback, etc).
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookNotifier creates a WebhookNotifier with a sane request timeout.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// webhookPayload is the JSON body POSTed to the webhook URL.
+type webhookPayload struct {
+	RunID        string                     `json:"run_id"`
+	Regression   bool                       `json:"regression"`
+	Summary      string                     `json:"summary"`
+	Result       *benchmark.BenchmarkResult `json:"result"`
+	ArtifactURLs []string                   `json:"artifact_urls,omitempty"`
+}
+
+// Notify POSTs n as JSON to w.URL.
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	payload := webhookPayload{
+		RunID:        n.RunID,
+		Regression:   n.Regression,
+		Summary:      n.Summary,
+		Result:       n.Result,
+		ArtifactURLs: n.ArtifactURLs,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
+)
+
+// NDJSONProgressReporter writes gcp.ProgressReporter calls as
+// newline-delimited JSON, one line per phase, byte update, or poll, so
+// CI logs capture Deploy and upload progress without needing a
+// terminal. It implements gcp.ProgressReporter.
+type NDJSONProgressReporter struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+// progressLine is the NDJSON shape for a single ProgressReporter call;
+// only the fields relevant to Type are populated.
+type progressLine struct {
+	Type     string        `json:"type"`
+	Phase    string        `json:"phase,omitempty"`
+	Op       string        `json:"op,omitempty"`
+	Path     string        `json:"path,omitempty"`
+	Uploaded int64         `json:"uploaded,omitempty"`
+	Total    int64         `json:"total,omitempty"`
+	Elapsed  time.Duration `json:"elapsed,omitempty"`
+}
+
+// NewNDJSONProgressReporter opens path for streaming progress output.
+// The special path "-" writes to stdout instead of a file.
+func NewNDJSONProgressReporter(path string) (*NDJSONProgressReporter, error) {
+	if path == "-" {
+		return &NDJSONProgressReporter{enc: json.NewEncoder(os.Stdout)}, nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating progress file: %w", err)
+	}
+
+	return &NDJSONProgressReporter{enc: json.NewEncoder(f), closer: f}, nil
+}
+
+var _ gcp.ProgressReporter = (*NDJSONProgressReporter)(nil)
+
+// OnPhase writes a "phase" line.
+func (r *NDJSONProgressReporter) OnPhase(phase string) {
+	r.emit(progressLine{Type: "phase", Phase: phase})
+}
+
+// OnBytes writes a "bytes" line.
+func (r *NDJSONProgressReporter) OnBytes(path string, uploaded, total int64) {
+	r.emit(progressLine{Type: "bytes", Path: path, Uploaded: uploaded, Total: total})
+}
+
+// OnPoll writes a "poll" line.
+func (r *NDJSONProgressReporter) OnPoll(op string, elapsed time.Duration) {
+	r.emit(progressLine{Type: "poll", Op: op, Elapsed: elapsed})
+}
+
+func (r *NDJSONProgressReporter) emit(line progressLine) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_ = r.enc.Encode(line)
+}
+
+// Close flushes and closes the underlying file, if any (stdout is left open).
+func (r *NDJSONProgressReporter) Close() error {
+	if r.closer != nil {
+		return r.closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package report
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/latency"
+)
+
+// WritePrometheus writes benchmark results in Prometheus text exposition
+// format, suitable for scraping or loading into a Pushgateway.
+func WritePrometheus(result *benchmark.BenchmarkResult, path string) error {
+	data := renderPrometheus(result)
+
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	return nil
+}
+
+// ServeMetrics starts an HTTP server exposing the benchmark result on /metrics
+// in Prometheus exposition format. It blocks until the server returns an error
+// (e.g. addr already in use) or the process is terminated.
+func ServeMetrics(result *benchmark.BenchmarkResult, addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		fmt.Fprint(w, renderPrometheus(result))
+	})
+
+	return http.ListenAndServe(addr, mux)
+}
+
+// renderPrometheus builds the Prometheus text exposition body for a result.
+func renderPrometheus(result *benchmark.BenchmarkResult) string {
+	profile := result.Config.GetProfile("default")
+
+	var sb strings.Builder
+
+	sb.WriteString("# HELP cloudrun_benchmark_info Static information about the benchmark run.\n")
+	sb.WriteString("# TYPE cloudrun_benchmark_info gauge\n")
+	fmt.Fprintf(&sb, "cloudrun_benchmark_info{run_id=%q,cpu=%q,memory=%q,execution_env=%q,startup_cpu_boost=%q} 1\n",
+		result.RunID, profile.CPU, profile.Memory, profile.ExecutionEnv, fmt.Sprintf("%t", profile.StartupCPUBoost))
+
+	sb.WriteString("# HELP cloudrun_deploy_duration_seconds Time taken to deploy a service.\n")
+	sb.WriteString("# TYPE cloudrun_deploy_duration_seconds gauge\n")
+
+	sb.WriteString("# HELP cloudrun_cold_start_ttfb_seconds Cold start time-to-first-byte quantiles.\n")
+	sb.WriteString("# TYPE cloudrun_cold_start_ttfb_seconds gauge\n")
+
+	sb.WriteString("# HELP cloudrun_cold_start_iterations_total Number of cold start iterations, by result.\n")
+	sb.WriteString("# TYPE cloudrun_cold_start_iterations_total counter\n")
+
+	sb.WriteString("# HELP cloudrun_warm_requests_per_second Throughput observed during the warm request phase.\n")
+	sb.WriteString("# TYPE cloudrun_warm_requests_per_second gauge\n")
+
+	sb.WriteString("# HELP cloudrun_warm_latency_seconds Warm request latency quantiles.\n")
+	sb.WriteString("# TYPE cloudrun_warm_latency_seconds gauge\n")
+
+	// Sort service names for stable output.
+	names := make([]string, 0, len(result.Services))
+	for name := range result.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		svc := result.Services[name]
+
+		fmt.Fprintf(&sb, "cloudrun_deploy_duration_seconds{service=%q} %f\n", name, svc.DeploymentDuration.Seconds())
+
+		if svc.ColdStart != nil {
+			cs := svc.ColdStart
+			fmt.Fprintf(&sb, "cloudrun_cold_start_ttfb_seconds{service=%q,quantile=\"0.5\"} %f\n", name, cs.TTFBP50.Seconds())
+			fmt.Fprintf(&sb, "cloudrun_cold_start_ttfb_seconds{service=%q,quantile=\"0.95\"} %f\n", name, cs.TTFBP95.Seconds())
+			fmt.Fprintf(&sb, "cloudrun_cold_start_ttfb_seconds{service=%q,quantile=\"0.99\"} %f\n", name, cs.TTFBP99.Seconds())
+			fmt.Fprintf(&sb, "cloudrun_cold_start_iterations_total{service=%q,result=\"success\"} %d\n", name, cs.SuccessCount)
+			fmt.Fprintf(&sb, "cloudrun_cold_start_iterations_total{service=%q,result=\"failure\"} %d\n", name, cs.FailureCount)
+		}
+
+		if svc.WarmRequest != nil {
+			wr := svc.WarmRequest
+			fmt.Fprintf(&sb, "cloudrun_warm_requests_per_second{service=%q} %f\n", name, wr.RequestsPerSecond)
+			fmt.Fprintf(&sb, "cloudrun_warm_latency_seconds{service=%q,quantile=\"0.5\"} %f\n", name, wr.P50.Seconds())
+			fmt.Fprintf(&sb, "cloudrun_warm_latency_seconds{service=%q,quantile=\"0.95\"} %f\n", name, wr.P95.Seconds())
+			fmt.Fprintf(&sb, "cloudrun_warm_latency_seconds{service=%q,quantile=\"0.99\"} %f\n", name, wr.P99.Seconds())
+		}
+	}
+
+	return sb.String()
+}
+
+// WriteOpenMetrics writes result in OpenMetrics text format (the `# EOF`-
+// terminated, `_total`-suffixed-counter successor to the Prometheus text
+// exposition format WritePrometheus produces), suitable for a one-shot
+// Pushgateway push after a run completes.
+func WriteOpenMetrics(result *benchmark.BenchmarkResult, path string) error {
+	var sb strings.Builder
+
+	names := make([]string, 0, len(result.Services))
+	for name := range result.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sb.WriteString("# TYPE cloudrun_deploy_duration_seconds gauge\n")
+	sb.WriteString("# UNIT cloudrun_deploy_duration_seconds seconds\n")
+	sb.WriteString("# TYPE cloudrun_cold_start_ttfb_seconds gauge\n")
+	sb.WriteString("# UNIT cloudrun_cold_start_ttfb_seconds seconds\n")
+	sb.WriteString("# TYPE cloudrun_bench_iterations counter\n")
+	sb.WriteString("# TYPE cloudrun_warm_requests_per_second gauge\n")
+	sb.WriteString("# TYPE cloudrun_warm_latency_seconds gauge\n")
+	sb.WriteString("# UNIT cloudrun_warm_latency_seconds seconds\n")
+
+	for _, name := range names {
+		svc := result.Services[name]
+
+		fmt.Fprintf(&sb, "cloudrun_deploy_duration_seconds{service=%q} %f\n", name, svc.DeploymentDuration.Seconds())
+
+		if svc.ColdStart != nil {
+			cs := svc.ColdStart
+			fmt.Fprintf(&sb, "cloudrun_cold_start_ttfb_seconds{service=%q,quantile=\"0.5\"} %f\n", name, cs.TTFBP50.Seconds())
+			fmt.Fprintf(&sb, "cloudrun_cold_start_ttfb_seconds{service=%q,quantile=\"0.95\"} %f\n", name, cs.TTFBP95.Seconds())
+			fmt.Fprintf(&sb, "cloudrun_cold_start_ttfb_seconds{service=%q,quantile=\"0.99\"} %f\n", name, cs.TTFBP99.Seconds())
+			fmt.Fprintf(&sb, "cloudrun_bench_iterations_total{service=%q,result=\"success\"} %d\n", name, cs.SuccessCount)
+			fmt.Fprintf(&sb, "cloudrun_bench_iterations_total{service=%q,result=\"failure\"} %d\n", name, cs.FailureCount)
+		}
+
+		if svc.WarmRequest != nil {
+			wr := svc.WarmRequest
+			fmt.Fprintf(&sb, "cloudrun_warm_requests_per_second{service=%q} %f\n", name, wr.RequestsPerSecond)
+			fmt.Fprintf(&sb, "cloudrun_warm_latency_seconds{service=%q,quantile=\"0.5\"} %f\n", name, wr.P50.Seconds())
+			fmt.Fprintf(&sb, "cloudrun_warm_latency_seconds{service=%q,quantile=\"0.95\"} %f\n", name, wr.P95.Seconds())
+			fmt.Fprintf(&sb, "cloudrun_warm_latency_seconds{service=%q,quantile=\"0.99\"} %f\n", name, wr.P99.Seconds())
+		}
+	}
+
+	sb.WriteString("# EOF\n")
+
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	return nil
+}
+
+// serviceMetrics accumulates one service's live observations for
+// PrometheusRegistry.
+type serviceMetrics struct {
+	deployDuration    time.Duration
+	coldStartHist      *latency.Histogram
+	coldStartSuccesses int64
+	coldStartFailures  int64
+	warmHist           *latency.Histogram
+	warmSuccesses      int64
+	warmFailures       int64
+}
+
+// PrometheusRegistry is a benchmark.MetricsSink that accumulates live
+// observations as a benchmark runs, so /metrics reflects progress
+// incrementally instead of only the final BenchmarkResult. Safe for
+// concurrent use.
+type PrometheusRegistry struct {
+	mu       sync.Mutex
+	services map[string]*serviceMetrics
+}
+
+// NewPrometheusRegistry creates an empty, ready-to-use PrometheusRegistry.
+func NewPrometheusRegistry() *PrometheusRegistry {
+	return &PrometheusRegistry{services: make(map[string]*serviceMetrics)}
+}
+
+func (p *PrometheusRegistry) service(name string) *serviceMetrics {
+	svc, ok := p.services[name]
+	if !ok {
+		svc = &serviceMetrics{coldStartHist: latency.NewHistogram(), warmHist: latency.NewHistogram()}
+		p.services[name] = svc
+	}
+	return svc
+}
+
+// ObserveDeployDuration implements benchmark.MetricsSink.
+func (p *PrometheusRegistry) ObserveDeployDuration(service string, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.service(service).deployDuration = d
+}
+
+// ObserveColdStart implements benchmark.MetricsSink.
+func (p *PrometheusRegistry) ObserveColdStart(service string, ttfb time.Duration, success bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	svc := p.service(service)
+	if success {
+		svc.coldStartSuccesses++
+		svc.coldStartHist.RecordValue(ttfb)
+	} else {
+		svc.coldStartFailures++
+	}
+}
+
+// ObserveWarmRequest implements benchmark.MetricsSink.
+func (p *PrometheusRegistry) ObserveWarmRequest(service string, requestLatency time.Duration, success bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	svc := p.service(service)
+	if success {
+		svc.warmSuccesses++
+		svc.warmHist.RecordValue(requestLatency)
+	} else {
+		svc.warmFailures++
+	}
+}
+
+// render builds the current Prometheus text exposition body.
+func (p *PrometheusRegistry) render() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var sb strings.Builder
+
+	sb.WriteString("# HELP cloudrun_deploy_duration_seconds Time taken to deploy a service.\n")
+	sb.WriteString("# TYPE cloudrun_deploy_duration_seconds gauge\n")
+	sb.WriteString("# HELP cloudrun_cold_start_ttfb_seconds Cold start time-to-first-byte quantiles.\n")
+	sb.WriteString("# TYPE cloudrun_cold_start_ttfb_seconds gauge\n")
+	sb.WriteString("# HELP cloudrun_bench_iterations_total Number of cold start iterations, by result.\n")
+	sb.WriteString("# TYPE cloudrun_bench_iterations_total counter\n")
+	sb.WriteString("# HELP cloudrun_warm_latency_seconds Warm request latency quantiles.\n")
+	sb.WriteString("# TYPE cloudrun_warm_latency_seconds gauge\n")
+
+	names := make([]string, 0, len(p.services))
+	for name := range p.services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		svc := p.services[name]
+
+		fmt.Fprintf(&sb, "cloudrun_deploy_duration_seconds{service=%q} %f\n", name, svc.deployDuration.Seconds())
+
+		if svc.coldStartHist.TotalCount() > 0 {
+			fmt.Fprintf(&sb, "cloudrun_cold_start_ttfb_seconds{service=%q,quantile=\"0.5\"} %f\n", name, svc.coldStartHist.ValueAtQuantile(50).Seconds())
+			fmt.Fprintf(&sb, "cloudrun_cold_start_ttfb_seconds{service=%q,quantile=\"0.95\"} %f\n", name, svc.coldStartHist.ValueAtQuantile(95).Seconds())
+			fmt.Fprintf(&sb, "cloudrun_cold_start_ttfb_seconds{service=%q,quantile=\"0.99\"} %f\n", name, svc.coldStartHist.ValueAtQuantile(99).Seconds())
+		}
+		fmt.Fprintf(&sb, "cloudrun_bench_iterations_total{service=%q,result=\"success\"} %d\n", name, svc.coldStartSuccesses)
+		fmt.Fprintf(&sb, "cloudrun_bench_iterations_total{service=%q,result=\"failure\"} %d\n", name, svc.coldStartFailures)
+
+		if svc.warmHist.TotalCount() > 0 {
+			fmt.Fprintf(&sb, "cloudrun_warm_latency_seconds{service=%q,quantile=\"0.5\"} %f\n", name, svc.warmHist.ValueAtQuantile(50).Seconds())
+			fmt.Fprintf(&sb, "cloudrun_warm_latency_seconds{service=%q,quantile=\"0.95\"} %f\n", name, svc.warmHist.ValueAtQuantile(95).Seconds())
+			fmt.Fprintf(&sb, "cloudrun_warm_latency_seconds{service=%q,quantile=\"0.99\"} %f\n", name, svc.warmHist.ValueAtQuantile(99).Seconds())
+		}
+	}
+
+	return sb.String()
+}
+
+// Serve exposes p on /metrics, updated live as observations arrive, until
+// addr fails to bind or the process is terminated.
+func (p *PrometheusRegistry) Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		fmt.Fprint(w, p.render())
+	})
+
+	return http.ListenAndServe(addr, mux)
+}
@@ -0,0 +1,146 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/gcp"
+)
+
+// resultRecord is the on-disk/on-wire shape both NDJSONResultSink and
+// PubSubResultSink write: a discriminated union keyed by Type, with only
+// the field matching Type populated. RunBatchResume reads back the "deploy"
+// and "cold_start" records it needs via its own, narrower resumeRecord
+// struct in internal/benchmark.
+type resultRecord struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Key       string    `json:"key,omitempty"`
+	Iteration int       `json:"iteration,omitempty"`
+
+	Deploy    *benchmark.DeployedService  `json:"deploy,omitempty"`
+	ColdStart *benchmark.ColdStartResult  `json:"cold_start,omitempty"`
+	Warm      *benchmark.WarmRequestStats `json:"warm,omitempty"`
+	Summary   *benchmark.BenchmarkResult  `json:"summary,omitempty"`
+}
+
+// NDJSONResultSink writes full-fidelity batch benchmark results as
+// newline-delimited JSON, one record per line, so a run can be resumed via
+// Runner.RunBatchResume if it's interrupted partway through. It implements
+// benchmark.ResultSink.
+type NDJSONResultSink struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+// NewNDJSONResultSink opens path for streaming result output. The special
+// path "-" writes to stdout instead of a file.
+func NewNDJSONResultSink(path string) (*NDJSONResultSink, error) {
+	if path == "-" {
+		return &NDJSONResultSink{enc: json.NewEncoder(os.Stdout)}, nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating results file: %w", err)
+	}
+
+	return &NDJSONResultSink{enc: json.NewEncoder(f), closer: f}, nil
+}
+
+// EmitDeploy writes a "deploy" record.
+func (s *NDJSONResultSink) EmitDeploy(key string, deployed *benchmark.DeployedService) {
+	s.write(resultRecord{Type: "deploy", Timestamp: time.Now(), Key: key, Deploy: deployed})
+}
+
+// EmitColdStart writes a "cold_start" record.
+func (s *NDJSONResultSink) EmitColdStart(iteration int, key string, result *benchmark.ColdStartResult) {
+	s.write(resultRecord{Type: "cold_start", Timestamp: time.Now(), Key: key, Iteration: iteration, ColdStart: result})
+}
+
+// EmitWarm writes a "warm" record.
+func (s *NDJSONResultSink) EmitWarm(key string, stats *benchmark.WarmRequestStats) {
+	s.write(resultRecord{Type: "warm", Timestamp: time.Now(), Key: key, Warm: stats})
+}
+
+// EmitSummary writes a "summary" record.
+func (s *NDJSONResultSink) EmitSummary(result *benchmark.BenchmarkResult) {
+	s.write(resultRecord{Type: "summary", Timestamp: time.Now(), Summary: result})
+}
+
+// write encodes rec as a line of JSON. It is safe for concurrent use, since
+// deployAll and testAllColdStart/testAllWarm emit from multiple goroutines.
+func (s *NDJSONResultSink) write(rec resultRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.enc.Encode(rec)
+}
+
+// Close flushes and closes the underlying file, if any (stdout is left open).
+func (s *NDJSONResultSink) Close() error {
+	if s.closer != nil {
+		return s.closer.Close()
+	}
+	return nil
+}
+
+// PubSubResultSink publishes full-fidelity batch benchmark results to the
+// same Pub/Sub topic a benchmark run's deployed services publish their own
+// events to, so an external subscriber can follow a run (or rebuild its
+// state for a resume) without access to the machine running it. It
+// implements benchmark.ResultSink.
+type PubSubResultSink struct {
+	ctx    context.Context
+	client *gcp.PubSubClient
+	cfg    gcp.PubSubConfig
+}
+
+// NewPubSubResultSink wraps client, publishing every emitted record to
+// cfg's topic. ctx is stored because benchmark.ResultSink's methods don't
+// take one themselves; it should be the same context the benchmark run
+// itself uses, so publishes are canceled along with the run.
+func NewPubSubResultSink(ctx context.Context, client *gcp.PubSubClient, cfg gcp.PubSubConfig) *PubSubResultSink {
+	return &PubSubResultSink{ctx: ctx, client: client, cfg: cfg}
+}
+
+// EmitDeploy publishes a "deploy" record.
+func (s *PubSubResultSink) EmitDeploy(key string, deployed *benchmark.DeployedService) {
+	s.publish(resultRecord{Type: "deploy", Timestamp: time.Now(), Key: key, Deploy: deployed})
+}
+
+// EmitColdStart publishes a "cold_start" record.
+func (s *PubSubResultSink) EmitColdStart(iteration int, key string, result *benchmark.ColdStartResult) {
+	s.publish(resultRecord{Type: "cold_start", Timestamp: time.Now(), Key: key, Iteration: iteration, ColdStart: result})
+}
+
+// EmitWarm publishes a "warm" record.
+func (s *PubSubResultSink) EmitWarm(key string, stats *benchmark.WarmRequestStats) {
+	s.publish(resultRecord{Type: "warm", Timestamp: time.Now(), Key: key, Warm: stats})
+}
+
+// EmitSummary publishes a "summary" record.
+func (s *PubSubResultSink) EmitSummary(result *benchmark.BenchmarkResult) {
+	s.publish(resultRecord{Type: "summary", Timestamp: time.Now(), Summary: result})
+}
+
+// publish marshals rec and publishes it, logging (rather than returning) any
+// failure, since ResultSink's methods have no error return for callers to
+// react to.
+func (s *PubSubResultSink) publish(rec resultRecord) {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		fmt.Printf("Warning: marshaling result record for Pub/Sub: %v\n", err)
+		return
+	}
+
+	if _, _, err := s.client.Publish(s.ctx, s.cfg, data, map[string]string{"type": rec.Type}); err != nil {
+		fmt.Printf("Warning: publishing result record: %v\n", err)
+	}
+}
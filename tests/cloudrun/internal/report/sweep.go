@@ -0,0 +1,74 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+)
+
+// sparkChars renders a value between min and max as one of eight Unicode
+// block characters, lowest to highest.
+var sparkChars = []rune("▁▂▃▄▅▆▇█")
+
+func sparkline(values []time.Duration) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	min, max := values[0], values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		if max == min {
+			sb.WriteRune(sparkChars[0])
+			continue
+		}
+		idx := int(float64(v-min) / float64(max-min) * float64(len(sparkChars)-1))
+		sb.WriteRune(sparkChars[idx])
+	}
+	return sb.String()
+}
+
+// WriteSweepMarkdown writes a time-series report of a revision sweep, with
+// a table of each revision's result and a sparkline per tracked metric.
+func WriteSweepMarkdown(results []*benchmark.RevisionResult, path string) error {
+	var sb strings.Builder
+
+	sb.WriteString("# Revision Sweep Results\n\n")
+	sb.WriteString(fmt.Sprintf("**Revisions:** %d\n\n", len(results)))
+
+	sb.WriteString("| Revision | Status | Cold Start P50 | Warm P50 |\n")
+	sb.WriteString("|----------|--------|-----------------|----------|\n")
+
+	var coldP50s, warmP50s []time.Duration
+	for _, r := range results {
+		if r.Error != nil {
+			sb.WriteString(fmt.Sprintf("| `%s` | failed | - | - |\n", r.Revision))
+			continue
+		}
+
+		cold := benchmark.MetricColdStartP50.Aggregate(r.Result)
+		warm := benchmark.MetricWarmP50.Aggregate(r.Result)
+		coldP50s = append(coldP50s, cold)
+		warmP50s = append(warmP50s, warm)
+
+		sb.WriteString(fmt.Sprintf("| `%s` | ok | %s | %s |\n", r.Revision, cold.Round(time.Millisecond), warm.Round(time.Millisecond)))
+	}
+
+	sb.WriteString("\n## Trend\n\n")
+	sb.WriteString(fmt.Sprintf("Cold start P50: `%s`\n\n", sparkline(coldP50s)))
+	sb.WriteString(fmt.Sprintf("Warm P50: `%s`\n\n", sparkline(warmP50s)))
+
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
@@ -0,0 +1,225 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"fmt"
+	"hash/crc32"
+	"math/rand"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// FileMismatch describes a single file that was uploaded but whose remote
+// copy no longer matches the local original.
+type FileMismatch struct {
+	Name   string
+	Reason string
+}
+
+// VerificationReport is the result of comparing a run's uploaded GCS
+// objects back against the local files that produced them.
+type VerificationReport struct {
+	Prefix       string
+	LocalOnly    []string
+	RemoteOnly   []string
+	Mismatches   []FileMismatch
+	SampledOK    []string
+	SampledCount int
+}
+
+// OK reports whether verification found no discrepancies.
+func (v *VerificationReport) OK() bool {
+	return len(v.LocalOnly) == 0 && len(v.RemoteOnly) == 0 && len(v.Mismatches) == 0
+}
+
+// VerifyUpload re-lists the objects under prefix, compares their size and
+// CRC32C/MD5 against the local files of the same name in localDir, and
+// re-downloads up to sampleSize of them to confirm byte-for-byte equality.
+// It catches truncated uploads and buckets whose retention/lifecycle rules
+// silently mutated an object after it was written.
+func (u *GCSUploader) VerifyUpload(ctx context.Context, prefix, localDir string, sampleSize int) (*VerificationReport, error) {
+	report := &VerificationReport{Prefix: prefix}
+
+	localFiles, err := os.ReadDir(localDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading local dir: %w", err)
+	}
+
+	local := make(map[string]string) // basename -> local path
+	for _, f := range localFiles {
+		if f.IsDir() {
+			continue
+		}
+		local[f.Name()] = filepath.Join(localDir, f.Name())
+	}
+
+	remote := make(map[string]*storage.ObjectAttrs) // basename -> attrs
+	it := u.client.Bucket(u.bucketName).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing uploaded objects: %w", err)
+		}
+		remote[path.Base(attrs.Name)] = attrs
+	}
+
+	var matched []string
+	for name, localPath := range local {
+		attrs, ok := remote[name]
+		if !ok {
+			report.LocalOnly = append(report.LocalOnly, name)
+			continue
+		}
+
+		data, err := os.ReadFile(localPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading local file %s: %w", name, err)
+		}
+
+		if int64(len(data)) != attrs.Size {
+			report.Mismatches = append(report.Mismatches, FileMismatch{
+				Name:   name,
+				Reason: fmt.Sprintf("size mismatch: local %d bytes, remote %d bytes", len(data), attrs.Size),
+			})
+			continue
+		}
+
+		if crc := crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli)); attrs.CRC32C != 0 && crc != attrs.CRC32C {
+			report.Mismatches = append(report.Mismatches, FileMismatch{
+				Name:   name,
+				Reason: fmt.Sprintf("CRC32C mismatch: local %08x, remote %08x", crc, attrs.CRC32C),
+			})
+			continue
+		}
+
+		if sum := md5.Sum(data); len(attrs.MD5) == len(sum) && !bytes.Equal(sum[:], attrs.MD5) {
+			report.Mismatches = append(report.Mismatches, FileMismatch{
+				Name:   name,
+				Reason: "MD5 mismatch",
+			})
+			continue
+		}
+
+		matched = append(matched, name)
+	}
+
+	for name := range remote {
+		if _, ok := local[name]; !ok {
+			report.RemoteOnly = append(report.RemoteOnly, name)
+		}
+	}
+
+	if sampleSize > len(matched) {
+		sampleSize = len(matched)
+	}
+	sampled := sampleNames(matched, sampleSize)
+
+	for _, name := range sampled {
+		attrs := remote[name]
+		downloaded, err := u.downloadObject(ctx, attrs.Name)
+		if err != nil {
+			return nil, fmt.Errorf("re-downloading %s: %w", name, err)
+		}
+
+		original, err := os.ReadFile(filepath.Join(localDir, name))
+		if err != nil {
+			return nil, fmt.Errorf("re-reading local file %s: %w", name, err)
+		}
+
+		if !bytes.Equal(downloaded, original) {
+			report.Mismatches = append(report.Mismatches, FileMismatch{
+				Name:   name,
+				Reason: "re-downloaded bytes differ from local file",
+			})
+			continue
+		}
+
+		report.SampledOK = append(report.SampledOK, name)
+	}
+	report.SampledCount = len(sampled)
+
+	return report, nil
+}
+
+// sampleNames picks n names from names at random, without replacement.
+func sampleNames(names []string, n int) []string {
+	if n <= 0 || len(names) == 0 {
+		return nil
+	}
+
+	shuffled := append([]string(nil), names...)
+	rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+	if n > len(shuffled) {
+		n = len(shuffled)
+	}
+	return shuffled[:n]
+}
+
+// downloadObject reads the full contents of a GCS object.
+func (u *GCSUploader) downloadObject(ctx context.Context, gcsPath string) ([]byte, error) {
+	reader, err := u.client.Bucket(u.bucketName).Object(gcsPath).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("opening object: %w", err)
+	}
+	defer reader.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(reader); err != nil {
+		return nil, fmt.Errorf("reading object: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteVerificationMarkdown renders a VerificationReport as Markdown.
+func WriteVerificationMarkdown(report *VerificationReport, path string) error {
+	var sb strings.Builder
+
+	sb.WriteString("# Upload Verification\n\n")
+	fmt.Fprintf(&sb, "Prefix: `%s`\n\n", report.Prefix)
+
+	if report.OK() {
+		sb.WriteString("All uploaded files matched their local originals.\n\n")
+	} else {
+		sb.WriteString("**Discrepancies found.**\n\n")
+	}
+
+	fmt.Fprintf(&sb, "Sampled %d file(s) for byte-for-byte re-download: %d confirmed identical.\n\n", report.SampledCount, len(report.SampledOK))
+
+	if len(report.LocalOnly) > 0 {
+		sb.WriteString("## Files only local (missing from GCS)\n\n")
+		for _, name := range report.LocalOnly {
+			fmt.Fprintf(&sb, "- %s\n", name)
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(report.RemoteOnly) > 0 {
+		sb.WriteString("## Files only remote (unexpected objects under prefix)\n\n")
+		for _, name := range report.RemoteOnly {
+			fmt.Fprintf(&sb, "- %s\n", name)
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(report.Mismatches) > 0 {
+		sb.WriteString("## Hash/size mismatches\n\n")
+		sb.WriteString("| File | Reason |\n|---|---|\n")
+		for _, m := range report.Mismatches {
+			fmt.Fprintf(&sb, "| %s | %s |\n", m.Name, m.Reason)
+		}
+		sb.WriteString("\n")
+	}
+
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
@@ -0,0 +1,31 @@
+package signing
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// NewSignerFromEnv builds a Signer based on the SIGNER environment
+// variable, so the same contract test suite or benchmark run can
+// exercise a locally-run function against the deterministic test key
+// (SIGNER=test, the default), a staging service with a real Discord
+// public key (SIGNER=file, key from SIGNER_KEY_FILE), or a production
+// Cloud Run revision backed by Cloud KMS (SIGNER=kms, key version from
+// SIGNER_KMS_KEY) just by changing environment variables.
+func NewSignerFromEnv(ctx context.Context) (Signer, error) {
+	switch mode := os.Getenv("SIGNER"); mode {
+	case "", "test":
+		return NewSigner(), nil
+	case "file":
+		return NewFileSignerFromEnv("SIGNER_KEY_FILE")
+	case "kms":
+		keyName := os.Getenv("SIGNER_KMS_KEY")
+		if keyName == "" {
+			return nil, fmt.Errorf("SIGNER_KMS_KEY is required when SIGNER=kms")
+		}
+		return NewKMSSigner(ctx, keyName)
+	default:
+		return nil, fmt.Errorf("unknown SIGNER %q: must be test, file, or kms", mode)
+	}
+}
@@ -0,0 +1,89 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// FileSigner is a Signer backed by an Ed25519 seed loaded from a file or
+// an environment variable, for exercising a staging service that's
+// configured with a real (non-test) Discord public key.
+type FileSigner struct {
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+}
+
+// NewFileSigner loads a 32-byte Ed25519 seed from path and derives a
+// FileSigner from it. The file's contents may be the hex-encoded seed or
+// the raw 32 bytes.
+func NewFileSigner(path string) (*FileSigner, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key file %s: %w", path, err)
+	}
+
+	seed, err := decodeSeed(raw)
+	if err != nil {
+		return nil, fmt.Errorf("decoding signing key file %s: %w", path, err)
+	}
+
+	return newFileSignerFromSeed(seed), nil
+}
+
+// NewFileSignerFromEnv builds a FileSigner from the envVar environment
+// variable, which may hold either a path to a key file or the
+// hex-encoded seed directly.
+func NewFileSignerFromEnv(envVar string) (*FileSigner, error) {
+	value := os.Getenv(envVar)
+	if value == "" {
+		return nil, fmt.Errorf("%s is not set", envVar)
+	}
+
+	if seed, err := hex.DecodeString(strings.TrimSpace(value)); err == nil && len(seed) == ed25519.SeedSize {
+		return newFileSignerFromSeed(seed), nil
+	}
+
+	return NewFileSigner(value)
+}
+
+func newFileSignerFromSeed(seed []byte) *FileSigner {
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	return &FileSigner{
+		privateKey: privateKey,
+		publicKey:  privateKey.Public().(ed25519.PublicKey),
+	}
+}
+
+func decodeSeed(raw []byte) ([]byte, error) {
+	trimmed := strings.TrimSpace(string(raw))
+	if seed, err := hex.DecodeString(trimmed); err == nil && len(seed) == ed25519.SeedSize {
+		return seed, nil
+	}
+	if len(raw) == ed25519.SeedSize {
+		return raw, nil
+	}
+	return nil, fmt.Errorf("expected a %d-byte seed, hex-encoded or raw, got %d bytes", ed25519.SeedSize, len(raw))
+}
+
+// PublicKeyHex returns the hex-encoded public key for DISCORD_PUBLIC_KEY env var.
+func (s *FileSigner) PublicKeyHex() string {
+	return hex.EncodeToString(s.publicKey)
+}
+
+// SignRequest signs a Discord interaction request body, returning the
+// signature (hex) and timestamp to use in request headers.
+func (s *FileSigner) SignRequest(body []byte) (signature string, timestamp string) {
+	timestamp = fmt.Sprintf("%d", time.Now().Unix())
+	return s.SignRequestWithTimestamp(body, timestamp), timestamp
+}
+
+// SignRequestWithTimestamp signs a request body with a specific timestamp.
+func (s *FileSigner) SignRequestWithTimestamp(body []byte, timestamp string) string {
+	message := append([]byte(timestamp), body...)
+	sig := ed25519.Sign(s.privateKey, message)
+	return hex.EncodeToString(sig)
+}
@@ -0,0 +1,114 @@
+package signing
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	kmspb "cloud.google.com/go/kms/apiv1/kmspb"
+)
+
+// KMSSigner is a Signer whose private key never leaves Cloud KMS; it
+// calls AsymmetricSign against an ED25519_SHA512 key version for every
+// request, for exercising a production Cloud Run revision configured
+// with a real, KMS-backed Discord public key.
+type KMSSigner struct {
+	client    *kms.KeyManagementClient
+	keyName   string // CryptoKeyVersion resource name
+	publicKey ed25519.PublicKey
+}
+
+// NewKMSSigner creates a KMSSigner for the CryptoKeyVersion named
+// keyName (projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*),
+// fetching and caching its public key.
+func NewKMSSigner(ctx context.Context, keyName string) (*KMSSigner, error) {
+	client, err := kms.NewKeyManagementClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating KMS client: %w", err)
+	}
+
+	resp, err := client.GetPublicKey(ctx, &kmspb.GetPublicKeyRequest{Name: keyName})
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("fetching KMS public key %s: %w", keyName, err)
+	}
+
+	publicKey, err := parseEd25519PublicKeyPEM(resp.Pem)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("parsing KMS public key %s: %w", keyName, err)
+	}
+
+	return &KMSSigner{client: client, keyName: keyName, publicKey: publicKey}, nil
+}
+
+// Close releases the underlying KMS client connection.
+func (s *KMSSigner) Close() error {
+	return s.client.Close()
+}
+
+// PublicKeyHex returns the hex-encoded public key for DISCORD_PUBLIC_KEY env var.
+func (s *KMSSigner) PublicKeyHex() string {
+	return hex.EncodeToString(s.publicKey)
+}
+
+// SignRequest signs a Discord interaction request body, returning the
+// signature (hex) and timestamp to use in request headers.
+func (s *KMSSigner) SignRequest(body []byte) (signature string, timestamp string) {
+	timestamp = fmt.Sprintf("%d", time.Now().Unix())
+	return s.SignRequestWithTimestamp(body, timestamp), timestamp
+}
+
+// SignRequestWithTimestamp signs body with timestamp via
+// KeyManagementClient.AsymmetricSign, hex-encoding the signature KMS
+// returns for Discord's X-Signature-Ed25519 header. Unlike KMS's ECDSA
+// algorithms, EC_SIGN_ED25519 already returns the raw 64-byte R||S
+// signature defined by RFC 8032 rather than an ASN.1 DER encoding, so no
+// conversion is needed. It returns an empty string on failure since Signer
+// has no error-returning signing method; callers exercising KMSSigner
+// should treat an empty signature as a hard failure for that request.
+func (s *KMSSigner) SignRequestWithTimestamp(body []byte, timestamp string) string {
+	message := append([]byte(timestamp), body...)
+	resp, err := s.client.AsymmetricSign(context.Background(), &kmspb.AsymmetricSignRequest{
+		Name: s.keyName,
+		Data: message,
+	})
+	if err != nil {
+		return ""
+	}
+
+	return formatKMSSignature(resp.Signature)
+}
+
+// formatKMSSignature hex-encodes a raw Ed25519 signature returned by
+// Cloud KMS's AsymmetricSign for an EC_SIGN_ED25519 key. It's split out
+// from SignRequestWithTimestamp so the encoding step can be exercised
+// without a live KMS connection.
+func formatKMSSignature(raw []byte) string {
+	return hex.EncodeToString(raw)
+}
+
+// parseEd25519PublicKeyPEM parses the PEM-encoded PKIX public key Cloud
+// KMS returns from GetPublicKey.
+func parseEd25519PublicKeyPEM(pemData string) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in KMS public key")
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing PKIX public key: %w", err)
+	}
+
+	key, ok := pub.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("KMS key is not an Ed25519 public key")
+	}
+	return key, nil
+}
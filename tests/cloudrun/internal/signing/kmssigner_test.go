@@ -0,0 +1,33 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+)
+
+// TestFormatKMSSignature_RoundTripsRawSignature asserts that a raw 64-byte
+// R||S Ed25519 signature - what Cloud KMS's AsymmetricSign actually
+// returns for an EC_SIGN_ED25519 key - is hex-encoded as-is, not treated
+// as an ASN.1 DER-encoded (R, S) pair the way KMS's ECDSA algorithms do.
+func TestFormatKMSSignature_RoundTripsRawSignature(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	raw := ed25519.Sign(priv, []byte("1700000000payload"))
+	if len(raw) != ed25519.SignatureSize {
+		t.Fatalf("fake signature length = %d, want %d", len(raw), ed25519.SignatureSize)
+	}
+
+	got := formatKMSSignature(raw)
+
+	decoded, err := hex.DecodeString(got)
+	if err != nil {
+		t.Fatalf("formatKMSSignature output isn't valid hex: %v", err)
+	}
+	if !bytes.Equal(decoded, raw) {
+		t.Errorf("decoded signature = %x, want %x (raw signature should round-trip unmodified)", decoded, raw)
+	}
+}
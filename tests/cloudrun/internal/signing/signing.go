@@ -18,14 +18,34 @@ const (
 	testSeed = "discord-bot-test-suite-ed25519-test-key-seed-v1"
 )
 
-// Signer provides methods for signing Discord interaction requests.
-type Signer struct {
+// Signer abstracts producing Discord request signatures, so the contract
+// tests and benchmark harness can sign requests against a deterministic
+// local key (TestSigner), a key loaded from disk or an env var
+// (FileSigner), or a Cloud KMS-backed key (KMSSigner) without changing
+// any calling code.
+type Signer interface {
+	// PublicKeyHex returns the hex-encoded Ed25519 public key for the
+	// DISCORD_PUBLIC_KEY env var.
+	PublicKeyHex() string
+	// SignRequest signs body with the current time, returning the
+	// signature (hex) and timestamp to use in request headers.
+	SignRequest(body []byte) (sig, ts string)
+	// SignRequestWithTimestamp signs body with a caller-supplied
+	// timestamp, for tests that need to control the X-Signature-Timestamp
+	// header directly.
+	SignRequestWithTimestamp(body []byte, ts string) string
+}
+
+// TestSigner is a Signer backed by a fixed, deterministic Ed25519 key
+// pair, for exercising a service without needing a real Discord
+// application or KMS key.
+type TestSigner struct {
 	privateKey ed25519.PrivateKey
 	publicKey  ed25519.PublicKey
 }
 
-// NewSigner creates a new Signer using the test key pair.
-func NewSigner() *Signer {
+// NewSigner creates a new TestSigner using the fixed test key pair.
+func NewSigner() *TestSigner {
 	// Derive a 32-byte seed from our fixed seed string
 	seed := sha256.Sum256([]byte(testSeed))
 
@@ -33,14 +53,14 @@ func NewSigner() *Signer {
 	privateKey := ed25519.NewKeyFromSeed(seed[:])
 	publicKey := privateKey.Public().(ed25519.PublicKey)
 
-	return &Signer{
+	return &TestSigner{
 		privateKey: privateKey,
 		publicKey:  publicKey,
 	}
 }
 
 // PublicKeyHex returns the hex-encoded public key for DISCORD_PUBLIC_KEY env var.
-func (s *Signer) PublicKeyHex() string {
+func (s *TestSigner) PublicKeyHex() string {
 	return hex.EncodeToString(s.publicKey)
 }
 
@@ -50,13 +70,13 @@ func (s *Signer) PublicKeyHex() string {
 // Headers to set:
 //   - X-Signature-Ed25519: signature
 //   - X-Signature-Timestamp: timestamp
-func (s *Signer) SignRequest(body []byte) (signature string, timestamp string) {
+func (s *TestSigner) SignRequest(body []byte) (signature string, timestamp string) {
 	timestamp = fmt.Sprintf("%d", time.Now().Unix())
 	return s.SignRequestWithTimestamp(body, timestamp), timestamp
 }
 
 // SignRequestWithTimestamp signs a request body with a specific timestamp.
-func (s *Signer) SignRequestWithTimestamp(body []byte, timestamp string) string {
+func (s *TestSigner) SignRequestWithTimestamp(body []byte, timestamp string) string {
 	// Discord signature format: sign(timestamp + body)
 	message := append([]byte(timestamp), body...)
 	sig := ed25519.Sign(s.privateKey, message)
@@ -91,3 +111,65 @@ func DiscordSlashCommandRequest() []byte {
 		}
 	}`)
 }
+
+// DiscordMessageComponentRequest returns a valid Discord message component
+// interaction request body (type 3), as sent when a user clicks a button or
+// picks a select menu option. The component/guild/channel/user IDs are test values.
+func DiscordMessageComponentRequest() []byte {
+	return []byte(`{
+		"type": 3,
+		"id": "123456789",
+		"application_id": "987654321",
+		"token": "test-token-redacted",
+		"guild_id": "111222333",
+		"channel_id": "444555666",
+		"message": {
+			"id": "555000111"
+		},
+		"member": {
+			"user": {
+				"id": "777888999",
+				"username": "testuser"
+			}
+		},
+		"data": {
+			"custom_id": "test-button",
+			"component_type": 2
+		}
+	}`)
+}
+
+// DiscordModalSubmitRequest returns a valid Discord modal submit
+// interaction request body (type 5), as sent when a user submits a modal
+// form. The component/guild/channel/user IDs are test values.
+func DiscordModalSubmitRequest() []byte {
+	return []byte(`{
+		"type": 5,
+		"id": "123456789",
+		"application_id": "987654321",
+		"token": "test-token-redacted",
+		"guild_id": "111222333",
+		"channel_id": "444555666",
+		"member": {
+			"user": {
+				"id": "777888999",
+				"username": "testuser"
+			}
+		},
+		"data": {
+			"custom_id": "test-modal",
+			"components": [
+				{
+					"type": 1,
+					"components": [
+						{
+							"type": 4,
+							"custom_id": "test-field",
+							"value": "test input"
+						}
+					]
+				}
+			]
+		}
+	}`)
+}
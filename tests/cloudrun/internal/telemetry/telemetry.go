@@ -0,0 +1,108 @@
+// Package telemetry wires the benchmark runner up to OpenTelemetry, so
+// cold-start and warm-request load generated by this tool can be
+// correlated with Cloud Run's own server-side traces in Cloud Trace.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/contrib/detectors/gcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/pmgledhill102/discord-bot-test-suite/tests/cloudrun/internal/benchmark"
+
+// Provider holds the tracer and meter used to instrument a benchmark run.
+// A zero-value Provider (returned by NewProvider with an empty endpoint)
+// hands out the global otel API's no-op tracer/meter, so call sites never
+// need to nil-check it.
+type Provider struct {
+	tracerProvider *sdktrace.TracerProvider
+	meterProvider  *sdkmetric.MeterProvider
+}
+
+// NewProvider configures an OTLP (gRPC) trace and metric exporter pointed
+// at endpoint, with Cloud Run/GCE resource attributes auto-detected via
+// the contrib GCP detector. If endpoint is empty, it returns a Provider
+// whose Tracer/Meter fall back to the global otel API's no-op
+// implementation, so instrumentation calls are free when telemetry isn't
+// configured.
+func NewProvider(ctx context.Context, serviceName, endpoint string) (*Provider, error) {
+	if endpoint == "" {
+		return &Provider{}, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithDetectors(gcp.NewDetector()),
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("detecting resource attributes: %w", err)
+	}
+
+	traceExporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+
+	metricExporter, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithEndpoint(endpoint), otlpmetricgrpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP metric exporter: %w", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithResource(res),
+	)
+
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
+		sdkmetric.WithResource(res),
+	)
+
+	return &Provider{tracerProvider: tracerProvider, meterProvider: meterProvider}, nil
+}
+
+// Tracer returns the tracer benchmark requests should create spans from.
+func (p *Provider) Tracer() trace.Tracer {
+	if p == nil || p.tracerProvider == nil {
+		return otel.Tracer(instrumentationName)
+	}
+	return p.tracerProvider.Tracer(instrumentationName)
+}
+
+// Meter returns the meter benchmark metrics should be recorded against.
+func (p *Provider) Meter() metric.Meter {
+	if p == nil || p.meterProvider == nil {
+		return otel.Meter(instrumentationName)
+	}
+	return p.meterProvider.Meter(instrumentationName)
+}
+
+// Shutdown flushes and closes the configured exporters. Safe to call on a
+// no-op Provider (endpoint was empty).
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	if p.tracerProvider != nil {
+		if err := p.tracerProvider.Shutdown(ctx); err != nil {
+			return fmt.Errorf("shutting down trace provider: %w", err)
+		}
+	}
+	if p.meterProvider != nil {
+		if err := p.meterProvider.Shutdown(ctx); err != nil {
+			return fmt.Errorf("shutting down meter provider: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package contract
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"testing/quick"
+)
+
+// FuzzInteractionEndpoint seeds the corpus with the malformed bodies the
+// TestError_* cases already exercise by hand, plus a valid PING and
+// APPLICATION_COMMAND payload, then mutates them looking for a body that
+// violates the endpoint's invariants: the response status must be one of
+// {200, 400, 401}, the body must be empty or valid JSON, the handler must
+// never panic, and any 2xx response to a PING (type 1) must echo type 1
+// back.
+func FuzzInteractionEndpoint(f *testing.F) {
+	f.Add([]byte(`{not valid json}`))
+	f.Add([]byte(``))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`[{"type": 1}]`))
+	f.Add([]byte(`{"id": "test-id", "application_id": "test-app"}`))
+	f.Add([]byte(`{"type": "invalid"}`))
+	f.Add([]byte(`{"type": -1}`))
+	f.Add([]byte(`{"type": 0}`))
+	f.Add([]byte(`{"type": 3, "id": "test-id", "application_id": "test-app"}`))
+	f.Add([]byte(`{"type": 1}`))
+	f.Add([]byte(`{"type": 2, "id": "test-id", "application_id": "test-app", "data": {"name": "ping"}}`))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		resp, respBody := sendRequest(t, body)
+
+		switch resp.StatusCode {
+		case http.StatusOK, http.StatusBadRequest, http.StatusUnauthorized:
+		default:
+			t.Fatalf("unexpected status code %d for body %q", resp.StatusCode, body)
+		}
+
+		if len(respBody) > 0 && !json.Valid(respBody) {
+			t.Fatalf("response body is neither empty nor valid JSON: %q", respBody)
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			var req InteractionRequest
+			if err := json.Unmarshal(body, &req); err == nil && req.Type == 1 {
+				response := parseResponse(t, respBody)
+				if response.Type != 1 {
+					t.Fatalf("PING request got 200 OK but response type %d, want 1 (Pong)", response.Type)
+				}
+			}
+		}
+	})
+}
+
+// TestInteractionRequest_JSONRoundTrip generates arbitrary InteractionRequest
+// values via testing/quick and confirms marshalling then unmarshalling
+// preserves them byte-for-byte, catching a field whose JSON encoding does not
+// survive decoding (for example mismatched tags) as a test failure. It checks
+// the struct against itself only, not against payloads Discord sends.
+func TestInteractionRequest_JSONRoundTrip(t *testing.T) {
+	roundTrips := func(req InteractionRequest) bool {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return false
+		}
+
+		var decoded InteractionRequest
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return false
+		}
+
+		redata, err := json.Marshal(decoded)
+		if err != nil {
+			return false
+		}
+
+		return string(data) == string(redata)
+	}
+
+	if err := quick.Check(roundTrips, nil); err != nil {
+		t.Errorf("InteractionRequest did not round-trip through JSON: %v", err)
+	}
+}
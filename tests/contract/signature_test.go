@@ -3,6 +3,7 @@ package contract
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/pmgledhill102/discord-bot-test-suite/tests/contract/testkeys"
 )
@@ -100,3 +101,91 @@ func TestSignature_WrongBodySigned(t *testing.T) {
 		t.Errorf("Expected status 401 Unauthorized for mismatched body, got %d", resp.StatusCode)
 	}
 }
+
+func TestSignature_ReplayedRequest(t *testing.T) {
+	req := createPingRequest()
+	body := toJSON(t, req)
+
+	signature, timestamp := testkeys.ReuseNonce(body)
+
+	first, _ := sendRequestWithHeaders(t, body, signature, timestamp)
+	if first.StatusCode != http.StatusOK {
+		t.Fatalf("Expected first request to succeed with 200 OK, got %d", first.StatusCode)
+	}
+
+	// Replay the exact same body+signature+timestamp. The endpoint verifies
+	// Ed25519 signatures statelessly and tracks no nonce/request-id, so a
+	// byte-identical replay within the timestamp window is expected to
+	// succeed again rather than being rejected.
+	second, _ := sendRequestWithHeaders(t, body, signature, timestamp)
+	if second.StatusCode != http.StatusOK {
+		t.Errorf("Expected replayed request to get 200 OK (no replay protection implemented), got %d", second.StatusCode)
+	}
+}
+
+func TestSignature_FutureTimestamp(t *testing.T) {
+	req := createPingRequest()
+	body := toJSON(t, req)
+
+	// A few seconds ahead of "now" due to clock skew between client and server.
+	futureTimestamp := testkeys.FutureTimestamp(3 * time.Second)
+	signature := testkeys.SignRequestWithTimestamp(body, futureTimestamp)
+	resp, _ := sendRequestWithHeaders(t, body, signature, futureTimestamp)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200 OK for a timestamp within clock-skew tolerance, got %d", resp.StatusCode)
+	}
+}
+
+func TestSignature_TimestampAtBoundary(t *testing.T) {
+	req := createPingRequest()
+	body := toJSON(t, req)
+
+	t.Run("just inside 5s window", func(t *testing.T) {
+		timestamp := testkeys.FutureTimestamp(-4*time.Second - 900*time.Millisecond)
+		signature := testkeys.SignRequestWithTimestamp(body, timestamp)
+		resp, _ := sendRequestWithHeaders(t, body, signature, timestamp)
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status 200 OK for a timestamp just inside the 5s window, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("just outside 5s window", func(t *testing.T) {
+		// Timestamps are whole seconds, so 6s is the first age that is
+		// outside the 5s window whatever fraction of a second "now" is at.
+		timestamp := testkeys.FutureTimestamp(-6 * time.Second)
+		signature := testkeys.SignRequestWithTimestamp(body, timestamp)
+		resp, _ := sendRequestWithHeaders(t, body, signature, timestamp)
+
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected status 401 Unauthorized for a timestamp just outside the 5s window, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestSignature_TruncatedSignature(t *testing.T) {
+	req := createPingRequest()
+	body := toJSON(t, req)
+
+	signature, timestamp := testkeys.SignRequest(body)
+	truncated := signature[:len(signature)/2]
+	resp, _ := sendRequestWithHeaders(t, body, truncated, timestamp)
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 Unauthorized for a truncated signature, got %d", resp.StatusCode)
+	}
+}
+
+func TestSignature_ExtraLongSignature(t *testing.T) {
+	req := createPingRequest()
+	body := toJSON(t, req)
+
+	signature, timestamp := testkeys.SignRequest(body)
+	tooLong := signature + "deadbeef"
+	resp, _ := sendRequestWithHeaders(t, body, tooLong, timestamp)
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 Unauthorized for an overlong signature, got %d", resp.StatusCode)
+	}
+}
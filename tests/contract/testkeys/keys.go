@@ -1,62 +1,193 @@
 // Package testkeys provides deterministic Ed25519 key pairs for contract testing.
 //
-// The keys are derived from a fixed seed to ensure reproducibility across test runs.
-// Services under test must be configured with the TestPublicKeyHex value.
+// Keys are derived from fixed seeds to ensure reproducibility across test
+// runs. Services under test must be configured with an Identity's
+// PublicKeyHex value.
 package testkeys
 
 import (
 	"crypto/ed25519"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 )
 
-const (
-	// testSeed is a fixed seed for deterministic key generation.
-	// DO NOT use these keys in production - they are for testing only.
-	testSeed = "discord-bot-test-suite-ed25519-test-key-seed-v1"
-)
+// defaultSeed is the fixed seed DefaultKeyring derives its sole identity
+// from. DO NOT use these keys in production - they are for testing only.
+const defaultSeed = "discord-bot-test-suite-ed25519-test-key-seed-v1"
+
+// Identity is a single Ed25519 signing identity within a Keyring: its own
+// key pair, independent of any other identity the Keyring holds.
+type Identity struct {
+	// Name identifies this identity within its Keyring (see
+	// Keyring.Identity and the JWKS "kid" Keyring.JWKS emits). It's the
+	// seed string the identity was derived from.
+	Name string
+
+	PrivateKey ed25519.PrivateKey
+	PublicKey  ed25519.PublicKey
+}
+
+// newIdentity derives an Identity's key pair from seed via SHA-256, the
+// same scheme the original single-key testSeed used.
+func newIdentity(seed string) Identity {
+	sum := sha256.Sum256([]byte(seed))
+	priv := ed25519.NewKeyFromSeed(sum[:])
+	return Identity{
+		Name:       seed,
+		PrivateKey: priv,
+		PublicKey:  priv.Public().(ed25519.PublicKey),
+	}
+}
+
+// PublicKeyHex returns the hex-encoded public key for DISCORD_PUBLIC_KEY env var.
+func (id Identity) PublicKeyHex() string {
+	return hex.EncodeToString(id.PublicKey)
+}
+
+// SignRequest signs a Discord interaction request body with id's private
+// key. Returns the signature and timestamp to use in request headers.
+//
+// Headers to set:
+//   - X-Signature-Ed25519: signature (hex-encoded)
+//   - X-Signature-Timestamp: timestamp
+func (id Identity) SignRequest(body []byte) (signature string, timestamp string) {
+	timestamp = fmt.Sprintf("%d", time.Now().Unix())
+	return id.SignRequestWithTimestamp(body, timestamp), timestamp
+}
+
+// SignRequestWithTimestamp signs a request body with a specific timestamp.
+// This is useful for testing expired timestamp scenarios.
+func (id Identity) SignRequestWithTimestamp(body []byte, timestamp string) string {
+	// Discord signature format: sign(timestamp + body)
+	message := append([]byte(timestamp), body...)
+	sig := ed25519.Sign(id.PrivateKey, message)
+	return hex.EncodeToString(sig)
+}
+
+// Keyring holds one or more named Identity values and tracks which one is
+// currently active, for contract tests exercising key rotation or a
+// staging matrix of multiple bot identities.
+type Keyring struct {
+	mu         sync.Mutex
+	identities []Identity
+	byName     map[string]*Identity
+	activeIdx  int
+}
+
+// NewKeyring derives one Identity per seed, in order, and activates the
+// first one. Passing more than one seed lets Rotate cycle between them;
+// passing one makes Rotate a no-op, matching the single fixed key the
+// package previously exposed.
+func NewKeyring(seeds ...string) *Keyring {
+	k := &Keyring{
+		identities: make([]Identity, len(seeds)),
+		byName:     make(map[string]*Identity, len(seeds)),
+	}
+	for i, seed := range seeds {
+		k.identities[i] = newIdentity(seed)
+		k.byName[seed] = &k.identities[i]
+	}
+	return k
+}
+
+// Active returns the Keyring's currently active Identity.
+func (k *Keyring) Active() Identity {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.identities[k.activeIdx]
+}
+
+// Rotate advances the Keyring to the next Identity (wrapping around to
+// the first once the last is reached) and returns it. A single-identity
+// Keyring rotates to itself.
+func (k *Keyring) Rotate() Identity {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.activeIdx = (k.activeIdx + 1) % len(k.identities)
+	return k.identities[k.activeIdx]
+}
+
+// Identity returns the identity derived from the seed named name, and
+// whether it was found.
+func (k *Keyring) Identity(name string) (Identity, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	id, ok := k.byName[name]
+	if !ok {
+		return Identity{}, false
+	}
+	return *id, true
+}
+
+// jwk is one entry in the JSON Web Key Set Keyring.JWKS emits, per
+// RFC 8037's OKP key type for Ed25519.
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+}
+
+// JWKS renders every identity in the Keyring as a JSON Web Key Set, so a
+// contract test can stand up a mock JWKS endpoint for services that fetch
+// verification keys dynamically instead of reading a single env var.
+func (k *Keyring) JWKS() []byte {
+	k.mu.Lock()
+	identities := append([]Identity(nil), k.identities...)
+	k.mu.Unlock()
+
+	keys := make([]jwk, len(identities))
+	for i, id := range identities {
+		keys[i] = jwk{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(id.PublicKey),
+			Kid: id.Name,
+		}
+	}
+
+	doc, _ := json.Marshal(struct {
+		Keys []jwk `json:"keys"`
+	}{Keys: keys})
+	return doc
+}
+
+// DefaultKeyring is the single-identity Keyring the package-level
+// TestPrivateKey, SignRequest, etc. are thin aliases over, preserved for
+// backward compatibility with code written before Keyring existed.
+var DefaultKeyring = NewKeyring(defaultSeed)
 
 var (
 	// TestPrivateKey is the Ed25519 private key for signing test requests.
-	TestPrivateKey ed25519.PrivateKey
+	TestPrivateKey = DefaultKeyring.Active().PrivateKey
 
 	// TestPublicKey is the Ed25519 public key for verifying signatures.
-	TestPublicKey ed25519.PublicKey
+	TestPublicKey = DefaultKeyring.Active().PublicKey
 
 	// TestPublicKeyHex is the hex-encoded public key for DISCORD_PUBLIC_KEY env var.
-	TestPublicKeyHex string
+	TestPublicKeyHex = DefaultKeyring.Active().PublicKeyHex()
 )
 
-func init() {
-	// Derive a 32-byte seed from our fixed seed string
-	seed := sha256.Sum256([]byte(testSeed))
-
-	// Generate the key pair from the seed
-	TestPrivateKey = ed25519.NewKeyFromSeed(seed[:])
-	TestPublicKey = TestPrivateKey.Public().(ed25519.PublicKey)
-	TestPublicKeyHex = hex.EncodeToString(TestPublicKey)
-}
-
-// SignRequest signs a Discord interaction request body with the test private key.
-// Returns the signature and timestamp to use in request headers.
+// SignRequest signs a Discord interaction request body with DefaultKeyring's
+// active private key. Returns the signature and timestamp to use in request
+// headers.
 //
 // Headers to set:
 //   - X-Signature-Ed25519: signature (hex-encoded)
 //   - X-Signature-Timestamp: timestamp
 func SignRequest(body []byte) (signature string, timestamp string) {
-	timestamp = fmt.Sprintf("%d", time.Now().Unix())
-	return SignRequestWithTimestamp(body, timestamp), timestamp
+	return DefaultKeyring.Active().SignRequest(body)
 }
 
 // SignRequestWithTimestamp signs a request body with a specific timestamp.
 // This is useful for testing expired timestamp scenarios.
 func SignRequestWithTimestamp(body []byte, timestamp string) string {
-	// Discord signature format: sign(timestamp + body)
-	message := append([]byte(timestamp), body...)
-	sig := ed25519.Sign(TestPrivateKey, message)
-	return hex.EncodeToString(sig)
+	return DefaultKeyring.Active().SignRequestWithTimestamp(body, timestamp)
 }
 
 // ExpiredTimestamp returns a timestamp that is older than Discord's 5-second tolerance.
@@ -64,8 +195,75 @@ func ExpiredTimestamp() string {
 	return fmt.Sprintf("%d", time.Now().Add(-10*time.Second).Unix())
 }
 
+// FutureTimestamp returns a timestamp offset seconds into the future, for
+// testing how the endpoint handles clock skew ahead of the server's clock.
+func FutureTimestamp(offset time.Duration) string {
+	return fmt.Sprintf("%d", time.Now().Add(offset).Unix())
+}
+
+// ReuseNonce signs body once and returns its (signature, timestamp) pair, so
+// a test can send the same body, signature and timestamp twice and assert on
+// whatever replay behavior (or lack thereof) the endpoint implements.
+func ReuseNonce(body []byte) (signature string, timestamp string) {
+	return SignRequest(body)
+}
+
+// ReplayPair is an alias for ReuseNonce: it signs body once and returns a
+// (signature, timestamp) pair a test can send twice to check how the service
+// treats the second copy.
+func ReplayPair(body []byte) (sig string, ts string) {
+	return ReuseNonce(body)
+}
+
 // InvalidSignature returns a syntactically valid but incorrect signature.
 func InvalidSignature() string {
 	// Return a valid hex string of the right length but wrong value
 	return hex.EncodeToString(make([]byte, ed25519.SignatureSize))
 }
+
+// NonceSigner embeds a monotonically increasing nonce into every request it
+// signs, for contract tests against services that layer a nonce cache on
+// top of Discord's base signature verification. It wraps DefaultKeyring's
+// active identity and is not safe for concurrent use.
+type NonceSigner struct {
+	identity Identity
+	nonce    int64
+}
+
+// NewNonceSigner returns a NonceSigner using DefaultKeyring's active identity.
+func NewNonceSigner() *NonceSigner {
+	return &NonceSigner{identity: DefaultKeyring.Active()}
+}
+
+// SignWithNonce increments the signer's nonce counter, embeds it into body
+// as a top-level "nonce" field, and signs the resulting envelope the same
+// way SignRequest does. It returns the envelope alongside its signature and
+// timestamp, since the caller must send exactly the bytes that were signed.
+func (s *NonceSigner) SignWithNonce(body []byte) (envelope []byte, signature string, timestamp string, err error) {
+	s.nonce++
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, "", "", fmt.Errorf("decoding body as a JSON object: %w", err)
+	}
+
+	nonceField, err := json.Marshal(s.nonce)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("encoding nonce: %w", err)
+	}
+	fields["nonce"] = nonceField
+
+	envelope, err = json.Marshal(fields)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("encoding envelope: %w", err)
+	}
+
+	signature, timestamp = s.identity.SignRequest(envelope)
+	return envelope, signature, timestamp, nil
+}
+
+// LastNonce returns the nonce embedded by the most recent SignWithNonce
+// call, or 0 if SignWithNonce hasn't been called yet.
+func (s *NonceSigner) LastNonce() int64 {
+	return s.nonce
+}
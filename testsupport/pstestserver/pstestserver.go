@@ -0,0 +1,126 @@
+// Package pstestserver boots an in-process fake Pub/Sub server for
+// hermetic tests of gcp.PubSubClient and the Discord webhook contract
+// suite, neither of which should need PUBSUB_EMULATOR_HOST or real GCP
+// credentials to exercise publish/ack behavior.
+//
+// It wraps cloud.google.com/go/pubsub/pstest, which already provides the
+// in-process gRPC server, and adds the seeding/inspection/fault-injection
+// helpers this repo's tests need on top of it.
+package pstestserver
+
+import (
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub/pstest"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Server is an in-process fake Pub/Sub server bound to a random localhost
+// port. Create one with New, point a client at it with ClientOptions, and
+// Close it when the test finishes.
+type Server struct {
+	fake *pstest.Server
+
+	mu      sync.Mutex
+	pending map[string]bool // message ID -> seeded/published, not yet acked
+}
+
+// New starts a fake Pub/Sub server listening on a random localhost port.
+// Pass pstest.ServerReactorOption values (see ResourceExhaustedOnStreamingPull
+// and PublishDelay below) to inject faults or latency.
+func New(opts ...pstest.ServerReactorOption) *Server {
+	return &Server{
+		fake:    pstest.NewServer(opts...),
+		pending: make(map[string]bool),
+	}
+}
+
+// Close shuts down the fake server.
+func (s *Server) Close() error {
+	return s.fake.Close()
+}
+
+// ClientOptions returns the option.ClientOption slice a test passes to
+// gcp.NewPubSubClient's variadic opts to redirect it at this fake instead
+// of a real project: WithEndpoint points the client at the fake's
+// listener, WithoutAuthentication skips credential loading, and the
+// insecure dial option is required because the fake speaks plaintext gRPC.
+func (s *Server) ClientOptions() []option.ClientOption {
+	return []option.ClientOption{
+		option.WithEndpoint(s.fake.Addr),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	}
+}
+
+// Message is a single message to seed directly onto a topic, bypassing a
+// client.
+type Message struct {
+	Data       []byte
+	Attributes map[string]string
+}
+
+// SeedMessages publishes messages directly to topic so a test can set up
+// subscription state before calling PullMessages, without needing a live
+// publisher client.
+func (s *Server) SeedMessages(topic string, messages ...Message) {
+	for _, m := range messages {
+		id := s.fake.Publish(topic, m.Data, m.Attributes)
+		s.mu.Lock()
+		s.pending[id] = true
+		s.mu.Unlock()
+	}
+}
+
+// MarkAcked records that messageID has been acknowledged. gcp.PubSubClient's
+// PullMessages acks every message it receives; a test that wants to assert
+// on OutstandingAcks should call this from its own Receive callback:
+// OutstandingAcks changes only through MarkAcked, so a PullMessages call on
+// its own leaves it unchanged.
+func (s *Server) MarkAcked(messageID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pending, messageID)
+}
+
+// OutstandingAcks returns the IDs of messages seeded or published that
+// haven't been marked acked yet.
+func (s *Server) OutstandingAcks() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.pending))
+	for id := range s.pending {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ResourceExhaustedOnStreamingPull is a pstest.ServerReactorOption that
+// makes every StreamingPull call fail with codes.ResourceExhausted,
+// simulating a subscriber that has hit Pub/Sub's flow-control limits.
+func ResourceExhaustedOnStreamingPull() pstest.ServerReactorOption {
+	return pstest.WithErrorInjection("StreamingPull", codes.ResourceExhausted, "flow control: too many outstanding messages")
+}
+
+// delayReactor sleeps for Delay before letting the real handler run, to
+// simulate a slow upstream Pub/Sub without failing the call outright.
+type delayReactor struct {
+	Delay time.Duration
+}
+
+// React implements pstest.Reactor.
+func (r delayReactor) React(_ interface{}) (handled bool, ret interface{}, err error) {
+	time.Sleep(r.Delay)
+	return false, nil, nil
+}
+
+// PublishDelay is a pstest.ServerReactorOption that sleeps d before every
+// Publish call reaches the fake server's normal handling, for tests that
+// need to exercise a client's timeout/retry behavior.
+func PublishDelay(d time.Duration) pstest.ServerReactorOption {
+	return pstest.WithReactor("Publish", delayReactor{Delay: d})
+}
@@ -0,0 +1,34 @@
+package pstestserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeedMessagesAndOutstandingAcks(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	s.SeedMessages("projects/test-project/topics/t", Message{Data: []byte("hello")})
+
+	outstanding := s.OutstandingAcks()
+	if len(outstanding) != 1 {
+		t.Fatalf("expected 1 outstanding ack, got %d", len(outstanding))
+	}
+
+	s.MarkAcked(outstanding[0])
+	if got := s.OutstandingAcks(); len(got) != 0 {
+		t.Errorf("expected 0 outstanding acks after MarkAcked, got %d", len(got))
+	}
+}
+
+func TestPublishDelay(t *testing.T) {
+	s := New(PublishDelay(50 * time.Millisecond))
+	defer s.Close()
+
+	start := time.Now()
+	s.SeedMessages("projects/test-project/topics/t", Message{Data: []byte("slow")})
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected Publish to take at least 50ms, took %v", elapsed)
+	}
+}